@@ -9,6 +9,7 @@ require (
 	github.com/cidverse/cidverseutils/filesystem v0.1.2-0.20250627221305-78405635788a
 	github.com/cidverse/cidverseutils/zerologconfig v0.1.2-0.20260225205012-7328c766ce81
 	github.com/cidverse/go-ptr v0.0.0-20240331160646-489e694bebbf
+	github.com/cidverse/go-vcs v0.0.0-20260519220358-81ec25a7ed93
 	github.com/cidverse/go-vcsapp v0.0.0-20260529145719-f9f7a9ad977b
 	github.com/evanphx/json-patch/v5 v5.9.11
 	github.com/gosimple/slug v1.15.0
@@ -17,7 +18,6 @@ require (
 	github.com/pb33f/doctor v0.0.67
 	github.com/pb33f/libopenapi v0.36.6
 	github.com/sashabaranov/go-openai v1.41.2
-	github.com/shomali11/parallelizer v0.0.0-20220717173222-a6776fbf40a9
 	github.com/speakeasy-api/openapi v1.23.0
 	github.com/spf13/cobra v1.10.2
 	github.com/stretchr/testify v1.11.1
@@ -34,7 +34,6 @@ require (
 	github.com/buger/jsonparser v1.2.0 // indirect
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/charlievieth/fastwalk v1.0.14 // indirect
-	github.com/cidverse/go-vcs v0.0.0-20260519220358-81ec25a7ed93 // indirect
 	github.com/cloudflare/circl v1.6.3 // indirect
 	github.com/cyphar/filepath-securejoin v0.6.1 // indirect
 	github.com/davecgh/go-spew v1.1.2-0.20180830191138-d8f796af33cc // indirect
@@ -23,6 +23,8 @@ type Configuration struct {
 	Presets    PresetConf      `yaml:"presets"`    // Presets are pre-configured generators for specific languages
 
 	Spec Spec `yaml:"spec"`
+
+	Changelog ChangelogConf `yaml:"changelog"`
 }
 
 func (c Configuration) HasGenerator() bool {
@@ -68,12 +70,21 @@ type Link struct {
 	URL  string `yaml:"url"`
 }
 
+// ChangelogConf maintains a changelog with the api changes in the generated repository
+type ChangelogConf struct {
+	Enabled       bool   `yaml:"enabled"`       // Enable the changelog
+	File          string `yaml:"file"`          // File is the path to the changelog, defaults to CHANGELOG.md
+	OperationLink string `yaml:"operationLink"` // OperationLink is the link for operation ids, supports the {operationId} placeholder
+	ServiceLink   string `yaml:"serviceLink"`   // ServiceLink is the link for the affected services, supports the {service} placeholder
+}
+
 type GeneratorConf struct {
-	Enabled   bool                   `yaml:"enabled"`   // Enable the generator
-	Name      string                 `yaml:"name"`      // Name of the generator
-	Type      GeneratorType          `yaml:"type"`      // Type of the generator
-	Arguments []string               `yaml:"arguments"` // Arguments that are passed to the generator command
-	Config    map[string]interface{} `yaml:"config"`    // Config that is passed to the generator
+	Enabled     bool                   `yaml:"enabled"`     // Enable the generator
+	Name        string                 `yaml:"name"`        // Name of the generator
+	Type        GeneratorType          `yaml:"type"`        // Type of the generator
+	Arguments   []string               `yaml:"arguments"`   // Arguments that are passed to the generator command
+	Config      map[string]interface{} `yaml:"config"`      // Config that is passed to the generator
+	TemplateDir string                 `yaml:"templateDir"` // TemplateDir is a template directory for primecodegen generators, relative to the config file
 }
 
 // PresetConf are pre-configured generators for specific languages
@@ -84,6 +95,7 @@ type PresetConf struct {
 	Kotlin        KotlinLanguageOptions     `yaml:"kotlin"`
 	Python        PythonLanguageOptions     `yaml:"python"`
 	CSharp        CSharpLanguageOptions     `yaml:"csharp"`
+	Rust          RustLanguageOptions       `yaml:"rust"`
 	Typescript    TypescriptLanguageOptions `yaml:"typescript"`
 	PrintingPress PrintingPressOptions      `yaml:"printingpress"`
 	LLMs          LLMsOptions               `yaml:"llms"`
@@ -108,6 +120,12 @@ func (c PresetConf) EnabledCount() int {
 	if c.Python.Enabled {
 		enabledCount++
 	}
+	if c.CSharp.Enabled {
+		enabledCount++
+	}
+	if c.Rust.Enabled {
+		enabledCount++
+	}
 	if c.Typescript.Enabled {
 		enabledCount++
 	}
@@ -170,6 +188,16 @@ type PythonLanguageOptions struct {
 type CSharpLanguageOptions struct {
 	Enabled     bool     `yaml:"enabled"`
 	IgnoreFiles []string `yaml:"ignoreFiles"`
+
+	Namespace string `yaml:"namespace"`
+	PackageId string `yaml:"packageId"`
+}
+
+type RustLanguageOptions struct {
+	Enabled     bool     `yaml:"enabled"`
+	IgnoreFiles []string `yaml:"ignoreFiles"`
+
+	CrateName string `yaml:"crateName"`
 }
 
 type TypescriptLanguageOptions struct {
@@ -203,6 +231,8 @@ type Spec struct {
 	InputPatches []sharedpatch.SpecPatch `yaml:"inputPatches"`
 	// PatchSets are the named patch sets that are applied to the specification
 	PatchSets []openapipatch.PatchSet `yaml:"patchSets"`
+	// PatchSetDefinitions are user-defined patch sets that can be used in PatchSets in addition to the builtin sets
+	PatchSetDefinitions []openapipatch.PatchPreset `yaml:"patchSetDefinitions"`
 	// Patches are the patches that are applied to the specification
 	Patches []sharedpatch.SpecPatch `yaml:"patches"`
 }
@@ -265,12 +295,20 @@ func LoadConfig(content string) (Configuration, error) {
 	if config.Spec.File == "" {
 		config.Spec.File = "openapi.yaml"
 	}
+	if config.Changelog.File == "" {
+		config.Changelog.File = "CHANGELOG.md"
+	}
 	for i := range config.Spec.InputPatches {
 		defaultPatchType(&config.Spec.InputPatches[i])
 	}
 	for i := range config.Spec.Patches {
 		defaultPatchType(&config.Spec.Patches[i])
 	}
+	for i := range config.Spec.PatchSetDefinitions {
+		for j := range config.Spec.PatchSetDefinitions[i].Patches {
+			defaultPatchType(&config.Spec.PatchSetDefinitions[i].Patches[j])
+		}
+	}
 	for i := range config.Spec.Sources {
 		for j := range config.Spec.Sources[i].Patches {
 			defaultPatchType(&config.Spec.Sources[i].Patches[j])
@@ -2,6 +2,7 @@ package generator
 
 import (
 	"os"
+	"path/filepath"
 
 	"github.com/primelib/primecodegen/pkg/app/appconf"
 	"github.com/primelib/primecodegen/pkg/openapi/openapicmd"
@@ -18,6 +19,7 @@ type PrimeCodeGenGenerator struct {
 type PrimeCodeGenGeneratorConfig struct {
 	TemplateLanguage string                   `json:"templateLanguage" yaml:"templateLanguage"`
 	TemplateType     string                   `json:"templateType" yaml:"templateType"`
+	TemplateDir      string                   `json:"templateDir" yaml:"templateDir"`
 	Patches          []string                 `json:"patches" yaml:"patches"`
 	GroupId          string                   `json:"groupId" yaml:"groupId"`
 	ArtifactId       string                   `json:"artifactId" yaml:"artifactId"`
@@ -51,6 +53,12 @@ func (n *PrimeCodeGenGenerator) Generate(opts GenerateOptions) error {
 }
 
 func (n *PrimeCodeGenGenerator) generateCode(opts GenerateOptions) error {
+	// template dir is relative to the project
+	templateDir := n.Config.TemplateDir
+	if templateDir != "" && !filepath.IsAbs(templateDir) {
+		templateDir = filepath.Join(opts.ProjectDirectory, templateDir)
+	}
+
 	// generate
 	return openapicmd.Generate(n.APISpec, n.Config.Patches, n.Config.TemplateLanguage, n.Config.TemplateType, opts.OutputDirectory, openapigenerator.GenerateOpts{
 		ArtifactGroupId:  n.Config.GroupId,
@@ -58,8 +66,10 @@ func (n *PrimeCodeGenGenerator) generateCode(opts GenerateOptions) error {
 		RepositoryUrl:    n.Config.Repository.URL,
 		LicenseName:      n.Config.Repository.LicenseName,
 		LicenseUrl:       n.Config.Repository.LicenseURL,
+		Maintainers:      n.Config.Maintainers,
 		Provider:         n.Config.Provider,
 		GeneratorNames:   n.Config.GeneratorNames,
 		GeneratorOutputs: n.Config.GeneratorOutputs,
+		TemplateDir:      templateDir,
 	})
 }
@@ -53,6 +53,13 @@ func Generators(specFile string, conf appconf.Configuration) []generator.Generat
 		Provider:    conf.Provider,
 		Opts:        conf.Presets.CSharp,
 	})
+	generators = addGeneratorIfEnabled(generators, conf.Presets.Rust.Enabled, &RustLibraryGenerator{
+		APISpec:     specFile,
+		Repository:  conf.Repository,
+		Maintainers: conf.Maintainers,
+		Provider:    conf.Provider,
+		Opts:        conf.Presets.Rust,
+	})
 	generators = addGeneratorIfEnabled(generators, conf.Presets.Typescript.Enabled, &TypeScriptLibraryGenerator{
 		APISpec:     specFile,
 		Repository:  conf.Repository,
@@ -103,6 +110,7 @@ func Generators(specFile string, conf appconf.Configuration) []generator.Generat
 				Config: generator.PrimeCodeGenGeneratorConfig{
 					TemplateLanguage: util.GetMapString(g.Config, "templateLanguage", ""),
 					TemplateType:     util.GetMapString(g.Config, "templateType", ""),
+					TemplateDir:      g.TemplateDir,
 					Patches:          util.GetMapSliceString(g.Config, "patches", []string{}),
 					GroupId:          util.GetMapString(g.Config, "groupId", ""),
 					ArtifactId:       util.GetMapString(g.Config, "artifactId", ""),
@@ -5,6 +5,7 @@ import (
 
 	"github.com/primelib/primecodegen/pkg/app/appconf"
 	"github.com/primelib/primecodegen/pkg/app/generator"
+	openapi_csharp "github.com/primelib/primecodegen/pkg/generator/openapi-csharp"
 )
 
 type CSharpLibraryGenerator struct {
@@ -24,32 +25,40 @@ func (n *CSharpLibraryGenerator) GetOutputName() string {
 }
 
 func (n *CSharpLibraryGenerator) Generate(opts generator.GenerateOptions) error {
-	slog.Info("generating csharp library", "dir", opts.OutputDirectory, "spec", n.APISpec)
+	packageId := suggestNugetPackageId(n.Opts.PackageId, n.Repository)
+	namespace := n.Opts.Namespace
+	if namespace == "" {
+		namespace = packageId
+	}
 
-	gen := generator.OpenAPIGenerator{
+	slog.With("dir", opts.OutputDirectory, "spec", n.APISpec).With("package", packageId, "namespace", namespace).Info("generating csharp library")
+	gen := generator.PrimeCodeGenGenerator{
 		OutputName: n.GetOutputName(),
 		APISpec:    n.APISpec,
-		Config: generator.OpenAPIGeneratorConfig{
-			GeneratorName:         "csharp",
-			EnablePostProcessFile: false,
-			GlobalProperty:        nil,
-			AdditionalProperties: map[string]interface{}{
-				"projectName": n.Repository.Name,
-			},
-			IgnoreFiles: []string{
-				"README.md",
-				".travis.yml",
-				"appveyor.yml",
-				".gitlab-ci.yml",
-				".gitignore",
-				"git_push.sh",
-				".github/*",
-				"docs/*",
-			},
-			Repository:  n.Repository,
-			Maintainers: n.Maintainers,
+		Args:       []string{},
+		Config: generator.PrimeCodeGenGeneratorConfig{
+			TemplateLanguage: "csharp",
+			TemplateType:     "httpclient",
+			Patches:          []string{},
+			GroupId:          namespace,
+			ArtifactId:       packageId,
+			Repository:       n.Repository,
+			Maintainers:      n.Maintainers,
+			Provider:         n.Provider,
 		},
 	}
 
 	return gen.Generate(opts)
 }
+
+// suggestNugetPackageId returns the package id used on NuGet, which is also the default root namespace
+func suggestNugetPackageId(packageId string, repository appconf.RepositoryConf) string {
+	if packageId != "" {
+		return packageId
+	}
+	if name := openapi_csharp.ToNamespace(repository.Name); name != "" {
+		return name
+	}
+
+	return "UnknownPackage"
+}
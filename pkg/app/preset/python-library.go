@@ -5,6 +5,7 @@ import (
 
 	"github.com/primelib/primecodegen/pkg/app/appconf"
 	"github.com/primelib/primecodegen/pkg/app/generator"
+	"github.com/primelib/primecodegen/pkg/util"
 )
 
 type PythonLibraryGenerator struct {
@@ -24,37 +25,35 @@ func (n *PythonLibraryGenerator) GetOutputName() string {
 }
 
 func (n *PythonLibraryGenerator) Generate(opts generator.GenerateOptions) error {
-	slog.Info("generating python library", "dir", opts.OutputDirectory, "spec", n.APISpec)
+	packageName := suggestPypiPackageName(n.Opts.PypiPackageName, n.Repository)
 
-	gen := generator.OpenAPIGenerator{
+	slog.With("dir", opts.OutputDirectory, "spec", n.APISpec).With("package", packageName).Info("generating python library")
+	gen := generator.PrimeCodeGenGenerator{
 		OutputName: n.GetOutputName(),
 		APISpec:    n.APISpec,
-		Config: generator.OpenAPIGeneratorConfig{
-			GeneratorName:         "python",
-			EnablePostProcessFile: false,
-			GlobalProperty:        nil,
-			AdditionalProperties: map[string]interface{}{
-				"library":        "urllib3",
-				"projectName":    n.Repository.Name,
-				"packageName":    n.Opts.PypiPackageName,
-				"packageUrl":     n.Repository.URL,
-				"packageVersion": "",
-			},
-			IgnoreFiles: []string{
-				"README.md",
-				"tox.ini",
-				".travis.yml",
-				"appveyor.yml",
-				".gitlab-ci.yml",
-				".gitignore",
-				"git_push.sh",
-				".github/*",
-				"docs/*",
-			},
-			Repository:  n.Repository,
-			Maintainers: n.Maintainers,
+		Args:       []string{},
+		Config: generator.PrimeCodeGenGeneratorConfig{
+			TemplateLanguage: "python",
+			TemplateType:     "httpclient",
+			Patches:          []string{},
+			ArtifactId:       packageName,
+			Repository:       n.Repository,
+			Maintainers:      n.Maintainers,
+			Provider:         n.Provider,
 		},
 	}
 
 	return gen.Generate(opts)
 }
+
+// suggestPypiPackageName returns the distribution name used on PyPI, the import name is derived from it by the generator
+func suggestPypiPackageName(pypiPackageName string, repository appconf.RepositoryConf) string {
+	if pypiPackageName != "" {
+		return pypiPackageName
+	}
+	if name := util.ToSlug(repository.Name); name != "" {
+		return name
+	}
+
+	return "unknown-package"
+}
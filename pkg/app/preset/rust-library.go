@@ -0,0 +1,59 @@
+package preset
+
+import (
+	"log/slog"
+
+	"github.com/primelib/primecodegen/pkg/app/appconf"
+	"github.com/primelib/primecodegen/pkg/app/generator"
+	openapi_rust "github.com/primelib/primecodegen/pkg/generator/openapi-rust"
+)
+
+type RustLibraryGenerator struct {
+	APISpec     string                      `json:"-" yaml:"-"`
+	Repository  appconf.RepositoryConf      `json:"-" yaml:"-"`
+	Maintainers []appconf.MaintainerConf    `json:"-" yaml:"-"`
+	Provider    appconf.ProviderConf        `json:"-" yaml:"-"`
+	Opts        appconf.RustLanguageOptions `json:"-" yaml:"-"`
+}
+
+func (n *RustLibraryGenerator) Name() string {
+	return "rust-httpclient"
+}
+
+func (n *RustLibraryGenerator) GetOutputName() string {
+	return "rust"
+}
+
+func (n *RustLibraryGenerator) Generate(opts generator.GenerateOptions) error {
+	crateName := suggestCrateName(n.Opts.CrateName, n.Repository)
+
+	slog.With("dir", opts.OutputDirectory, "spec", n.APISpec).With("crate", crateName).Info("generating rust library")
+	gen := generator.PrimeCodeGenGenerator{
+		OutputName: n.GetOutputName(),
+		APISpec:    n.APISpec,
+		Args:       []string{},
+		Config: generator.PrimeCodeGenGeneratorConfig{
+			TemplateLanguage: "rust",
+			TemplateType:     "httpclient",
+			Patches:          []string{},
+			ArtifactId:       crateName,
+			Repository:       n.Repository,
+			Maintainers:      n.Maintainers,
+			Provider:         n.Provider,
+		},
+	}
+
+	return gen.Generate(opts)
+}
+
+// suggestCrateName returns the crate name used on crates.io
+func suggestCrateName(crateName string, repository appconf.RepositoryConf) string {
+	if crateName != "" {
+		return openapi_rust.ToCrateName(crateName)
+	}
+	if name := openapi_rust.ToCrateName(repository.Name); name != "" {
+		return name
+	}
+
+	return "unknown-crate"
+}
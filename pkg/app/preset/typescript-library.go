@@ -2,9 +2,11 @@ package preset
 
 import (
 	"log/slog"
+	"strings"
 
 	"github.com/primelib/primecodegen/pkg/app/appconf"
 	"github.com/primelib/primecodegen/pkg/app/generator"
+	"github.com/primelib/primecodegen/pkg/util"
 )
 
 type TypeScriptLibraryGenerator struct {
@@ -24,32 +26,38 @@ func (n *TypeScriptLibraryGenerator) GetOutputName() string {
 }
 
 func (n *TypeScriptLibraryGenerator) Generate(opts generator.GenerateOptions) error {
-	slog.Info("generating typescript library", "dir", opts.OutputDirectory, "spec", n.APISpec)
+	packageName := suggestNpmPackageName(n.Opts.NpmOrg, n.Opts.NpmName, n.Repository)
 
-	gen := generator.OpenAPIGenerator{
+	slog.With("dir", opts.OutputDirectory, "spec", n.APISpec).With("package", packageName).Info("generating typescript library")
+	gen := generator.PrimeCodeGenGenerator{
 		OutputName: n.GetOutputName(),
 		APISpec:    n.APISpec,
-		Config: generator.OpenAPIGeneratorConfig{
-			GeneratorName:         "typescript-axios",
-			EnablePostProcessFile: false,
-			GlobalProperty:        nil,
-			AdditionalProperties: map[string]interface{}{
-				"projectName": n.Repository.Name,
-			},
-			IgnoreFiles: []string{
-				"README.md",
-				".travis.yml",
-				"appveyor.yml",
-				".gitlab-ci.yml",
-				".gitignore",
-				"git_push.sh",
-				".github/*",
-				"docs/*",
-			},
-			Repository:  n.Repository,
-			Maintainers: n.Maintainers,
+		Args:       []string{},
+		Config: generator.PrimeCodeGenGeneratorConfig{
+			TemplateLanguage: "typescript",
+			TemplateType:     "httpclient",
+			Patches:          []string{},
+			ArtifactId:       packageName,
+			Repository:       n.Repository,
+			Maintainers:      n.Maintainers,
+			Provider:         n.Provider,
 		},
 	}
 
 	return gen.Generate(opts)
 }
+
+// suggestNpmPackageName returns the npm package name, optionally scoped to the npm organization
+func suggestNpmPackageName(npmOrg string, npmName string, repository appconf.RepositoryConf) string {
+	if npmName == "" {
+		npmName = util.ToSlug(repository.Name)
+	}
+	if npmName == "" {
+		npmName = "unknown-package"
+	}
+	if npmOrg != "" {
+		return "@" + strings.TrimPrefix(npmOrg, "@") + "/" + npmName
+	}
+
+	return npmName
+}
@@ -145,9 +145,11 @@ func Update(dir string, conf appconf.Configuration, repository api.Repository) e
 		}
 
 		// combine patches from overlay, sets and spec patches
-		combined := append([]sharedpatch.SpecPatch{specPatch},
-			openapipatch.ResolvePatchSets(spec.PatchSets)...,
-		)
+		setPatches, err := openapipatch.ResolvePatchSets(spec.PatchSets, spec.PatchSetDefinitions)
+		if err != nil {
+			return fmt.Errorf("failed to resolve patch sets: %w", err)
+		}
+		combined := append([]sharedpatch.SpecPatch{specPatch}, setPatches...)
 		combined = append(combined, autoCodeSamplesPatches(conf)...)
 		spec.Patches = append(combined, spec.Patches...)
 
@@ -205,7 +207,6 @@ func autoCodeSamplesPatches(conf appconf.Configuration) []sharedpatch.SpecPatch
 				"dir":        filepath.ToSlash(dir),
 				"language":   language,
 				"ref-prefix": "snippets",
-				"strict":     false,
 			},
 		})
 	}
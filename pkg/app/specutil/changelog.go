@@ -0,0 +1,223 @@
+package specutil
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+const ChangelogUnreleased = "Unreleased"
+
+const changelogHeader = "# Changelog\n\nAll notable changes to the API of this library are documented in this file.\n"
+
+var changelogGroups = []struct {
+	level int
+	title string
+}{
+	{LevelBreaking, "Breaking Changes"},
+	{LevelMinor, "Minor Changes"},
+	{LevelPatch, "Patch Changes"},
+}
+
+type Changelog struct {
+	Releases []ChangelogRelease
+}
+
+type ChangelogRelease struct {
+	Version string
+	Date    string
+	Changes map[int][]string // Changes are the rendered change entries by level
+}
+
+type ChangelogOptions struct {
+	// OperationLink is the link target for operation ids, supports the {operationId} placeholder - no link if empty
+	OperationLink string
+	// ServiceLink is the link target for the affected services (operation tags), supports the {service} placeholder - no link if empty
+	ServiceLink string
+}
+
+// ParseChangelog parses a changelog that was previously rendered by Changelog.Render
+func ParseChangelog(content string) Changelog {
+	var changelog Changelog
+	var release *ChangelogRelease
+	level := 0
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, " \r")
+		switch {
+		case strings.HasPrefix(line, "## "):
+			version, date, _ := strings.Cut(strings.TrimPrefix(line, "## "), " - ")
+			changelog.Releases = append(changelog.Releases, ChangelogRelease{
+				Version: strings.Trim(version, "[]"),
+				Date:    date,
+				Changes: make(map[int][]string),
+			})
+			release = &changelog.Releases[len(changelog.Releases)-1]
+			level = 0
+		case strings.HasPrefix(line, "### "):
+			level = 0
+			for _, group := range changelogGroups {
+				if strings.TrimPrefix(line, "### ") == group.title {
+					level = group.level
+				}
+			}
+		case strings.HasPrefix(line, "- ") && release != nil && level > 0:
+			release.Changes[level] = append(release.Changes[level], line)
+		}
+	}
+
+	return changelog
+}
+
+// Add adds the changes of the diff to the release with the given version, an empty version adds the changes to the unreleased section.
+// Adding a released version moves all unreleased changes into that version.
+func (c *Changelog) Add(version string, date string, diff Diff, opts ChangelogOptions) {
+	if version == "" {
+		version = ChangelogUnreleased
+	}
+
+	release := c.release(version)
+	if release == nil {
+		c.Releases = append([]ChangelogRelease{{Version: version, Date: date, Changes: make(map[int][]string)}}, c.Releases...)
+		release = &c.Releases[0]
+	}
+	if date != "" {
+		release.Date = date
+	}
+
+	// move unreleased changes into the new release
+	if version != ChangelogUnreleased {
+		if unreleased := c.release(ChangelogUnreleased); unreleased != nil {
+			for level, entries := range unreleased.Changes {
+				release.addEntries(level, entries)
+			}
+			c.Releases = slices.DeleteFunc(c.Releases, func(r ChangelogRelease) bool {
+				return r.Version == ChangelogUnreleased
+			})
+		}
+	}
+
+	for _, change := range diff.OpenAPI {
+		release.addEntries(change.Level, []string{renderChangelogEntry(change, opts)})
+	}
+}
+
+// Render renders the changelog as markdown, newest release first
+func (c *Changelog) Render() string {
+	var sb strings.Builder
+	sb.WriteString(changelogHeader)
+
+	for _, release := range c.Releases {
+		sb.WriteString("\n## [" + release.Version + "]")
+		if release.Date != "" {
+			sb.WriteString(" - " + release.Date)
+		}
+		sb.WriteString("\n")
+
+		for _, group := range changelogGroups {
+			if len(release.Changes[group.level]) == 0 {
+				continue
+			}
+			sb.WriteString("\n### " + group.title + "\n\n")
+			for _, entry := range release.Changes[group.level] {
+				sb.WriteString(entry + "\n")
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+func (c *Changelog) release(version string) *ChangelogRelease {
+	for i := range c.Releases {
+		if c.Releases[i].Version == version {
+			return &c.Releases[i]
+		}
+	}
+	return nil
+}
+
+func (r *ChangelogRelease) addEntries(level int, entries []string) {
+	if r.Changes == nil {
+		r.Changes = make(map[int][]string)
+	}
+	for _, entry := range entries {
+		if !slices.Contains(r.Changes[level], entry) {
+			r.Changes[level] = append(r.Changes[level], entry)
+		}
+	}
+}
+
+func renderChangelogEntry(change OpenAPIDiff, opts ChangelogOptions) string {
+	var sb strings.Builder
+	sb.WriteString("- ")
+	if change.OperationID != "" {
+		sb.WriteString(markdownLink("`"+change.OperationID+"`", opts.OperationLink, "{operationId}", change.OperationID))
+		sb.WriteString(" ")
+	}
+	sb.WriteString(fmt.Sprintf("`%s %s`", change.Operation, change.Path))
+
+	if len(change.Tags) > 0 {
+		var services []string
+		for _, tag := range change.Tags {
+			services = append(services, markdownLink(tag, opts.ServiceLink, "{service}", tag))
+		}
+		sb.WriteString(" (" + strings.Join(services, ", ") + ")")
+	}
+	sb.WriteString(": " + change.Text)
+
+	return sb.String()
+}
+
+func markdownLink(text string, link string, placeholder string, value string) string {
+	if link == "" {
+		return text
+	}
+	return fmt.Sprintf("[%s](%s)", text, strings.ReplaceAll(link, placeholder, value))
+}
+
+// UpdateChangelog adds the changes of the diff to the changelog file, the file is created if it does not exist
+func UpdateChangelog(file string, version string, date string, diff Diff, opts ChangelogOptions) error {
+	var changelog Changelog
+	content, err := os.ReadFile(file)
+	if err == nil {
+		changelog = ParseChangelog(string(content))
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read changelog %s: %w", file, err)
+	}
+
+	// skip empty unreleased sections to avoid touching the file without changes
+	if len(diff.OpenAPI) == 0 && (version == "" || version == ChangelogUnreleased) {
+		return nil
+	}
+
+	changelog.Add(version, date, diff, opts)
+	if err = os.WriteFile(file, []byte(changelog.Render()), 0644); err != nil {
+		return fmt.Errorf("failed to write changelog %s: %w", file, err)
+	}
+
+	return nil
+}
+
+// ReleaseChangelog moves the unreleased section of the changelog file into the given version, returns false if there are no unreleased changes
+func ReleaseChangelog(file string, version string, date string) (bool, error) {
+	content, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to read changelog %s: %w", file, err)
+	}
+
+	changelog := ParseChangelog(string(content))
+	if changelog.release(ChangelogUnreleased) == nil {
+		return false, nil
+	}
+
+	changelog.Add(version, date, Diff{}, ChangelogOptions{})
+	if err = os.WriteFile(file, []byte(changelog.Render()), 0644); err != nil {
+		return false, fmt.Errorf("failed to write changelog %s: %w", file, err)
+	}
+
+	return true, nil
+}
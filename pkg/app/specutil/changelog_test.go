@@ -0,0 +1,109 @@
+package specutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var changelogTestDiff = Diff{OpenAPI: []OpenAPIDiff{
+	{ID: "api-removed-without-deprecation", Text: "api was removed", Level: LevelBreaking, Operation: "GET", OperationID: "listStores", Tags: []string{"stores"}, Path: "/stores"},
+	{ID: "endpoint-added", Text: "endpoint added", Level: LevelMinor, Operation: "GET", OperationID: "listOwners", Tags: []string{"owners"}, Path: "/owners"},
+}}
+
+func TestChangelogAdd(t *testing.T) {
+	// arrange
+	var changelog Changelog
+
+	// act
+	changelog.Add("", "", changelogTestDiff, ChangelogOptions{OperationLink: "docs.md#{operationId}"})
+
+	// assert
+	require.Len(t, changelog.Releases, 1)
+	assert.Equal(t, ChangelogUnreleased, changelog.Releases[0].Version)
+	assert.Equal(t, []string{"- [`listStores`](docs.md#listStores) `GET /stores` (stores): api was removed"}, changelog.Releases[0].Changes[LevelBreaking])
+	assert.Equal(t, []string{"- [`listOwners`](docs.md#listOwners) `GET /owners` (owners): endpoint added"}, changelog.Releases[0].Changes[LevelMinor])
+}
+
+func TestChangelogRelease(t *testing.T) {
+	// arrange
+	var changelog Changelog
+	changelog.Add("", "", changelogTestDiff, ChangelogOptions{})
+
+	// act
+	changelog.Add("2.0.0", "2024-01-02", Diff{}, ChangelogOptions{})
+
+	// assert
+	require.Len(t, changelog.Releases, 1)
+	assert.Equal(t, "2.0.0", changelog.Releases[0].Version)
+	assert.Equal(t, "2024-01-02", changelog.Releases[0].Date)
+	assert.Len(t, changelog.Releases[0].Changes[LevelBreaking], 1)
+	assert.Len(t, changelog.Releases[0].Changes[LevelMinor], 1)
+}
+
+func TestChangelogRenderAndParse(t *testing.T) {
+	// arrange
+	var changelog Changelog
+	changelog.Add("1.0.0", "2024-01-01", Diff{OpenAPI: changelogTestDiff.OpenAPI[1:]}, ChangelogOptions{})
+	changelog.Add("", "", changelogTestDiff, ChangelogOptions{ServiceLink: "services/{service}.md"})
+
+	// act
+	rendered := changelog.Render()
+	parsed := ParseChangelog(rendered)
+
+	// assert
+	assert.Contains(t, rendered, "## [Unreleased]\n\n### Breaking Changes\n\n- `listStores` `GET /stores` ([stores](services/stores.md)): api was removed\n")
+	assert.Contains(t, rendered, "## [1.0.0] - 2024-01-01\n\n### Minor Changes\n\n- `listOwners` `GET /owners` (owners): endpoint added\n")
+	assert.Equal(t, changelog, parsed)
+	assert.Equal(t, rendered, parsed.Render())
+}
+
+func TestUpdateChangelog(t *testing.T) {
+	// arrange
+	file := filepath.Join(t.TempDir(), "CHANGELOG.md")
+
+	// act
+	require.NoError(t, UpdateChangelog(file, "", "", changelogTestDiff, ChangelogOptions{}))
+	require.NoError(t, UpdateChangelog(file, "", "", changelogTestDiff, ChangelogOptions{}))
+
+	// assert
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	changelog := ParseChangelog(string(content))
+	require.Len(t, changelog.Releases, 1)
+	assert.Len(t, changelog.Releases[0].Changes[LevelBreaking], 1, "duplicate entries should not be added")
+}
+
+func TestReleaseChangelog(t *testing.T) {
+	// arrange
+	file := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	require.NoError(t, UpdateChangelog(file, "1.0.0", "2024-01-01", Diff{OpenAPI: changelogTestDiff.OpenAPI[1:]}, ChangelogOptions{}))
+
+	// act
+	released, err := ReleaseChangelog(file, "1.1.0", "2024-02-01")
+	require.NoError(t, err)
+	assert.False(t, released, "no unreleased changes")
+	require.NoError(t, UpdateChangelog(file, "", "", changelogTestDiff, ChangelogOptions{}))
+	released, err = ReleaseChangelog(file, "2.0.0", "2024-03-01")
+	require.NoError(t, err)
+
+	// assert
+	assert.True(t, released)
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	changelog := ParseChangelog(string(content))
+	require.Len(t, changelog.Releases, 2)
+	assert.Equal(t, "2.0.0", changelog.Releases[0].Version)
+	assert.Equal(t, "2024-03-01", changelog.Releases[0].Date)
+	assert.Len(t, changelog.Releases[0].Changes[LevelBreaking], 1)
+	assert.Equal(t, "1.0.0", changelog.Releases[1].Version)
+}
+
+func TestReleaseChangelogMissingFile(t *testing.T) {
+	released, err := ReleaseChangelog(filepath.Join(t.TempDir(), "CHANGELOG.md"), "1.0.0", "2024-01-01")
+	require.NoError(t, err)
+	assert.False(t, released)
+}
@@ -45,6 +45,18 @@ func (d *Diff) PatchChangeCount() int {
 	return count
 }
 
+// MaxLevel returns the highest change level of all changes, 0 if there are no changes
+func (d *Diff) MaxLevel() int {
+	maxLevel := 0
+	for _, r := range d.OpenAPI {
+		if r.Level > maxLevel {
+			maxLevel = r.Level
+		}
+	}
+
+	return maxLevel
+}
+
 func DiffSpec(format string, file1 string, file2 string) (Diff, error) {
 	var diff = Diff{
 		OpenAPI: []OpenAPIDiff{},
@@ -66,7 +78,7 @@ func DiffSpec(format string, file1 string, file2 string) (Diff, error) {
 		}
 
 		// sort by level
-		sort.Slice(d, func(i, j int) bool {
+		sort.SliceStable(d, func(i, j int) bool {
 			return d[i].Level > d[j].Level
 		})
 
@@ -76,45 +88,44 @@ func DiffSpec(format string, file1 string, file2 string) (Diff, error) {
 	return diff, nil
 }
 
-func BumpVersion(format string, file1 string, file2 string, currentVersion string) (string, error) {
+// BumpVersion diffs both specifications and increments the current version based on the highest change level, the version is returned unchanged if there are no changes
+func BumpVersion(format string, file1 string, file2 string, currentVersion string) (string, Diff, error) {
 	// parse current version
-	v, err := semver.NewVersion(currentVersion)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse current version: %w", err)
+	if _, err := semver.NewVersion(currentVersion); err != nil {
+		return "", Diff{}, fmt.Errorf("failed to parse current version: %w", err)
 	}
 
 	// set initial version to 0.1.0 if no old spec is available
-	if _, err = os.Stat(file1); os.IsNotExist(err) {
-		return "0.1.0", nil
+	if _, err := os.Stat(file1); os.IsNotExist(err) {
+		return "0.1.0", Diff{}, nil
 	}
 
-	// require spec
-	if _, err = os.Stat(file2); os.IsNotExist(err) {
-		return "", fmt.Errorf("file %s does not exist", file2)
+	// diff
+	diff, err := DiffSpec(format, file1, file2)
+	if err != nil {
+		return "", diff, err
+	}
+	if diff.MaxLevel() == 0 {
+		return currentVersion, diff, nil
 	}
 
-	// diff openapi
-	if format == "openapi" || format == "" {
-		d, err := DiffOpenAPI(file1, file2)
-		if err != nil {
-			return "", fmt.Errorf("failed to diff openapi: %w", err)
-		}
-
-		maxLevel := 0
-		for _, r := range d {
-			if r.Level > maxLevel {
-				maxLevel = r.Level
-			}
-		}
+	version, err := BumpVersionByLevel(currentVersion, diff.MaxLevel())
+	return version, diff, err
+}
 
-		if maxLevel == 3 {
-			*v = v.IncMajor()
-		} else if maxLevel == 2 {
-			*v = v.IncMinor()
-		} else {
-			*v = v.IncPatch()
-		}
+// BumpVersionByLevel increments the current version based on the change level (breaking = major, minor = minor, everything else = patch)
+func BumpVersionByLevel(currentVersion string, level int) (string, error) {
+	v, err := semver.NewVersion(currentVersion)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse current version: %w", err)
 	}
 
-	return v.String(), nil
+	switch level {
+	case LevelBreaking:
+		return v.IncMajor().String(), nil
+	case LevelMinor:
+		return v.IncMinor().String(), nil
+	default:
+		return v.IncPatch().String(), nil
+	}
 }
@@ -0,0 +1,58 @@
+package specutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBumpVersionByLevel(t *testing.T) {
+	tests := []struct {
+		level    int
+		expected string
+	}{
+		{LevelBreaking, "2.0.0"},
+		{LevelMinor, "1.3.0"},
+		{LevelPatch, "1.2.4"},
+	}
+
+	for _, tt := range tests {
+		version, err := BumpVersionByLevel("1.2.3", tt.level)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, version)
+	}
+}
+
+func TestBumpVersion(t *testing.T) {
+	// arrange
+	dir := t.TempDir()
+	baseFile := filepath.Join(dir, "base.yaml")
+	revisionFile := filepath.Join(dir, "revision.yaml")
+	require.NoError(t, os.WriteFile(baseFile, []byte(diffBaseSpec), 0644))
+	require.NoError(t, os.WriteFile(revisionFile, []byte(diffRevisionSpec), 0644))
+
+	// act
+	version, diff, err := BumpVersion("openapi", baseFile, revisionFile, "1.2.3")
+
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", version)
+	assert.Equal(t, LevelBreaking, diff.MaxLevel())
+}
+
+func TestBumpVersionUnchanged(t *testing.T) {
+	// arrange
+	dir := t.TempDir()
+	baseFile := filepath.Join(dir, "base.yaml")
+	require.NoError(t, os.WriteFile(baseFile, []byte(diffBaseSpec), 0644))
+
+	// act
+	version, _, err := BumpVersion("openapi", baseFile, baseFile, "1.2.3")
+
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3", version)
+}
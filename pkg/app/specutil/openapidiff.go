@@ -0,0 +1,358 @@
+package specutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
+)
+
+const (
+	LevelPatch    = 1
+	LevelMinor    = 2
+	LevelBreaking = 3
+)
+
+type OpenAPIDiff struct {
+	ID          string   `json:"id"`
+	Text        string   `json:"text"`
+	Level       int      `json:"level"`
+	Operation   string   `json:"operation"`
+	OperationID string   `json:"operationId"`
+	Tags        []string `json:"tags,omitempty"`
+	Path        string   `json:"path"`
+	Source      string   `json:"source"`
+}
+
+func (oad *OpenAPIDiff) IsBreakingChange() bool {
+	return oad.Level == LevelBreaking
+}
+
+func (oad *OpenAPIDiff) IsMinorChange() bool {
+	return oad.Level == LevelMinor
+}
+
+func (oad *OpenAPIDiff) IsPatchChange() bool {
+	return oad.Level == LevelPatch
+}
+
+func (oad *OpenAPIDiff) LevelAsString() string {
+	switch oad.Level {
+	case LevelPatch:
+		return "patch"
+	case LevelMinor:
+		return "minor"
+	case LevelBreaking:
+		return "breaking"
+	default:
+		return "unknown"
+	}
+}
+
+// DiffOpenAPI compares two OAS files and returns the differences
+func DiffOpenAPI(file1 string, file2 string) ([]OpenAPIDiff, error) {
+	baseDoc, err := openV3DocumentFile(file1)
+	if err != nil {
+		return nil, err
+	}
+	revisionDoc, err := openV3DocumentFile(file2)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := DiffOpenAPIDocuments(baseDoc, revisionDoc)
+	for i := range diffs {
+		diffs[i].Source = file2
+	}
+
+	return diffs, nil
+}
+
+func openV3DocumentFile(file string) (*libopenapi.DocumentModel[v3.Document], error) {
+	input, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec file %s: %w", file, err)
+	}
+	document, err := openapidocument.OpenDocumentWithBaseDir(input, filepath.Dir(file))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open spec file %s: %w", file, err)
+	}
+	v3doc, err := document.BuildV3Model()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build v3 high level model for %s: %w", file, err)
+	}
+
+	return v3doc, nil
+}
+
+// DiffOpenAPIDocuments compares the operations of two OpenAPI 3 documents and classifies each change as patch, minor or breaking
+func DiffOpenAPIDocuments(baseDoc *libopenapi.DocumentModel[v3.Document], revisionDoc *libopenapi.DocumentModel[v3.Document]) []OpenAPIDiff {
+	d := openAPIDiffer{}
+
+	baseOperations := collectDiffOperations(baseDoc)
+	revisionOperations := collectDiffOperations(revisionDoc)
+	for _, key := range sortedOperationKeys(baseOperations, revisionOperations) {
+		baseOp, inBase := baseOperations[key]
+		revisionOp, inRevision := revisionOperations[key]
+
+		switch {
+		case inBase && !inRevision:
+			if isDeprecated(baseOp.operation.Deprecated) {
+				d.add(baseOp, "api-removed-after-deprecation", LevelBreaking, "api removed after deprecation")
+			} else {
+				d.add(baseOp, "api-removed-without-deprecation", LevelBreaking, "api removed without deprecation")
+			}
+		case !inBase && inRevision:
+			d.add(revisionOp, "endpoint-added", LevelMinor, "endpoint added")
+		default:
+			d.diffOperation(baseOp, revisionOp)
+		}
+	}
+
+	return d.diffs
+}
+
+// diffOperation is an operation with its path, method and the effective (path and operation level) parameters
+type diffOperation struct {
+	path       string
+	method     string
+	operation  *v3.Operation
+	parameters []*v3.Parameter
+}
+
+func collectDiffOperations(doc *libopenapi.DocumentModel[v3.Document]) map[string]diffOperation {
+	result := make(map[string]diffOperation)
+	if doc == nil || doc.Model.Paths == nil || doc.Model.Paths.PathItems == nil {
+		return result
+	}
+
+	for path := doc.Model.Paths.PathItems.Oldest(); path != nil; path = path.Next() {
+		if path.Value == nil {
+			continue
+		}
+		for op := path.Value.GetOperations().Oldest(); op != nil; op = op.Next() {
+			if op.Value == nil {
+				continue
+			}
+
+			// operation level parameters override path level parameters with the same name and location
+			parameters := slices.Clone(op.Value.Parameters)
+			for _, pathParameter := range path.Value.Parameters {
+				if !slices.ContainsFunc(parameters, func(p *v3.Parameter) bool { return p.Name == pathParameter.Name && p.In == pathParameter.In }) {
+					parameters = append(parameters, pathParameter)
+				}
+			}
+
+			method := strings.ToUpper(op.Key)
+			result[method+" "+path.Key] = diffOperation{
+				path:       path.Key,
+				method:     method,
+				operation:  op.Value,
+				parameters: parameters,
+			}
+		}
+	}
+
+	return result
+}
+
+// sortedOperationKeys returns the operation keys of both documents, ordered by path and method
+func sortedOperationKeys(base map[string]diffOperation, revision map[string]diffOperation) []string {
+	var keys []string
+	for key := range base {
+		keys = append(keys, key)
+	}
+	for key := range revision {
+		if _, ok := base[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		aMethod, aPath, _ := strings.Cut(a, " ")
+		bMethod, bPath, _ := strings.Cut(b, " ")
+		if c := strings.Compare(aPath, bPath); c != 0 {
+			return c
+		}
+		return strings.Compare(aMethod, bMethod)
+	})
+	return keys
+}
+
+type openAPIDiffer struct {
+	diffs []OpenAPIDiff
+}
+
+func (d *openAPIDiffer) add(op diffOperation, id string, level int, text string) {
+	d.diffs = append(d.diffs, OpenAPIDiff{
+		ID:          id,
+		Text:        text,
+		Level:       level,
+		Operation:   op.method,
+		OperationID: op.operation.OperationId,
+		Tags:        op.operation.Tags,
+		Path:        op.path,
+	})
+}
+
+func (d *openAPIDiffer) diffOperation(baseOp diffOperation, revisionOp diffOperation) {
+	base, revision := baseOp.operation, revisionOp.operation
+
+	if base.OperationId != revision.OperationId {
+		d.add(revisionOp, "api-operation-id-changed", LevelBreaking, fmt.Sprintf("api operation id changed from '%s' to '%s'", base.OperationId, revision.OperationId))
+	}
+	if !isDeprecated(base.Deprecated) && isDeprecated(revision.Deprecated) {
+		d.add(revisionOp, "endpoint-deprecated", LevelMinor, "endpoint deprecated")
+	} else if isDeprecated(base.Deprecated) && !isDeprecated(revision.Deprecated) {
+		d.add(revisionOp, "endpoint-reactivated", LevelMinor, "endpoint reactivated")
+	}
+	if base.Summary != revision.Summary {
+		d.add(revisionOp, "api-summary-changed", LevelPatch, "api summary changed")
+	}
+	if base.Description != revision.Description {
+		d.add(revisionOp, "api-description-changed", LevelPatch, "api description changed")
+	}
+
+	d.diffParameters(baseOp, revisionOp)
+	d.diffRequestBody(baseOp.operation.RequestBody, revisionOp)
+	d.diffResponses(baseOp.operation.Responses, revisionOp)
+}
+
+func (d *openAPIDiffer) diffParameters(baseOp diffOperation, revisionOp diffOperation) {
+	findParameter := func(parameters []*v3.Parameter, name string, in string) *v3.Parameter {
+		for _, p := range parameters {
+			if p != nil && p.Name == name && p.In == in {
+				return p
+			}
+		}
+		return nil
+	}
+
+	for _, base := range baseOp.parameters {
+		if base == nil {
+			continue
+		}
+		revision := findParameter(revisionOp.parameters, base.Name, base.In)
+		if revision == nil {
+			d.add(revisionOp, "request-parameter-removed", LevelBreaking, fmt.Sprintf("deleted the '%s' %s request parameter", base.Name, base.In))
+			continue
+		}
+
+		if !isRequired(base.Required) && isRequired(revision.Required) {
+			d.add(revisionOp, "request-parameter-became-required", LevelBreaking, fmt.Sprintf("the '%s' %s request parameter became required", base.Name, base.In))
+		} else if isRequired(base.Required) && !isRequired(revision.Required) {
+			d.add(revisionOp, "request-parameter-became-optional", LevelMinor, fmt.Sprintf("the '%s' %s request parameter became optional", base.Name, base.In))
+		}
+		if base.Schema != nil && revision.Schema != nil {
+			sd := schemaDiffer{differ: d, op: revisionOp, request: true, subject: fmt.Sprintf("the '%s' %s request parameter", base.Name, base.In)}
+			sd.diff(base.Schema.Schema(), revision.Schema.Schema(), "")
+		}
+	}
+
+	for _, revision := range revisionOp.parameters {
+		if revision == nil || findParameter(baseOp.parameters, revision.Name, revision.In) != nil {
+			continue
+		}
+		if isRequired(revision.Required) {
+			d.add(revisionOp, "new-required-request-parameter", LevelBreaking, fmt.Sprintf("added the new required '%s' %s request parameter", revision.Name, revision.In))
+		} else {
+			d.add(revisionOp, "new-optional-request-parameter", LevelMinor, fmt.Sprintf("added the new optional '%s' %s request parameter", revision.Name, revision.In))
+		}
+	}
+}
+
+func (d *openAPIDiffer) diffRequestBody(base *v3.RequestBody, revisionOp diffOperation) {
+	revision := revisionOp.operation.RequestBody
+	switch {
+	case base == nil && revision == nil:
+		return
+	case base == nil:
+		if isRequired(revision.Required) {
+			d.add(revisionOp, "request-body-added-required", LevelBreaking, "added required request body")
+		} else {
+			d.add(revisionOp, "request-body-added-optional", LevelMinor, "added optional request body")
+		}
+		return
+	case revision == nil:
+		d.add(revisionOp, "request-body-removed", LevelBreaking, "removed request body")
+		return
+	}
+
+	if !isRequired(base.Required) && isRequired(revision.Required) {
+		d.add(revisionOp, "request-body-became-required", LevelBreaking, "request body became required")
+	} else if isRequired(base.Required) && !isRequired(revision.Required) {
+		d.add(revisionOp, "request-body-became-optional", LevelMinor, "request body became optional")
+	}
+	d.diffContent(base.Content, revision.Content, revisionOp, true, "request body")
+}
+
+func (d *openAPIDiffer) diffResponses(base *v3.Responses, revisionOp diffOperation) {
+	revision := revisionOp.operation.Responses
+	if base == nil || revision == nil || base.Codes == nil || revision.Codes == nil {
+		return
+	}
+
+	for code := base.Codes.Oldest(); code != nil; code = code.Next() {
+		revisionResponse, ok := revision.Codes.Get(code.Key)
+		if !ok {
+			if strings.HasPrefix(code.Key, "2") {
+				d.add(revisionOp, "response-success-status-removed", LevelBreaking, fmt.Sprintf("removed the success response with the status '%s'", code.Key))
+			} else {
+				d.add(revisionOp, "response-non-success-status-removed", LevelPatch, fmt.Sprintf("removed the non-success response with the status '%s'", code.Key))
+			}
+			continue
+		}
+		if code.Value != nil && revisionResponse != nil {
+			d.diffContent(code.Value.Content, revisionResponse.Content, revisionOp, false, fmt.Sprintf("response with the status '%s'", code.Key))
+		}
+	}
+
+	for code := revision.Codes.Oldest(); code != nil; code = code.Next() {
+		if _, ok := base.Codes.Get(code.Key); !ok {
+			d.add(revisionOp, "response-status-added", LevelMinor, fmt.Sprintf("added the response with the status '%s'", code.Key))
+		}
+	}
+}
+
+func (d *openAPIDiffer) diffContent(base *orderedmap.Map[string, *v3.MediaType], revision *orderedmap.Map[string, *v3.MediaType], revisionOp diffOperation, request bool, subject string) {
+	if base != nil {
+		for mediaType := base.Oldest(); mediaType != nil; mediaType = mediaType.Next() {
+			var revisionMediaType *v3.MediaType
+			if revision != nil {
+				revisionMediaType, _ = revision.Get(mediaType.Key)
+			}
+			if revisionMediaType == nil {
+				d.add(revisionOp, "media-type-removed", LevelBreaking, fmt.Sprintf("removed the media type '%s' from the %s", mediaType.Key, subject))
+				continue
+			}
+			if mediaType.Value != nil && mediaType.Value.Schema != nil && revisionMediaType.Schema != nil {
+				sd := schemaDiffer{differ: d, op: revisionOp, request: request, subject: fmt.Sprintf("the %s", subject)}
+				sd.diff(mediaType.Value.Schema.Schema(), revisionMediaType.Schema.Schema(), "")
+			}
+		}
+	}
+	if revision != nil {
+		for mediaType := revision.Oldest(); mediaType != nil; mediaType = mediaType.Next() {
+			if base == nil {
+				d.add(revisionOp, "media-type-added", LevelMinor, fmt.Sprintf("added the media type '%s' to the %s", mediaType.Key, subject))
+				continue
+			}
+			if _, ok := base.Get(mediaType.Key); !ok {
+				d.add(revisionOp, "media-type-added", LevelMinor, fmt.Sprintf("added the media type '%s' to the %s", mediaType.Key, subject))
+			}
+		}
+	}
+}
+
+func isRequired(required *bool) bool {
+	return required != nil && *required
+}
+
+func isDeprecated(deprecated *bool) bool {
+	return deprecated != nil && *deprecated
+}
@@ -0,0 +1,148 @@
+package specutil
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// schemaDiffer compares two schemas, the level of a change depends on the direction (request or response)
+type schemaDiffer struct {
+	differ  *openAPIDiffer
+	op      diffOperation
+	request bool
+	subject string
+	visited map[[2]*base.Schema]bool
+}
+
+func (sd *schemaDiffer) add(id string, requestLevel int, responseLevel int, property string, text string) {
+	level := responseLevel
+	direction := "response"
+	if sd.request {
+		level = requestLevel
+		direction = "request"
+	}
+	id = strings.ReplaceAll(id, "{direction}", direction)
+
+	subject := sd.subject
+	if property != "" {
+		subject = fmt.Sprintf("the %s property '%s' of %s", direction, property, sd.subject)
+	}
+	sd.differ.add(sd.op, id, level, subject+" "+text)
+}
+
+func (sd *schemaDiffer) diff(baseSchema *base.Schema, revisionSchema *base.Schema, property string) {
+	if baseSchema == nil || revisionSchema == nil {
+		return
+	}
+
+	// guard against circular schemas
+	if sd.visited == nil {
+		sd.visited = make(map[[2]*base.Schema]bool)
+	}
+	key := [2]*base.Schema{baseSchema, revisionSchema}
+	if sd.visited[key] {
+		return
+	}
+	sd.visited[key] = true
+
+	// type
+	if len(baseSchema.Type) > 0 && len(revisionSchema.Type) > 0 && !equalStrings(baseSchema.Type, revisionSchema.Type) {
+		sd.add("{direction}-type-changed", LevelBreaking, LevelBreaking, property, fmt.Sprintf("type changed from '%s' to '%s'", strings.Join(baseSchema.Type, ", "), strings.Join(revisionSchema.Type, ", ")))
+	}
+
+	// enum, removed values narrow a request while added values widen a response
+	baseEnum := enumValues(baseSchema)
+	revisionEnum := enumValues(revisionSchema)
+	if len(baseEnum) > 0 && len(revisionEnum) > 0 {
+		for _, value := range baseEnum {
+			if !slices.Contains(revisionEnum, value) {
+				sd.add("{direction}-enum-value-removed", LevelBreaking, LevelMinor, property, fmt.Sprintf("enum value '%s' was removed", value))
+			}
+		}
+		for _, value := range revisionEnum {
+			if !slices.Contains(baseEnum, value) {
+				sd.add("{direction}-enum-value-added", LevelMinor, LevelBreaking, property, fmt.Sprintf("enum value '%s' was added", value))
+			}
+		}
+	}
+
+	// properties
+	if baseSchema.Properties != nil {
+		for prop := baseSchema.Properties.Oldest(); prop != nil; prop = prop.Next() {
+			propertyPath := joinPropertyPath(property, prop.Key)
+			if !hasProperty(revisionSchema, prop.Key) {
+				sd.add("{direction}-property-removed", LevelMinor, LevelBreaking, propertyPath, "was removed")
+				continue
+			}
+			if revisionProp, _ := revisionSchema.Properties.Get(prop.Key); prop.Value != nil && revisionProp != nil {
+				sd.diff(prop.Value.Schema(), revisionProp.Schema(), propertyPath)
+			}
+		}
+	}
+	if revisionSchema.Properties != nil {
+		for prop := revisionSchema.Properties.Oldest(); prop != nil; prop = prop.Next() {
+			if hasProperty(baseSchema, prop.Key) {
+				continue
+			}
+			propertyPath := joinPropertyPath(property, prop.Key)
+			if slices.Contains(revisionSchema.Required, prop.Key) {
+				sd.add("new-required-{direction}-property", LevelBreaking, LevelMinor, propertyPath, "was added as required property")
+			} else {
+				sd.add("new-optional-{direction}-property", LevelMinor, LevelMinor, propertyPath, "was added as optional property")
+			}
+		}
+	}
+
+	// required, only for properties that exist in both versions
+	for _, name := range revisionSchema.Required {
+		if !slices.Contains(baseSchema.Required, name) && hasProperty(baseSchema, name) {
+			sd.add("{direction}-property-became-required", LevelBreaking, LevelPatch, joinPropertyPath(property, name), "became required")
+		}
+	}
+	for _, name := range baseSchema.Required {
+		if !slices.Contains(revisionSchema.Required, name) && hasProperty(revisionSchema, name) {
+			sd.add("{direction}-property-became-optional", LevelMinor, LevelBreaking, joinPropertyPath(property, name), "became optional")
+		}
+	}
+
+	// array items
+	if baseSchema.Items != nil && revisionSchema.Items != nil && baseSchema.Items.IsA() && revisionSchema.Items.IsA() {
+		sd.diff(baseSchema.Items.A.Schema(), revisionSchema.Items.A.Schema(), joinPropertyPath(property, "[]"))
+	}
+}
+
+func enumValues(schema *base.Schema) []string {
+	var values []string
+	for _, node := range schema.Enum {
+		if node != nil {
+			values = append(values, node.Value)
+		}
+	}
+	return values
+}
+
+func hasProperty(schema *base.Schema, name string) bool {
+	if schema.Properties == nil {
+		return false
+	}
+	_, ok := schema.Properties.Get(name)
+	return ok
+}
+
+func joinPropertyPath(parent string, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "/" + name
+}
+
+func equalStrings(a []string, b []string) bool {
+	as := slices.Clone(a)
+	bs := slices.Clone(b)
+	slices.Sort(as)
+	slices.Sort(bs)
+	return slices.Equal(as, bs)
+}
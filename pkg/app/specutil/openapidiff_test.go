@@ -0,0 +1,204 @@
+package specutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const diffBaseSpec = `openapi: 3.0.3
+info:
+  title: Petstore
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+        - name: status
+          in: query
+          schema:
+            type: string
+            enum: [available, pending, sold]
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+        "404":
+          description: not found
+    post:
+      operationId: createPet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        "201":
+          description: created
+  /stores:
+    get:
+      operationId: listStores
+      responses:
+        "200":
+          description: ok
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        age:
+          type: integer
+        tag:
+          type: string
+`
+
+const diffRevisionSpec = `openapi: 3.0.3
+info:
+  title: Petstore
+  version: 1.1.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      description: lists all pets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: string
+        - name: status
+          in: query
+          schema:
+            type: string
+            enum: [available, pending]
+        - name: owner
+          in: query
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+    post:
+      operationId: createPet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        "201":
+          description: created
+  /owners:
+    get:
+      operationId: listOwners
+      responses:
+        "200":
+          description: ok
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name, age]
+      properties:
+        name:
+          type: string
+        age:
+          type: integer
+        color:
+          type: string
+`
+
+func TestDiffOpenAPIDocuments(t *testing.T) {
+	// arrange
+	baseDoc := openapidocument.OpenV3DocumentForTest([]byte(diffBaseSpec))
+	revisionDoc := openapidocument.OpenV3DocumentForTest([]byte(diffRevisionSpec))
+
+	// act
+	diffs := DiffOpenAPIDocuments(baseDoc, revisionDoc)
+
+	// assert
+	levels := make(map[string]int)
+	for _, d := range diffs {
+		levels[d.Operation+" "+d.Path+" "+d.ID] = d.Level
+	}
+	assert.Equal(t, map[string]int{
+		"GET /owners endpoint-added":                    LevelMinor,
+		"GET /pets api-description-changed":             LevelPatch,
+		"GET /pets request-type-changed":                LevelBreaking,
+		"GET /pets request-enum-value-removed":          LevelBreaking,
+		"GET /pets new-required-request-parameter":      LevelBreaking,
+		"GET /pets response-non-success-status-removed": LevelPatch,
+		"GET /pets response-property-removed":           LevelBreaking,
+		"GET /pets new-optional-response-property":      LevelMinor,
+		"GET /pets response-property-became-required":   LevelPatch,
+		"POST /pets request-property-removed":           LevelMinor,
+		"POST /pets new-optional-request-property":      LevelMinor,
+		"POST /pets request-property-became-required":   LevelBreaking,
+		"GET /stores api-removed-without-deprecation":   LevelBreaking,
+	}, levels)
+	assert.Len(t, diffs, len(levels))
+}
+
+func TestDiffOpenAPIDocumentsText(t *testing.T) {
+	// arrange
+	baseDoc := openapidocument.OpenV3DocumentForTest([]byte(diffBaseSpec))
+	revisionDoc := openapidocument.OpenV3DocumentForTest([]byte(diffRevisionSpec))
+
+	// act
+	diffs := DiffOpenAPIDocuments(baseDoc, revisionDoc)
+
+	// assert
+	var texts []string
+	for _, d := range diffs {
+		if d.Operation == "GET" && d.Path == "/pets" {
+			texts = append(texts, d.Text)
+		}
+	}
+	assert.Contains(t, texts, "the 'status' query request parameter enum value 'sold' was removed")
+	assert.Contains(t, texts, "added the new required 'owner' query request parameter")
+	assert.Contains(t, texts, "the response property '[]/tag' of the response with the status '200' was removed")
+}
+
+func TestDiffSpec(t *testing.T) {
+	// arrange
+	dir := t.TempDir()
+	baseFile := filepath.Join(dir, "base.yaml")
+	revisionFile := filepath.Join(dir, "revision.yaml")
+	require.NoError(t, os.WriteFile(baseFile, []byte(diffBaseSpec), 0644))
+	require.NoError(t, os.WriteFile(revisionFile, []byte(diffRevisionSpec), 0644))
+
+	// act
+	diff, err := DiffSpec("openapi", baseFile, revisionFile)
+
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 6, diff.BreakingChangeCount())
+	assert.Equal(t, 4, diff.MinorChangeCount())
+	assert.Equal(t, 3, diff.PatchChangeCount())
+	assert.True(t, diff.OpenAPI[0].IsBreakingChange())
+	assert.Equal(t, revisionFile, diff.OpenAPI[0].Source)
+}
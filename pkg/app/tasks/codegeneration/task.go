@@ -96,6 +96,17 @@ func (n PrimeLibGenerateTask) Execute(ctx taskcommon.TaskContext) error {
 	if err != nil {
 		slog.Warn("failed to diff spec file", "err", err)
 	}
+
+	// changelog
+	if config.Changelog.Enabled {
+		err = specutil.UpdateChangelog(path.Join(ctx.Directory, config.Changelog.File), "", "", diff, specutil.ChangelogOptions{
+			OperationLink: config.Changelog.OperationLink,
+			ServiceLink:   config.Changelog.ServiceLink,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update changelog: %w", err)
+		}
+	}
 	if len(diff.OpenAPI) > 15 {
 		diff.OpenAPI = diff.OpenAPI[:15] // limit to the first n changes, sorted by level
 	}
@@ -4,16 +4,40 @@ import (
 	_ "embed"
 	"fmt"
 	"log/slog"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
 
+	"github.com/Masterminds/semver/v3"
+	"github.com/cidverse/go-vcs/vcsapi"
 	"github.com/cidverse/go-vcsapp/pkg/platform/api"
+	"github.com/cidverse/go-vcsapp/pkg/task/simpletask"
 	"github.com/cidverse/go-vcsapp/pkg/task/taskcommon"
+	"github.com/cidverse/go-vcsapp/pkg/vcsapp"
 	"github.com/primelib/primecodegen/pkg/app/appconf"
+	"github.com/primelib/primecodegen/pkg/app/specutil"
 	"github.com/primelib/primecodegen/pkg/util"
 )
 
+//go:embed templates/release.gohtml
+var releaseNotesTemplate []byte
+
+var (
+	breakingCommitPattern = regexp.MustCompile(`^[a-zA-Z]+(\([^)]*\))?!:`)
+	featureCommitPattern  = regexp.MustCompile(`^feat(\([^)]*\))?:`)
+)
+
 type PrimeLibTagCreateTask struct {
 }
 
+type ReleaseNotesTemplateData struct {
+	Version  string
+	SpecDiff *specutil.Diff
+	Commits  []string
+}
+
 // Name returns the name of the task
 func (n PrimeLibTagCreateTask) Name() string {
 	return "release"
@@ -57,62 +81,63 @@ func (n PrimeLibTagCreateTask) Execute(ctx taskcommon.TaskContext) error {
 		}
 	}
 
-	// find the latest two releases
-	var lastRelease *api.Tag
-	for _, tag := range tagList {
-		if lastRelease == nil {
-			lastRelease = &tag
-		}
-	}
+	// find the latest release
+	lastRelease := latestReleaseTag(tagList)
 	slog.Debug("found last tag", "tag", lastRelease)
 
 	// get next version
-	nextVersion := []string{"0.1.0"}
-	/*
-		if lastRelease != nil {
-			for _, module := range config.Modules {
-				specFile := path.Join(ctx.Directory, conf.Spec.File)
-				// get old version of spec file
-				oldFile, err := os.CreateTemp("", "primelib-spec")
-				if err != nil {
-					return fmt.Errorf("failed to create temp file: %w", err)
-				}
-				oldContent, err := ctx.Platform.FileContent(ctx.Repository, lastRelease.CommitHash, specFile)
-				if err != nil {
-					return fmt.Errorf("failed to get spec file content: %w", err)
-				}
-				_, err = oldFile.WriteString(oldContent)
-				if err != nil {
-					return fmt.Errorf("failed to write to temp file: %w", err)
-				}
-
-				// get new version of spec file
-				newFile, err := os.CreateTemp("", "primelib-spec")
-				if err != nil {
-					return fmt.Errorf("failed to create temp file: %w", err)
-				}
-				currentContent, err := ctx.Platform.FileContent(ctx.Repository, lastRelease.CommitHash, specFile)
-				if err != nil {
-					return fmt.Errorf("failed to get spec file content: %w", err)
-				}
-				_, err = newFile.WriteString(currentContent)
-
-				// determinate the next version number
-				version, err := spec.BumpVersion(conf.Spec.Type, oldFile.Name(), newFile.Name(), lastRelease.Name)
-				if err != nil {
-					return fmt.Errorf("failed to bump version: %w", err)
-				}
-
-				nextVersion = append(nextVersion, version)
+	notes := ReleaseNotesTemplateData{Version: "0.1.0", SpecDiff: &specutil.Diff{}}
+	if lastRelease != nil {
+		currentVersion := strings.TrimPrefix(lastRelease.Name, "v")
+
+		// spec changes determine the version, breaking = major, minor = minor, patch = patch
+		version, diff, err := n.specVersion(ctx, conf, lastRelease, currentVersion)
+		if err != nil {
+			return fmt.Errorf("failed to bump version: %w", err)
+		}
+		notes.Version = version
+		notes.SpecDiff = &diff
+
+		// fall back to the commit messages if the spec is unchanged
+		if version == currentVersion {
+			commits, err := n.commitMessagesSince(ctx, lastRelease)
+			if err != nil {
+				return fmt.Errorf("failed to get commits since last tag: %w", err)
+			}
+			level := commitMessageLevel(commits)
+			if level == 0 {
+				slog.Debug("no changes since last tag, skipping", "tag", lastRelease.Name)
+				return nil
+			}
+
+			notes.Version, err = specutil.BumpVersionByLevel(currentVersion, level)
+			if err != nil {
+				return fmt.Errorf("failed to bump version: %w", err)
+			}
+			for _, commit := range commits {
+				notes.Commits = append(notes.Commits, strings.SplitN(commit, "\n", 2)[0])
 			}
 		}
-	*/
+	}
+	version := notes.Version
+
+	// release notes
+	message, err := vcsapp.Render(string(releaseNotesTemplate), notes)
+	if err != nil {
+		return fmt.Errorf("failed to render release notes: %w", err)
+	}
 
-	// find highest version
-	version := util.FindHighestVersion(nextVersion)
+	// move the unreleased changes of the changelog into the new version, the tag points to the commit with the updated changelog
+	commitHash := ctx.Repository.CommitHash
+	if conf.Changelog.Enabled {
+		commitHash, err = n.releaseChangelog(ctx, conf, version)
+		if err != nil {
+			return fmt.Errorf("failed to release changelog: %w", err)
+		}
+	}
 
 	// create tag
-	err = ctx.Platform.CreateTag(ctx.Repository, "v"+version, ctx.Repository.CommitHash, "")
+	err = ctx.Platform.CreateTag(ctx.Repository, "v"+version, commitHash, string(message))
 	if err != nil {
 		return fmt.Errorf("failed to create tag: %w", err)
 	}
@@ -121,6 +146,183 @@ func (n PrimeLibTagCreateTask) Execute(ctx taskcommon.TaskContext) error {
 	return nil
 }
 
+// specVersion diffs the spec file at the last tag against the current commit and returns the next version
+func (n PrimeLibTagCreateTask) specVersion(ctx taskcommon.TaskContext, conf appconf.Configuration, lastRelease *api.Tag, currentVersion string) (string, specutil.Diff, error) {
+	// get old version of spec file, the version is determined by the commit messages if the spec did not exist at the last tag
+	oldContent, err := ctx.Platform.FileContent(ctx.Repository, lastRelease.CommitHash, conf.Spec.File)
+	if err != nil {
+		slog.Warn("spec file not found at last tag, falling back to commit messages", "tag", lastRelease.Name, "file", conf.Spec.File, "err", err)
+		return currentVersion, specutil.Diff{}, nil
+	}
+	oldFile, err := writeTempFile(oldContent)
+	if err != nil {
+		return "", specutil.Diff{}, err
+	}
+	defer os.Remove(oldFile)
+
+	// get new version of spec file
+	currentContent, err := ctx.Platform.FileContent(ctx.Repository, ctx.Repository.CommitHash, conf.Spec.File)
+	if err != nil {
+		return "", specutil.Diff{}, fmt.Errorf("failed to get spec file content: %w", err)
+	}
+	newFile, err := writeTempFile(currentContent)
+	if err != nil {
+		return "", specutil.Diff{}, err
+	}
+	defer os.Remove(newFile)
+
+	return specutil.BumpVersion("openapi", oldFile, newFile, currentVersion)
+}
+
+// releaseChangelog commits the changelog with the unreleased changes moved into the version to the default branch and returns the hash of that commit
+func (n PrimeLibTagCreateTask) releaseChangelog(ctx taskcommon.TaskContext, conf appconf.Configuration, version string) (string, error) {
+	tempDir, err := os.MkdirTemp("", "vcs-app-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to prepare temp directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+	ctx.Directory = tempDir
+
+	// clone repository
+	helper := simpletask.New(ctx)
+	err = helper.Clone()
+	if err != nil {
+		return "", fmt.Errorf("failed to clone repository: %w", err)
+	}
+	head, err := helper.VCSClient.VCSHead()
+	if err != nil {
+		return "", fmt.Errorf("failed to get head: %w", err)
+	}
+	if head.Hash != ctx.Repository.CommitHash {
+		return "", fmt.Errorf("default branch moved from %s to %s, skipping release", ctx.Repository.CommitHash, head.Hash)
+	}
+
+	// update changelog
+	released, err := specutil.ReleaseChangelog(path.Join(ctx.Directory, conf.Changelog.File), version, time.Now().Format(time.DateOnly))
+	if err != nil {
+		return "", err
+	}
+	if !released {
+		return ctx.Repository.CommitHash, nil
+	}
+
+	// commit and push to the default branch
+	commitMessage := fmt.Sprintf("chore: release v%s", version)
+	err = ctx.Platform.CommitAndPush(ctx.Repository, head.Hash, ctx.Repository.DefaultBranch, commitMessage, ctx.Directory)
+	if err != nil {
+		return "", fmt.Errorf("failed to commit and push changelog: %w", err)
+	}
+	slog.Info("released changelog", "repository", ctx.Repository.Namespace+"/"+ctx.Repository.Name, "version", version)
+
+	// the platform does not return the new commit, clone again to resolve it
+	releaseDir, err := os.MkdirTemp("", "vcs-app-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to prepare temp directory: %w", err)
+	}
+	defer os.RemoveAll(releaseDir)
+	ctx.Directory = releaseDir
+
+	helper = simpletask.New(ctx)
+	err = helper.Clone()
+	if err != nil {
+		return "", fmt.Errorf("failed to clone repository: %w", err)
+	}
+	releaseHead, err := helper.VCSClient.VCSHead()
+	if err != nil {
+		return "", fmt.Errorf("failed to get head: %w", err)
+	}
+	commit, err := helper.VCSClient.FindCommitByHash(releaseHead.Hash, false)
+	if err != nil {
+		return "", fmt.Errorf("failed to get release commit: %w", err)
+	}
+	if strings.TrimSpace(commit.Message) != commitMessage {
+		return "", fmt.Errorf("head of the default branch is not the release commit, found [%s]", commit.Message)
+	}
+
+	return releaseHead.Hash, nil
+}
+
+// commitMessagesSince clones the repository and returns the commit messages since the last tag
+func (n PrimeLibTagCreateTask) commitMessagesSince(ctx taskcommon.TaskContext, lastRelease *api.Tag) ([]string, error) {
+	tempDir, err := os.MkdirTemp("", "vcs-app-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare temp directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+	ctx.Directory = tempDir
+
+	// clone repository
+	helper := simpletask.New(ctx)
+	err = helper.Clone()
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone repository: %w", err)
+	}
+
+	commits, err := helper.VCSClient.FindCommitsBetween(nil, &vcsapi.VCSRef{Type: "tag", Value: lastRelease.Name, Hash: lastRelease.CommitHash}, false, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []string
+	for _, commit := range commits {
+		messages = append(messages, strings.TrimSpace(commit.Message+"\n"+commit.Description))
+	}
+	return messages, nil
+}
+
+// latestReleaseTag returns the tag with the highest semantic version, tags that are not a valid version are ignored
+func latestReleaseTag(tags []api.Tag) *api.Tag {
+	var versions []string
+	for _, tag := range tags {
+		if _, err := semver.StrictNewVersion(strings.TrimPrefix(tag.Name, "v")); err == nil {
+			versions = append(versions, strings.TrimPrefix(tag.Name, "v"))
+		}
+	}
+	if len(versions) == 0 {
+		return nil
+	}
+
+	highest := util.FindHighestVersion(versions)
+	for i, tag := range tags {
+		if strings.TrimPrefix(tag.Name, "v") == highest {
+			return &tags[i]
+		}
+	}
+	return nil
+}
+
+// commitMessageLevel determines the change level from conventional commit messages, feat = minor, ! or BREAKING CHANGE = breaking, everything else = patch
+func commitMessageLevel(messages []string) int {
+	level := 0
+	for _, message := range messages {
+		switch {
+		case breakingCommitPattern.MatchString(message) || strings.Contains(message, "BREAKING CHANGE"):
+			return specutil.LevelBreaking
+		case featureCommitPattern.MatchString(message):
+			level = max(level, specutil.LevelMinor)
+		default:
+			level = max(level, specutil.LevelPatch)
+		}
+	}
+
+	return level
+}
+
+func writeTempFile(content string) (string, error) {
+	file, err := os.CreateTemp("", "primelib-spec-*.yaml")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer file.Close()
+
+	_, err = file.WriteString(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to write to temp file: %w", err)
+	}
+
+	return file.Name(), nil
+}
+
 func NewTask() PrimeLibTagCreateTask {
 	return PrimeLibTagCreateTask{}
 }
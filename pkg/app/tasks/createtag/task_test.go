@@ -0,0 +1,33 @@
+package createtag
+
+import (
+	"testing"
+
+	"github.com/cidverse/go-vcsapp/pkg/platform/api"
+	"github.com/primelib/primecodegen/pkg/app/specutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitMessageLevel(t *testing.T) {
+	assert.Equal(t, 0, commitMessageLevel(nil))
+	assert.Equal(t, specutil.LevelPatch, commitMessageLevel([]string{"fix: typo", "chore(deps): update"}))
+	assert.Equal(t, specutil.LevelMinor, commitMessageLevel([]string{"fix: typo", "feat(client): add retries"}))
+	assert.Equal(t, specutil.LevelBreaking, commitMessageLevel([]string{"feat!: drop java 8"}))
+	assert.Equal(t, specutil.LevelBreaking, commitMessageLevel([]string{"refactor: rename\n\nBREAKING CHANGE: renamed client"}))
+}
+
+func TestLatestReleaseTag(t *testing.T) {
+	assert.Nil(t, latestReleaseTag(nil))
+	assert.Nil(t, latestReleaseTag([]api.Tag{{Name: "latest"}}))
+
+	tag := latestReleaseTag([]api.Tag{
+		{Name: "v1.9.0", CommitHash: "a"},
+		{Name: "nightly", CommitHash: "b"},
+		{Name: "v1.10.0", CommitHash: "c"},
+		{Name: "v1.10.0-rc.1", CommitHash: "d"},
+		{Name: "v0.12.3", CommitHash: "e"},
+	})
+	assert.NotNil(t, tag)
+	assert.Equal(t, "v1.10.0", tag.Name)
+	assert.Equal(t, "c", tag.CommitHash)
+}
@@ -35,6 +35,9 @@ func rootCmd() *cobra.Command {
 	cmd.AddGroup(&cobra.Group{ID: "openapi", Title: "OpenAPI Generation"})
 	cmd.AddCommand(openapicmd.ConvertCmd())
 	cmd.AddCommand(openapicmd.MergeCmd())
+	cmd.AddCommand(openapicmd.DiffCmd())
+	cmd.AddCommand(openapicmd.ChangelogCmd())
+	cmd.AddCommand(openapicmd.LintCmd())
 	cmd.AddCommand(openapicmd.PatchCmd())
 	cmd.AddCommand(openapicmd.GenerateCmd())
 	cmd.AddCommand(openapicmd.GenerateTemplateCmd())
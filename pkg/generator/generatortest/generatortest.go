@@ -0,0 +1,38 @@
+// Package generatortest provides the specifications and helpers that are shared by the generator tests
+package generatortest
+
+import (
+	_ "embed"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	// OperationBasic has a get operation with path and query parameters and a post operation with a required json body
+	//go:embed specs/operation-basic.yaml
+	OperationBasic []byte
+	// OperationMultipart has a multipart/form-data upload with a file, a text and an array part
+	//go:embed specs/operation-multipart.yaml
+	OperationMultipart []byte
+	// ModelBasic has a model with primitive, array, map, enum and model properties
+	//go:embed specs/model-basic.yaml
+	ModelBasic []byte
+	// ModelNaming has a model and properties named after reserved words and an enum with values that are not valid identifiers
+	//go:embed specs/model-naming.yaml
+	ModelNaming []byte
+	// ModelNullable has required and optional properties, with and without nullable
+	//go:embed specs/model-nullable.yaml
+	ModelNullable []byte
+)
+
+// ReadGeneratedFile reads a file from the output directory of a generator
+func ReadGeneratedFile(t *testing.T, outputDir string, name string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join(outputDir, name))
+	require.NoError(t, err)
+	return string(content)
+}
@@ -0,0 +1,494 @@
+package openapi_csharp
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/primelib/primecodegen/pkg/openapi/openapigenerator"
+	"github.com/primelib/primecodegen/pkg/template/templateapi"
+	"github.com/primelib/primecodegen/pkg/util"
+)
+
+type CSharpGenerator struct {
+	reservedWords  []string
+	primitiveTypes []string
+	typeToImport   map[string]string
+}
+
+func (g *CSharpGenerator) Id() string {
+	return "csharp"
+}
+
+func (g *CSharpGenerator) Description() string {
+	return "Generates C# client code"
+}
+
+func (g *CSharpGenerator) Generate(opts openapigenerator.GenerateOpts) error {
+	// check opts
+	if opts.Doc == nil {
+		return fmt.Errorf("document is required")
+	}
+
+	// required options
+	if opts.ArtifactId == "" {
+		return fmt.Errorf("artifact id is required, please set the --md-artifact-id flag")
+	}
+
+	// set packages, the group id is used as root namespace
+	rootNamespace := opts.ArtifactGroupId
+	if rootNamespace == "" {
+		rootNamespace = ToNamespace(opts.ArtifactId)
+	}
+	opts.PackageConfig = openapigenerator.CommonPackages{
+		Root:       rootNamespace,
+		Client:     rootNamespace,
+		Models:     rootNamespace + ".Models",
+		Responses:  rootNamespace + ".Models",
+		Enums:      rootNamespace + ".Models",
+		Operations: rootNamespace + ".Services",
+		Auth:       rootNamespace + ".Auth",
+	}
+
+	// build template data
+	templateData, err := g.TemplateData(openapigenerator.TemplateDataOpts{
+		Doc:           opts.Doc,
+		PackageConfig: opts.PackageConfig,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build template data in %s: %w", g.Id(), err)
+	}
+
+	// generate files
+	files, err := openapigenerator.GenerateFiles(fmt.Sprintf("openapi-%s-%s", g.Id(), opts.TemplateId), opts.OutputDir, templateData, templateapi.RenderOpts{
+		DryRun:               opts.DryRun,
+		Types:                nil,
+		IgnoreFiles:          nil,
+		IgnoreFileCategories: nil,
+		Properties:           map[string]string{},
+		TemplateFunctions: texttemplate.FuncMap{
+			"toClassName":     g.ToClassName,
+			"toFunctionName":  g.ToFunctionName,
+			"toPropertyName":  g.ToPropertyName,
+			"toParameterName": g.ToParameterName,
+			"isPrimitiveType": g.IsPrimitiveType,
+		},
+	}, opts)
+	if err != nil {
+		return fmt.Errorf("failed to generate files: %w", err)
+	}
+	for _, f := range files {
+		slog.Debug("Generated file", "file", f.File, "template-file", f.TemplateFile, "state", string(f.State))
+	}
+	slog.Info(fmt.Sprintf("Generated %d files", len(files)))
+
+	// delete old files (oldfiles - files)
+	oldFiles := openapigenerator.FilesListedInMetadata(opts.OutputDir)
+	for _, f := range oldFiles {
+		if _, ok := files[f]; !ok {
+			slog.Debug("Removing obsolete file", "file", f)
+			if !opts.DryRun {
+				err = openapigenerator.RemoveGeneratedFile(opts.OutputDir, f)
+				if err != nil {
+					return fmt.Errorf("failed to remove generated file: %w", err)
+				}
+			}
+		}
+	}
+
+	// post-processing (formatting)
+	err = g.PostProcessing(opts.OutputDir, files)
+	if err != nil {
+		return fmt.Errorf("failed to run post-processing: %w", err)
+	}
+
+	// write metadata
+	if !opts.DryRun {
+		err = openapigenerator.WriteMetadata(opts.OutputDir, files)
+		if err != nil {
+			return errors.Join(openapigenerator.ErrFailedToWriteMetadata, err)
+		}
+	}
+
+	return nil
+}
+
+func (g *CSharpGenerator) TemplateData(opts openapigenerator.TemplateDataOpts) (openapigenerator.DocumentModel, error) {
+	templateData, err := openapigenerator.BuildTemplateData(opts.Doc, g, opts.PackageConfig)
+	if err != nil {
+		return templateData, err
+	}
+	templateData = openapigenerator.PruneTypeAliases(templateData, g.primitiveTypes)
+	return templateData, nil
+}
+
+func (g *CSharpGenerator) ToClassName(name string) string {
+	name = util.ToPascalCase(g.sanitizeName(name))
+
+	if slices.Contains(g.reservedWords, strings.ToLower(name)) {
+		return name + "Model"
+	}
+	return name
+}
+
+func (g *CSharpGenerator) ToFunctionName(name string) string {
+	return util.ToPascalCase(g.sanitizeName(name))
+}
+
+func (g *CSharpGenerator) ToPropertyName(name string) string {
+	return util.ToPascalCase(g.sanitizeName(name))
+}
+
+// ToParameterName uses a verbatim identifier (e.g. @class) for parameters that collide with a keyword
+func (g *CSharpGenerator) ToParameterName(name string) string {
+	name = util.ToCamelCase(g.sanitizeName(name))
+
+	if slices.Contains(g.reservedWords, name) {
+		return "@" + name
+	}
+	return name
+}
+
+func (g *CSharpGenerator) ToConstantName(name string) string {
+	name = util.ToPascalCase(g.sanitizeName(name))
+	if name == "" {
+		return "Empty"
+	}
+	return name
+}
+
+func (g *CSharpGenerator) sanitizeName(name string) string {
+	// special case: starts with a digit
+	if len(name) > 0 && name[0] >= '0' && name[0] <= '9' {
+		name = "N" + name
+	}
+
+	// replace everything that is not allowed in an identifier
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || r == '-' || r == ' ' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+func (g *CSharpGenerator) ToCodeType(schema *base.Schema, schemaType openapigenerator.CodeTypeSchemaType, required bool) (openapigenerator.CodeType, error) {
+	if schema == nil {
+		return openapigenerator.DefaultCodeType, fmt.Errorf("schema is nil")
+	}
+
+	// multiple types (e.g., ["string", "integer"])
+	if util.CountExcluding(schema.Type, "null") > 1 {
+		return openapigenerator.CodeType{Name: "object"}, nil
+	}
+
+	switch {
+	case len(schema.Type) == 0 && len(schema.OneOf) > 0:
+		codeTypes := make([]openapigenerator.CodeType, 0, len(schema.OneOf))
+		for _, oneOfSchema := range schema.OneOf {
+			codeType, err := g.ToCodeType(oneOfSchema.Schema(), schemaType, true)
+			if err != nil {
+				return openapigenerator.DefaultCodeType, errors.Join(fmt.Errorf("unhandled oneOf type. schema: %s, format: %s", schema.Type, schema.Format), err)
+			}
+			codeTypes = append(codeTypes, codeType)
+		}
+
+		if openapigenerator.HaveSameCodeTypeName(codeTypes) {
+			return codeTypes[0], nil
+		}
+		return openapigenerator.CodeType{Name: "object"}, nil
+	case slices.Contains(schema.Type, "string"):
+		switch schema.Format {
+		case "date":
+			return openapigenerator.NewSimpleCodeType("DateOnly", schema), nil
+		case "date-time":
+			return openapigenerator.NewSimpleCodeType("DateTimeOffset", schema), nil
+		case "uuid":
+			return openapigenerator.NewSimpleCodeType("Guid", schema), nil
+		case "binary":
+			return openapigenerator.CodeType{Name: "byte[]"}, nil
+		default:
+			return openapigenerator.NewSimpleCodeType("string", schema), nil
+		}
+	case slices.Contains(schema.Type, "boolean"):
+		return openapigenerator.NewSimpleCodeType("bool", schema), nil
+	case slices.Contains(schema.Type, "integer"):
+		if schema.Format == "int64" {
+			return openapigenerator.NewSimpleCodeType("long", schema), nil
+		}
+		return openapigenerator.NewSimpleCodeType("int", schema), nil
+	case slices.Contains(schema.Type, "number"):
+		if schema.Format == "float" {
+			return openapigenerator.NewSimpleCodeType("float", schema), nil
+		}
+		return openapigenerator.NewSimpleCodeType("double", schema), nil
+	case slices.Contains(schema.Type, "array"):
+		if schema.Items == nil || schema.Items.A == nil {
+			return openapigenerator.DefaultCodeType, fmt.Errorf("array schema missing items definition")
+		}
+		arrayType, err := g.ToCodeType(schema.Items.A.Schema(), schemaType, true)
+		if err != nil {
+			return openapigenerator.DefaultCodeType, errors.Join(fmt.Errorf("unhandled array type. schema: %s, format: %s", schema.Type, schema.Format), err)
+		}
+		return openapigenerator.NewListCodeType(arrayType, schema), nil
+	case slices.Contains(schema.Type, "object") || schema.Type == nil:
+		if schema.PatternProperties != nil {
+			pp := schema.PatternProperties.First()
+			ppSchema := pp.Value().Schema()
+
+			additionalPropertyType, err := g.ToCodeType(ppSchema, schemaType, true)
+			if err != nil {
+				return openapigenerator.DefaultCodeType, errors.Join(fmt.Errorf("unhandled pattern properties type. schema: %s, format: %s", schema.Type, schema.Format), err)
+			}
+
+			return openapigenerator.NewMapCodeType(openapigenerator.NewSimpleCodeType("string", schema), additionalPropertyType, schema), nil
+		} else if schema.AdditionalProperties != nil && schema.AdditionalProperties.IsA() {
+			additionalPropertyType, err := g.ToCodeType(schema.AdditionalProperties.A.Schema(), schemaType, true)
+			if err != nil {
+				return openapigenerator.DefaultCodeType, errors.Join(fmt.Errorf("unhandled additional properties type. schema: %s, format: %s", schema.Type, schema.Format), err)
+			}
+
+			return openapigenerator.NewMapCodeType(openapigenerator.NewSimpleCodeType("string", schema), additionalPropertyType, schema), nil
+		} else if schema.AdditionalProperties != nil && schema.AdditionalProperties.IsB() && schema.AdditionalProperties.B {
+			return openapigenerator.NewMapCodeType(openapigenerator.NewSimpleCodeType("string", schema), openapigenerator.NewSimpleCodeType("object", schema), schema), nil
+		} else if schema.Properties == nil && len(schema.OneOf) == 0 && len(schema.AnyOf) == 0 && len(schema.AllOf) == 0 {
+			return openapigenerator.CodeType{Name: "object"}, nil
+		}
+
+		if schema.Title == "" {
+			return openapigenerator.DefaultCodeType, fmt.Errorf("schema does not have a title. schema: %s", schema.Type)
+		}
+		return openapigenerator.CodeType{Name: g.ToClassName(schema.Title), ImportPath: "Models"}, nil
+	default:
+		return openapigenerator.DefaultCodeType, fmt.Errorf("unhandled type. schema: %s, format: %s", schema.Type, schema.Format)
+	}
+}
+
+func (g *CSharpGenerator) PostProcessType(codeType openapigenerator.CodeType) openapigenerator.CodeType {
+	if codeType.IsPostProcessed {
+		return codeType
+	}
+
+	// VoidType
+	if codeType.IsVoid {
+		codeType.Declaration = "void"
+		codeType.QualifiedDeclaration = "void"
+		codeType.Type = "void"
+		codeType.QualifiedType = "void"
+		codeType.IsPostProcessed = true
+		return codeType
+	}
+
+	// PostProcess TypeArgs
+	for i, typeArg := range codeType.TypeArgs {
+		codeType.TypeArgs[i] = g.PostProcessType(typeArg)
+	}
+
+	// Qualifier
+	qualifier := ""
+	if codeType.ImportPath != "" {
+		parts := strings.Split(codeType.ImportPath, ".")
+		qualifier = parts[len(parts)-1] + "."
+	}
+
+	// FullyQualifiedName
+	switch {
+	case codeType.IsArray || codeType.IsList:
+		codeType.Declaration = "List<" + codeType.TypeArgs[0].Declaration + ">"
+		codeType.QualifiedDeclaration = "List<" + codeType.TypeArgs[0].QualifiedDeclaration + ">"
+		codeType.Type = "List<" + codeType.TypeArgs[0].Type + ">"
+		codeType.QualifiedType = "List<" + codeType.TypeArgs[0].QualifiedType + ">"
+	case codeType.IsMap:
+		codeType.Declaration = "Dictionary<" + codeType.TypeArgs[0].Declaration + ", " + codeType.TypeArgs[1].Declaration + ">"
+		codeType.QualifiedDeclaration = "Dictionary<" + codeType.TypeArgs[0].QualifiedDeclaration + ", " + codeType.TypeArgs[1].QualifiedDeclaration + ">"
+		codeType.Type = "Dictionary<" + codeType.TypeArgs[0].Type + ", " + codeType.TypeArgs[1].Type + ">"
+		codeType.QualifiedType = "Dictionary<" + codeType.TypeArgs[0].QualifiedType + ", " + codeType.TypeArgs[1].QualifiedType + ">"
+	default:
+		codeType.Declaration = codeType.Name
+		codeType.QualifiedDeclaration = qualifier + codeType.Name
+		codeType.Type = codeType.Name
+		codeType.QualifiedType = qualifier + codeType.Name
+	}
+
+	codeType.IsPostProcessed = true
+	return codeType
+}
+
+func (g *CSharpGenerator) IsPrimitiveType(input string) bool {
+	return slices.Contains(g.primitiveTypes, input)
+}
+
+func (g *CSharpGenerator) TypeToImport(iType openapigenerator.CodeType) string {
+	typeName := iType.Name
+	if typeName == "" {
+		return ""
+	}
+
+	return g.typeToImport[typeName]
+}
+
+const dotnetBinary = "dotnet"
+
+func (g *CSharpGenerator) PostProcessing(outputDir string, files map[string]templateapi.RenderedFile) error {
+	if os.Getenv("PRIMECODEGEN_SKIP_POST_PROCESSING") == "true" {
+		slog.Debug("Skipping post processing csharp files")
+		return nil
+	}
+
+	if openapigenerator.IsBinaryAvailable(dotnetBinary) {
+		var formatFiles []string
+		for _, f := range files {
+			if strings.HasSuffix(f.File, ".cs") && f.State == templateapi.FileRendered {
+				if rel, err := filepath.Rel(outputDir, f.File); err == nil {
+					formatFiles = append(formatFiles, rel)
+				}
+			}
+		}
+		if len(formatFiles) == 0 {
+			return nil
+		}
+
+		slog.Debug("Post processing cs files using "+dotnetBinary+" format", "file_len", len(formatFiles))
+		cmd := exec.Command(dotnetBinary, "format", "whitespace", "--folder", "--include")
+		cmd.Args = append(cmd.Args, formatFiles...)
+		cmd.Dir = outputDir
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		err := cmd.Run()
+		if err != nil {
+			return fmt.Errorf("error running %s: %v", dotnetBinary, err)
+		}
+	}
+
+	return nil
+}
+
+// ToNamespace converts a package id (e.g. "acme-petstore") into a namespace (e.g. "Acme.Petstore")
+func ToNamespace(name string) string {
+	segments := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '.' || r == '-' || r == '_' || r == '/' || r == '@'
+	})
+	for i, segment := range segments {
+		segments[i] = util.ToPascalCase(segment)
+	}
+
+	return strings.Join(segments, ".")
+}
+
+func NewGenerator() *CSharpGenerator {
+	// references: https://learn.microsoft.com/en-us/dotnet/csharp/language-reference/keywords/
+	return &CSharpGenerator{
+		reservedWords: []string{
+			"abstract",
+			"as",
+			"base",
+			"bool",
+			"break",
+			"byte",
+			"case",
+			"catch",
+			"char",
+			"checked",
+			"class",
+			"const",
+			"continue",
+			"decimal",
+			"default",
+			"delegate",
+			"do",
+			"double",
+			"else",
+			"enum",
+			"event",
+			"explicit",
+			"extern",
+			"false",
+			"finally",
+			"fixed",
+			"float",
+			"for",
+			"foreach",
+			"goto",
+			"if",
+			"implicit",
+			"in",
+			"int",
+			"interface",
+			"internal",
+			"is",
+			"lock",
+			"long",
+			"namespace",
+			"new",
+			"null",
+			"object",
+			"operator",
+			"out",
+			"override",
+			"params",
+			"private",
+			"protected",
+			"public",
+			"readonly",
+			"ref",
+			"return",
+			"sbyte",
+			"sealed",
+			"short",
+			"sizeof",
+			"stackalloc",
+			"static",
+			"string",
+			"struct",
+			"switch",
+			"this",
+			"throw",
+			"true",
+			"try",
+			"typeof",
+			"uint",
+			"ulong",
+			"unchecked",
+			"unsafe",
+			"ushort",
+			"using",
+			"virtual",
+			"void",
+			"volatile",
+			"while",
+			// type names that would collide with the generated code or common namespaces
+			"action",
+			"dateonly",
+			"datetimeoffset",
+			"dictionary",
+			"func",
+			"guid",
+			"list",
+			"options",
+			"cancellationtoken",
+			"task",
+			"type",
+		},
+		primitiveTypes: []string{
+			"string",
+			"bool",
+			"int",
+			"long",
+			"float",
+			"double",
+			"object",
+			"byte[]",
+			"Guid",
+			"DateOnly",
+			"DateTimeOffset",
+		},
+		typeToImport: map[string]string{},
+	}
+}
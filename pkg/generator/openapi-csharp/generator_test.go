@@ -0,0 +1,189 @@
+package openapi_csharp
+
+import (
+	"testing"
+
+	"github.com/primelib/primecodegen/pkg/generator/generatortest"
+	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
+	"github.com/primelib/primecodegen/pkg/openapi/openapigenerator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var commonPackages = openapigenerator.CommonPackages{
+	Root:       "Example.Pets",
+	Client:     "Example.Pets",
+	Models:     "Example.Pets.Models",
+	Responses:  "Example.Pets.Models",
+	Enums:      "Example.Pets.Models",
+	Operations: "Example.Pets.Services",
+	Auth:       "Example.Pets.Auth",
+}
+
+func TestOperationBasic(t *testing.T) {
+	// arrange
+	v3doc := openapidocument.OpenV3DocumentForTest(generatortest.OperationBasic)
+
+	// act
+	templateData, err := openapigenerator.BuildTemplateData(v3doc, NewGenerator(), commonPackages)
+	assert.NoError(t, err)
+	assert.NotNil(t, templateData)
+
+	// assert
+	assert.Len(t, templateData.Operations, 2)
+	assert.Equal(t, "GetPet", templateData.Operations[0].Name)
+	assert.Equal(t, "get", templateData.Operations[0].Method)
+	assert.Equal(t, "/pets/{petId}", templateData.Operations[0].Path)
+	assert.Equal(t, "Models.Pet", templateData.Operations[0].ReturnType.QualifiedDeclaration)
+	assert.Len(t, templateData.Operations[0].PathParameters, 1)
+	assert.Equal(t, "petId", templateData.Operations[0].PathParameters[0].Name)
+	assert.Equal(t, "string", templateData.Operations[0].PathParameters[0].Type.QualifiedDeclaration)
+	assert.Len(t, templateData.Operations[0].QueryParameters, 1)
+	assert.Equal(t, "fields", templateData.Operations[0].QueryParameters[0].Name)
+	assert.Equal(t, "List<string>", templateData.Operations[0].QueryParameters[0].Type.QualifiedDeclaration)
+	assert.Equal(t, "CreatePet", templateData.Operations[1].Name)
+	assert.Equal(t, "post", templateData.Operations[1].Method)
+	assert.NotNil(t, templateData.Operations[1].BodyParameter)
+	assert.Equal(t, "Models.Pet", templateData.Operations[1].BodyParameter.Type.QualifiedDeclaration)
+	assert.True(t, templateData.Operations[1].RequestBodyRequired)
+}
+
+func TestModelBasic(t *testing.T) {
+	// arrange
+	v3doc := openapidocument.OpenV3DocumentForTest(generatortest.ModelBasic)
+
+	// act
+	templateData, err := openapigenerator.BuildTemplateData(v3doc, NewGenerator(), commonPackages)
+	assert.NoError(t, err)
+	assert.NotNil(t, templateData)
+
+	// assert
+	assert.Len(t, templateData.Models, 2)
+	assert.Equal(t, "Pet", templateData.Models[0].Name)
+	properties := templateData.Models[0].Properties
+	assert.Len(t, properties, 9)
+	assert.Equal(t, "Name", properties[0].Name)
+	assert.Equal(t, "string", properties[0].Type.QualifiedDeclaration)
+	assert.Equal(t, "Age", properties[1].Name)
+	assert.Equal(t, "long", properties[1].Type.QualifiedDeclaration)
+	assert.Equal(t, "Tags", properties[2].Name)
+	assert.Equal(t, "List<string>", properties[2].Type.QualifiedDeclaration)
+	assert.Equal(t, "Owner", properties[4].Name)
+	assert.Equal(t, "Models.Owner", properties[4].Type.QualifiedDeclaration)
+	assert.Equal(t, "Metadata", properties[5].Name)
+	assert.Equal(t, "Dictionary<string, object>", properties[5].Type.QualifiedDeclaration)
+	assert.Equal(t, "Type", properties[6].Name)
+	assert.Equal(t, "Class", properties[7].Name)
+	assert.Equal(t, "ContentType", properties[8].Name)
+	assert.Equal(t, "content-type", properties[8].FieldName)
+	assert.Equal(t, "Owner", templateData.Models[1].Name)
+	assert.Len(t, templateData.Enums, 1)
+	assert.Equal(t, "PetStatus", templateData.Enums[0].Name)
+}
+
+func TestGenerateOperationBasic(t *testing.T) {
+	// arrange
+	t.Setenv("PRIMECODEGEN_SKIP_POST_PROCESSING", "true")
+	outputDir := t.TempDir()
+
+	// act
+	err := NewGenerator().Generate(openapigenerator.GenerateOpts{
+		Doc:             openapidocument.OpenV3DocumentForTest(generatortest.OperationBasic),
+		OutputDir:       outputDir,
+		TemplateId:      "httpclient",
+		ArtifactGroupId: "Example.Pets",
+		ArtifactId:      "pet-client",
+	})
+	assert.NoError(t, err)
+
+	// assert
+	service := generatortest.ReadGeneratedFile(t, outputDir, "src/Services/PetsApi.cs")
+	assert.Contains(t, service, "namespace Example.Pets.Services;")
+	assert.Contains(t, service, "public sealed class PetsApi")
+	assert.Contains(t, service, "public Task<ApiResponse<Models.Pet>> GetPetAsync(string petId, List<string>? fields = null, RequestOptions? options = null, CancellationToken cancellationToken = default)")
+	assert.Contains(t, service, `var request = new ApiRequest(new HttpMethod("GET"), $"/pets/{ApiRequest.EncodePath(petId)}");`)
+	assert.Contains(t, service, `request.AddQuery("fields", fields, true, ",");`)
+	assert.Contains(t, service, "public Task<ApiResponse<Models.Pet>> CreatePetAsync(Models.Pet payload, RequestOptions? options = null, CancellationToken cancellationToken = default)")
+	assert.Contains(t, service, "request.Body = payload;")
+	client := generatortest.ReadGeneratedFile(t, outputDir, "src/PetClient.cs")
+	assert.Contains(t, client, "namespace Example.Pets;")
+	assert.Contains(t, client, "Pets = new PetsApi(Http);")
+	assert.Contains(t, generatortest.ReadGeneratedFile(t, outputDir, "src/pet-client.csproj"), "<PackageId>pet-client</PackageId>")
+}
+
+func TestGenerateModelNaming(t *testing.T) {
+	// arrange
+	t.Setenv("PRIMECODEGEN_SKIP_POST_PROCESSING", "true")
+	outputDir := t.TempDir()
+
+	// act
+	err := NewGenerator().Generate(openapigenerator.GenerateOpts{
+		Doc:             openapidocument.OpenV3DocumentForTest(generatortest.ModelNaming),
+		OutputDir:       outputDir,
+		TemplateId:      "httpclient",
+		ArtifactGroupId: "Example.Pets",
+		ArtifactId:      "pet-client",
+	})
+	assert.NoError(t, err)
+
+	// assert: keywords are safe as PascalCase properties, the wire name is kept in JsonPropertyName
+	model := generatortest.ReadGeneratedFile(t, outputDir, "src/Models/ClassModel.cs")
+	assert.Contains(t, model, "public record ClassModel")
+	assert.Contains(t, model, "    [JsonPropertyName(\"default\")]\n    public string? Default { get; init; }")
+	assert.Contains(t, model, "    [JsonPropertyName(\"async\")]\n    public bool? Async { get; init; }")
+	assert.Contains(t, model, "    [JsonPropertyName(\"1st-place\")]\n    public bool? N1StPlace { get; init; }")
+	enum := generatortest.ReadGeneratedFile(t, outputDir, "src/Models/TaskStatus.cs")
+	assert.Contains(t, enum, "    [JsonStringEnumMemberName(\"2fa-required\")]\n    N2FaRequired,")
+	assert.Contains(t, enum, "    [JsonStringEnumMemberName(\"class\")]\n    Class,")
+	assert.Contains(t, enum, "    [JsonStringEnumMemberName(\"in-progress\")]\n    InProgress,")
+}
+
+func TestGenerateModelNullable(t *testing.T) {
+	// arrange
+	t.Setenv("PRIMECODEGEN_SKIP_POST_PROCESSING", "true")
+	outputDir := t.TempDir()
+
+	// act
+	err := NewGenerator().Generate(openapigenerator.GenerateOpts{
+		Doc:             openapidocument.OpenV3DocumentForTest(generatortest.ModelNullable),
+		OutputDir:       outputDir,
+		TemplateId:      "httpclient",
+		ArtifactGroupId: "Example.Pets",
+		ArtifactId:      "pet-client",
+	})
+	assert.NoError(t, err)
+
+	// assert: Task would clash with System.Threading.Tasks.Task, reference and value types are nullable
+	model := generatortest.ReadGeneratedFile(t, outputDir, "src/Models/TaskModel.cs")
+	assert.Contains(t, model, "#nullable enable")
+	assert.Contains(t, model, "public record TaskModel")
+	assert.Contains(t, model, "    public string? ID { get; init; }")
+	assert.Contains(t, model, "    public DateTimeOffset? DeletedAt { get; init; }")
+	assert.Contains(t, model, "    public int? Priority { get; init; }")
+}
+
+func TestOperationMultipart(t *testing.T) {
+	// arrange
+	v3doc := openapidocument.OpenV3DocumentForTest(generatortest.OperationMultipart)
+
+	// act
+	templateData, err := openapigenerator.BuildTemplateData(v3doc, NewGenerator(), commonPackages)
+	assert.NoError(t, err)
+
+	// assert
+	require.Len(t, templateData.Operations, 1)
+	form := templateData.Operations[0].Form
+	require.NotNil(t, form)
+	assert.True(t, form.Multipart)
+	require.Len(t, form.Parts, 3)
+	assert.Equal(t, "File", form.Parts[0].Name)
+	assert.Equal(t, "file", form.Parts[0].FieldName)
+	assert.Equal(t, "byte[]", form.Parts[0].Type.QualifiedDeclaration)
+	assert.True(t, form.Parts[0].IsFile)
+	assert.Equal(t, "image/png", form.Parts[0].ContentType)
+	assert.Equal(t, "Caption", form.Parts[1].Name)
+	assert.Equal(t, "string", form.Parts[1].Type.QualifiedDeclaration)
+	assert.Equal(t, "Tags", form.Parts[2].Name)
+	assert.True(t, form.Parts[2].IsArray)
+	assert.Equal(t, "string", form.Parts[2].ItemType.QualifiedDeclaration)
+}
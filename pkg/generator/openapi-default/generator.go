@@ -92,9 +92,11 @@ func (g *DefaultGenerator) Generate(opts openapigenerator.GenerateOpts) error {
 	}
 
 	// write metadata
-	err = openapigenerator.WriteMetadata(opts.OutputDir, files)
-	if err != nil {
-		return errors.Join(openapigenerator.ErrFailedToWriteMetadata, err)
+	if !opts.DryRun {
+		err = openapigenerator.WriteMetadata(opts.OutputDir, files)
+		if err != nil {
+			return errors.Join(openapigenerator.ErrFailedToWriteMetadata, err)
+		}
 	}
 
 	return nil
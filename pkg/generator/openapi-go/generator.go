@@ -28,7 +28,7 @@ func (g *GoGenerator) Id() string {
 }
 
 func (g *GoGenerator) Description() string {
-	return "Generates Go client code"
+	return "Generates Go client and server code"
 }
 
 func (g *GoGenerator) Generate(opts openapigenerator.GenerateOpts) error {
@@ -106,9 +106,11 @@ func (g *GoGenerator) Generate(opts openapigenerator.GenerateOpts) error {
 	}
 
 	// write metadata
-	err = openapigenerator.WriteMetadata(opts.OutputDir, files)
-	if err != nil {
-		return errors.Join(openapigenerator.ErrFailedToWriteMetadata, err)
+	if !opts.DryRun {
+		err = openapigenerator.WriteMetadata(opts.OutputDir, files)
+		if err != nil {
+			return errors.Join(openapigenerator.ErrFailedToWriteMetadata, err)
+		}
 	}
 
 	return nil
@@ -193,7 +195,9 @@ func (g *GoGenerator) ToCodeType(schema *base.Schema, schemaType openapigenerato
 		case "uri":
 			return openapigenerator.NewSimpleCodeType("string", schema), nil
 		case "binary", "byte":
-			return openapigenerator.NewArrayCodeType(openapigenerator.NewSimpleCodeType("byte", schema), schema), nil
+			byteType := openapigenerator.NewSimpleCodeType("byte", schema)
+			byteType.IsNullable = false
+			return openapigenerator.NewArrayCodeType(byteType, schema), nil
 		case "date", "date-time":
 			return openapigenerator.NewSimpleCodeType("string", schema), nil
 		default:
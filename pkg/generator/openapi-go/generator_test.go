@@ -0,0 +1,139 @@
+package openapi_go
+
+import (
+	_ "embed"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
+	"github.com/primelib/primecodegen/pkg/openapi/openapigenerator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToCodeTypeBinaryIsByteSlice(t *testing.T) {
+	g := NewGenerator()
+
+	codeType, err := g.ToCodeType(&base.Schema{Type: []string{"string"}, Format: "binary"}, openapigenerator.CodeTypeSchemaProperty, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "[]byte", g.PostProcessType(codeType).QualifiedDeclaration)
+}
+
+func TestToCodeTypeQualifiesModels(t *testing.T) {
+	g := NewGenerator()
+
+	codeType, err := g.ToCodeType(&base.Schema{
+		Type:  []string{"object"},
+		Title: "pet",
+		AllOf: []*base.SchemaProxy{
+			base.CreateSchemaProxy(&base.Schema{Type: []string{"object"}}),
+		},
+	}, openapigenerator.CodeTypeSchemaProperty, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "*models.Pet", g.PostProcessType(codeType).QualifiedDeclaration)
+}
+
+var (
+	//go:embed specs/server-operations.yaml
+	serverOperations []byte
+)
+
+func operationByName(t *testing.T, templateData openapigenerator.DocumentModel, name string) openapigenerator.Operation {
+	for _, op := range templateData.Operations {
+		if op.Name == name {
+			return op
+		}
+	}
+	require.FailNow(t, "operation not found", name)
+	return openapigenerator.Operation{}
+}
+
+func TestServerOperations(t *testing.T) {
+	// arrange
+	v3doc := openapidocument.OpenV3DocumentForTest(serverOperations)
+
+	// act
+	templateData, err := openapigenerator.BuildTemplateData(v3doc, NewGenerator(), openapigenerator.CommonPackages{Models: "models", Enums: "enums"})
+	require.NoError(t, err)
+
+	// assert
+	assert.Len(t, templateData.Operations, 4)
+	createPet := operationByName(t, templateData, "CreatePet")
+	assert.False(t, createPet.RequestBodyRequired)
+	assert.Equal(t, []string{"application/json"}, createPet.RequestContentTypes)
+	assert.Nil(t, createPet.Form)
+
+	uploadPhoto := operationByName(t, templateData, "UploadPhoto")
+	assert.True(t, uploadPhoto.RequestBodyRequired)
+	require.NotNil(t, uploadPhoto.Form)
+	assert.True(t, uploadPhoto.Form.Multipart)
+	require.Len(t, uploadPhoto.Form.Parts, 3)
+	assert.True(t, uploadPhoto.Form.Parts[0].IsFile)
+	assert.True(t, uploadPhoto.Form.Parts[2].IsJSON)
+
+	renamePet := operationByName(t, templateData, "RenamePet")
+	require.NotNil(t, renamePet.Form)
+	assert.False(t, renamePet.Form.Multipart)
+}
+
+func TestServerTemplate(t *testing.T) {
+	// arrange
+	t.Setenv("PRIMECODEGEN_SKIP_POST_PROCESSING", "true")
+	v3doc := openapidocument.OpenV3DocumentForTest(serverOperations)
+	outputDir := t.TempDir()
+
+	// act
+	err := NewGenerator().Generate(openapigenerator.GenerateOpts{
+		Doc:        v3doc,
+		OutputDir:  outputDir,
+		TemplateId: "server",
+		ArtifactId: "example.com/pets",
+	})
+	require.NoError(t, err)
+
+	// assert - all go files are valid
+	files, err := filepath.Glob(filepath.Join(outputDir, "*", "*.go"))
+	require.NoError(t, err)
+	models, err := filepath.Glob(filepath.Join(outputDir, "pkgs", "*", "*.go"))
+	require.NoError(t, err)
+	files = append(files, models...)
+	assert.NotEmpty(t, models)
+	for _, file := range files {
+		_, err = parser.ParseFile(token.NewFileSet(), file, nil, parser.AllErrors)
+		assert.NoError(t, err, file)
+	}
+
+	// assert - request decoding
+	service := readGeneratedFile(t, filepath.Join(outputDir, "server", "service-pets.go"))
+	assert.Contains(t, service, `decodeBody(r, false, []string{"application/json"}, &req.Payload)`)
+	assert.Contains(t, service, `decodeForm(r, true)`)
+	assert.Contains(t, service, `decodeFormFile(r, "file", true, &payload.File)`)
+	assert.Contains(t, service, `decodeFormJSON(r, "metadata", false, &payload.Metadata)`)
+	assert.Contains(t, service, `decodeParameter("form", "tags", r.PostForm["tags"], false, true, ",", nil, &payload.Tags)`)
+
+	// assert - typed respond helpers
+	assert.Contains(t, service, "func (r *ListPetsResponse) Respond200(body []*models.Pet) error")
+	assert.Contains(t, service, "func (r *ListPetsResponse) RespondDefault(statusCode int, body *models.Error) error")
+	assert.NotContains(t, service, "func (r *CreatePetResponse) RespondDefault(")
+	server := readGeneratedFile(t, filepath.Join(outputDir, "server", "server.go"))
+	assert.Contains(t, server, "http.StatusUnsupportedMediaType")
+
+	// assert - models are rendered with the client templates
+	model := readGeneratedFile(t, filepath.Join(outputDir, "pkgs", "models", "Pet.go"))
+	assert.Contains(t, model, "type Pet struct")
+	assert.Contains(t, model, "primecodegen:custom-begin methods")
+	assert.Contains(t, model, "// primecodegen:custom-begin imports\n// primecodegen:custom-end imports\n")
+	assert.Less(t, strings.Index(model, "primecodegen:custom-begin imports"), strings.Index(model, "type Pet struct"))
+	assert.FileExists(t, filepath.Join(outputDir, "pkgs", "enums", "PetStatus.go"))
+}
+
+func readGeneratedFile(t *testing.T, file string) string {
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	return string(content)
+}
@@ -120,9 +120,11 @@ func (g *JavaGenerator) Generate(opts openapigenerator.GenerateOpts) error {
 	}
 
 	// write metadata
-	err = openapigenerator.WriteMetadata(opts.OutputDir, files)
-	if err != nil {
-		return errors.Join(openapigenerator.ErrFailedToWriteMetadata, err)
+	if !opts.DryRun {
+		err = openapigenerator.WriteMetadata(opts.OutputDir, files)
+		if err != nil {
+			return errors.Join(openapigenerator.ErrFailedToWriteMetadata, err)
+		}
 	}
 
 	return nil
@@ -105,9 +105,11 @@ func (g *KotlinMultiplatformGenerator) Generate(opts openapigenerator.GenerateOp
 		return fmt.Errorf("failed to run post-processing: %w", err)
 	}
 
-	err = openapigenerator.WriteMetadata(opts.OutputDir, files)
-	if err != nil {
-		return errors.Join(openapigenerator.ErrFailedToWriteMetadata, err)
+	if !opts.DryRun {
+		err = openapigenerator.WriteMetadata(opts.OutputDir, files)
+		if err != nil {
+			return errors.Join(openapigenerator.ErrFailedToWriteMetadata, err)
+		}
 	}
 
 	return nil
@@ -119,9 +119,11 @@ func (g *KotlinGenerator) Generate(opts openapigenerator.GenerateOpts) error {
 	}
 
 	// write metadata
-	err = openapigenerator.WriteMetadata(opts.OutputDir, files)
-	if err != nil {
-		return errors.Join(openapigenerator.ErrFailedToWriteMetadata, err)
+	if !opts.DryRun {
+		err = openapigenerator.WriteMetadata(opts.OutputDir, files)
+		if err != nil {
+			return errors.Join(openapigenerator.ErrFailedToWriteMetadata, err)
+		}
 	}
 
 	return nil
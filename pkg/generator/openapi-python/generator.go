@@ -0,0 +1,483 @@
+package openapi_python
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+	"unicode"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/primelib/primecodegen/pkg/openapi/openapigenerator"
+	"github.com/primelib/primecodegen/pkg/template/templateapi"
+	"github.com/primelib/primecodegen/pkg/util"
+)
+
+type PythonGenerator struct {
+	reservedWords  []string
+	primitiveTypes []string
+	typeToImport   map[string]string
+}
+
+func (g *PythonGenerator) Id() string {
+	return "python"
+}
+
+func (g *PythonGenerator) Description() string {
+	return "Generates Python client code"
+}
+
+func (g *PythonGenerator) Generate(opts openapigenerator.GenerateOpts) error {
+	// check opts
+	if opts.Doc == nil {
+		return fmt.Errorf("document is required")
+	}
+
+	// required options
+	if opts.ArtifactId == "" {
+		return fmt.Errorf("artifact id is required, please set the --md-artifact-id flag")
+	}
+
+	// set packages
+	rootPackage := ToModuleName(opts.ArtifactId)
+	opts.PackageConfig = openapigenerator.CommonPackages{
+		Root:       rootPackage,
+		Client:     rootPackage,
+		Models:     rootPackage + ".models",
+		Responses:  rootPackage + ".models",
+		Enums:      rootPackage + ".models",
+		Operations: rootPackage + ".services",
+		Auth:       rootPackage + ".auth",
+	}
+
+	// build template data
+	templateData, err := g.TemplateData(openapigenerator.TemplateDataOpts{
+		Doc:           opts.Doc,
+		PackageConfig: opts.PackageConfig,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build template data in %s: %w", g.Id(), err)
+	}
+
+	// generate files
+	files, err := openapigenerator.GenerateFiles(fmt.Sprintf("openapi-%s-%s", g.Id(), opts.TemplateId), opts.OutputDir, templateData, templateapi.RenderOpts{
+		DryRun:               opts.DryRun,
+		Types:                nil,
+		IgnoreFiles:          nil,
+		IgnoreFileCategories: nil,
+		Properties:           map[string]string{},
+		TemplateFunctions: texttemplate.FuncMap{
+			"toClassName":     g.ToClassName,
+			"toFunctionName":  g.ToFunctionName,
+			"toPropertyName":  g.ToPropertyName,
+			"toParameterName": g.ToParameterName,
+			"isPrimitiveType": g.IsPrimitiveType,
+		},
+	}, opts)
+	if err != nil {
+		return fmt.Errorf("failed to generate files: %w", err)
+	}
+	for _, f := range files {
+		slog.Debug("Generated file", "file", f.File, "template-file", f.TemplateFile, "state", string(f.State))
+	}
+	slog.Info(fmt.Sprintf("Generated %d files", len(files)))
+
+	// delete old files (oldfiles - files)
+	oldFiles := openapigenerator.FilesListedInMetadata(opts.OutputDir)
+	for _, f := range oldFiles {
+		if _, ok := files[f]; !ok {
+			slog.Debug("Removing obsolete file", "file", f)
+			if !opts.DryRun {
+				err = openapigenerator.RemoveGeneratedFile(opts.OutputDir, f)
+				if err != nil {
+					return fmt.Errorf("failed to remove generated file: %w", err)
+				}
+			}
+		}
+	}
+
+	// post-processing (formatting)
+	err = g.PostProcessing(files)
+	if err != nil {
+		return fmt.Errorf("failed to run post-processing: %w", err)
+	}
+
+	// write metadata
+	if !opts.DryRun {
+		err = openapigenerator.WriteMetadata(opts.OutputDir, files)
+		if err != nil {
+			return errors.Join(openapigenerator.ErrFailedToWriteMetadata, err)
+		}
+	}
+
+	return nil
+}
+
+func (g *PythonGenerator) TemplateData(opts openapigenerator.TemplateDataOpts) (openapigenerator.DocumentModel, error) {
+	return openapigenerator.BuildTemplateData(opts.Doc, g, opts.PackageConfig)
+}
+
+func (g *PythonGenerator) ToClassName(name string) string {
+	name = util.ToPascalCase(g.sanitizeName(name))
+
+	if slices.Contains(g.reservedWords, strings.ToLower(name)) {
+		return name + "Model"
+	}
+	return name
+}
+
+func (g *PythonGenerator) ToFunctionName(name string) string {
+	name = toSnakeCase(g.sanitizeName(name))
+
+	if slices.Contains(g.reservedWords, name) {
+		return name + "_"
+	}
+	return name
+}
+
+func (g *PythonGenerator) ToPropertyName(name string) string {
+	// pydantic treats attributes with a leading underscore as private
+	name = strings.TrimLeft(toSnakeCase(g.sanitizeName(name)), "_")
+	if name == "" {
+		name = "field"
+	}
+
+	if slices.Contains(g.reservedWords, name) {
+		return name + "_"
+	}
+	return name
+}
+
+func (g *PythonGenerator) ToParameterName(name string) string {
+	name = strings.TrimLeft(toSnakeCase(g.sanitizeName(name)), "_")
+
+	if slices.Contains(g.reservedWords, name) {
+		return name + "_"
+	}
+	return name
+}
+
+func (g *PythonGenerator) ToConstantName(name string) string {
+	name = strings.ToUpper(toSnakeCase(g.sanitizeName(name)))
+
+	if slices.Contains(g.reservedWords, strings.ToLower(name)) {
+		return name + "_"
+	}
+	return name
+}
+
+func (g *PythonGenerator) sanitizeName(name string) string {
+	// special case: starts with a digit
+	if len(name) > 0 && name[0] >= '0' && name[0] <= '9' {
+		name = "n" + name
+	}
+
+	// replace everything that is not allowed in an identifier
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || r == '-' || r == ' ' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// toSnakeCase converts a name to snake_case, unlike util.ToSnakeCase digits are not split from the preceding word (e.g. "GetPetsV1" -> "get_pets_v1")
+func toSnakeCase(name string) string {
+	runes := []rune(strings.TrimSpace(name))
+	var sb strings.Builder
+	for i, r := range runes {
+		switch {
+		case r == '-' || r == ' ' || r == '_':
+			if sb.Len() > 0 && !strings.HasSuffix(sb.String(), "_") {
+				sb.WriteRune('_')
+			}
+			continue
+		case unicode.IsUpper(r) && i > 0:
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if (unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower)) && !strings.HasSuffix(sb.String(), "_") {
+				sb.WriteRune('_')
+			}
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+
+	return strings.TrimSuffix(sb.String(), "_")
+}
+
+func (g *PythonGenerator) ToCodeType(schema *base.Schema, schemaType openapigenerator.CodeTypeSchemaType, required bool) (openapigenerator.CodeType, error) {
+	if schema == nil {
+		return openapigenerator.DefaultCodeType, fmt.Errorf("schema is nil")
+	}
+
+	// multiple types (e.g., ["string", "integer"])
+	if util.CountExcluding(schema.Type, "null") > 1 {
+		return openapigenerator.CodeType{Name: "Any"}, nil
+	}
+
+	switch {
+	case len(schema.Type) == 0 && len(schema.OneOf) > 0:
+		codeTypes := make([]openapigenerator.CodeType, 0, len(schema.OneOf))
+		for _, oneOfSchema := range schema.OneOf {
+			codeType, err := g.ToCodeType(oneOfSchema.Schema(), schemaType, true)
+			if err != nil {
+				return openapigenerator.DefaultCodeType, errors.Join(fmt.Errorf("unhandled oneOf type. schema: %s, format: %s", schema.Type, schema.Format), err)
+			}
+			codeTypes = append(codeTypes, codeType)
+		}
+
+		if openapigenerator.HaveSameCodeTypeName(codeTypes) {
+			return codeTypes[0], nil
+		}
+		return openapigenerator.CodeType{Name: "Any"}, nil
+	case slices.Contains(schema.Type, "string"):
+		switch schema.Format {
+		case "date":
+			return openapigenerator.NewSimpleCodeType("datetime.date", schema), nil
+		case "date-time":
+			return openapigenerator.NewSimpleCodeType("datetime.datetime", schema), nil
+		case "uuid":
+			return openapigenerator.NewSimpleCodeType("UUID", schema), nil
+		case "binary":
+			return openapigenerator.CodeType{Name: "bytes"}, nil
+		default:
+			return openapigenerator.NewSimpleCodeType("str", schema), nil
+		}
+	case slices.Contains(schema.Type, "boolean"):
+		return openapigenerator.NewSimpleCodeType("bool", schema), nil
+	case slices.Contains(schema.Type, "integer"):
+		return openapigenerator.NewSimpleCodeType("int", schema), nil
+	case slices.Contains(schema.Type, "number"):
+		return openapigenerator.NewSimpleCodeType("float", schema), nil
+	case slices.Contains(schema.Type, "array"):
+		if schema.Items == nil || schema.Items.A == nil {
+			return openapigenerator.DefaultCodeType, fmt.Errorf("array schema missing items definition")
+		}
+		arrayType, err := g.ToCodeType(schema.Items.A.Schema(), schemaType, true)
+		if err != nil {
+			return openapigenerator.DefaultCodeType, errors.Join(fmt.Errorf("unhandled array type. schema: %s, format: %s", schema.Type, schema.Format), err)
+		}
+		return openapigenerator.NewListCodeType(arrayType, schema), nil
+	case slices.Contains(schema.Type, "object") || schema.Type == nil:
+		if schema.PatternProperties != nil {
+			pp := schema.PatternProperties.First()
+			ppSchema := pp.Value().Schema()
+
+			additionalPropertyType, err := g.ToCodeType(ppSchema, schemaType, true)
+			if err != nil {
+				return openapigenerator.DefaultCodeType, errors.Join(fmt.Errorf("unhandled pattern properties type. schema: %s, format: %s", schema.Type, schema.Format), err)
+			}
+
+			return openapigenerator.NewMapCodeType(openapigenerator.NewSimpleCodeType("str", schema), additionalPropertyType, schema), nil
+		} else if schema.AdditionalProperties != nil && schema.AdditionalProperties.IsA() {
+			additionalPropertyType, err := g.ToCodeType(schema.AdditionalProperties.A.Schema(), schemaType, true)
+			if err != nil {
+				return openapigenerator.DefaultCodeType, errors.Join(fmt.Errorf("unhandled additional properties type. schema: %s, format: %s", schema.Type, schema.Format), err)
+			}
+
+			return openapigenerator.NewMapCodeType(openapigenerator.NewSimpleCodeType("str", schema), additionalPropertyType, schema), nil
+		} else if schema.AdditionalProperties != nil && schema.AdditionalProperties.IsB() && schema.AdditionalProperties.B {
+			return openapigenerator.NewMapCodeType(openapigenerator.NewSimpleCodeType("str", schema), openapigenerator.NewSimpleCodeType("Any", schema), schema), nil
+		} else if schema.Properties == nil && len(schema.OneOf) == 0 && len(schema.AnyOf) == 0 && len(schema.AllOf) == 0 {
+			return openapigenerator.CodeType{Name: "Any"}, nil
+		}
+
+		if schema.Title == "" {
+			return openapigenerator.DefaultCodeType, fmt.Errorf("schema does not have a title. schema: %s", schema.Type)
+		}
+		return openapigenerator.CodeType{Name: g.ToClassName(schema.Title), ImportPath: "models"}, nil
+	default:
+		return openapigenerator.DefaultCodeType, fmt.Errorf("unhandled type. schema: %s, format: %s", schema.Type, schema.Format)
+	}
+}
+
+func (g *PythonGenerator) PostProcessType(codeType openapigenerator.CodeType) openapigenerator.CodeType {
+	if codeType.IsPostProcessed {
+		return codeType
+	}
+
+	// VoidType
+	if codeType.IsVoid {
+		codeType.Declaration = "None"
+		codeType.QualifiedDeclaration = "None"
+		codeType.Type = "None"
+		codeType.QualifiedType = "None"
+		codeType.IsPostProcessed = true
+		return codeType
+	}
+
+	// PostProcess TypeArgs
+	for i, typeArg := range codeType.TypeArgs {
+		codeType.TypeArgs[i] = g.PostProcessType(typeArg)
+	}
+
+	// Qualifier
+	qualifier := ""
+	if codeType.ImportPath != "" {
+		parts := strings.Split(codeType.ImportPath, ".")
+		qualifier = parts[len(parts)-1] + "."
+	}
+
+	// FullyQualifiedName
+	switch {
+	case codeType.IsArray || codeType.IsList:
+		codeType.Declaration = "list[" + codeType.TypeArgs[0].Declaration + "]"
+		codeType.QualifiedDeclaration = "list[" + codeType.TypeArgs[0].QualifiedDeclaration + "]"
+		codeType.Type = "list[" + codeType.TypeArgs[0].Type + "]"
+		codeType.QualifiedType = "list[" + codeType.TypeArgs[0].QualifiedType + "]"
+	case codeType.IsMap:
+		codeType.Declaration = "dict[" + codeType.TypeArgs[0].Declaration + ", " + codeType.TypeArgs[1].Declaration + "]"
+		codeType.QualifiedDeclaration = "dict[" + codeType.TypeArgs[0].QualifiedDeclaration + ", " + codeType.TypeArgs[1].QualifiedDeclaration + "]"
+		codeType.Type = "dict[" + codeType.TypeArgs[0].Type + ", " + codeType.TypeArgs[1].Type + "]"
+		codeType.QualifiedType = "dict[" + codeType.TypeArgs[0].QualifiedType + ", " + codeType.TypeArgs[1].QualifiedType + "]"
+	default:
+		codeType.Declaration = codeType.Name
+		codeType.QualifiedDeclaration = qualifier + codeType.Name
+		codeType.Type = codeType.Name
+		codeType.QualifiedType = qualifier + codeType.Name
+	}
+
+	codeType.IsPostProcessed = true
+	return codeType
+}
+
+func (g *PythonGenerator) IsPrimitiveType(input string) bool {
+	return slices.Contains(g.primitiveTypes, input)
+}
+
+func (g *PythonGenerator) TypeToImport(iType openapigenerator.CodeType) string {
+	typeName := iType.Name
+	if typeName == "" {
+		return ""
+	}
+
+	return g.typeToImport[typeName]
+}
+
+const ruffBinary = "ruff"
+
+func (g *PythonGenerator) PostProcessing(files map[string]templateapi.RenderedFile) error {
+	if os.Getenv("PRIMECODEGEN_SKIP_POST_PROCESSING") == "true" {
+		slog.Debug("Skipping post processing python files")
+		return nil
+	}
+
+	if openapigenerator.IsBinaryAvailable(ruffBinary) {
+		var formatFiles []string
+		for _, f := range files {
+			if strings.HasSuffix(f.File, ".py") && f.State == templateapi.FileRendered {
+				formatFiles = append(formatFiles, f.File)
+			}
+		}
+		if len(formatFiles) == 0 {
+			return nil
+		}
+
+		slog.Debug("Post processing py files using "+ruffBinary, "file_len", len(formatFiles))
+		cmd := exec.Command(ruffBinary, "format", "--quiet")
+		cmd.Args = append(cmd.Args, formatFiles...)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		err := cmd.Run()
+		if err != nil {
+			return fmt.Errorf("error running %s: %v", ruffBinary, err)
+		}
+	}
+
+	return nil
+}
+
+// ToModuleName converts a distribution name (e.g. "acme-petstore") into an importable python module name (e.g. "acme_petstore")
+func ToModuleName(name string) string {
+	name = strings.ToLower(name)
+	name = strings.TrimPrefix(name, "@")
+	name = strings.NewReplacer("-", "_", ".", "_", "/", "_", " ", "_").Replace(name)
+
+	if len(name) > 0 && name[0] >= '0' && name[0] <= '9' {
+		name = "n" + name
+	}
+	return name
+}
+
+func NewGenerator() *PythonGenerator {
+	// references: https://docs.python.org/3/reference/lexical_analysis.html#keywords
+	return &PythonGenerator{
+		reservedWords: []string{
+			// keywords
+			"false",
+			"none",
+			"true",
+			"and",
+			"as",
+			"assert",
+			"async",
+			"await",
+			"break",
+			"class",
+			"continue",
+			"def",
+			"del",
+			"elif",
+			"else",
+			"except",
+			"finally",
+			"for",
+			"from",
+			"global",
+			"if",
+			"import",
+			"in",
+			"is",
+			"lambda",
+			"nonlocal",
+			"not",
+			"or",
+			"pass",
+			"raise",
+			"return",
+			"try",
+			"while",
+			"with",
+			"yield",
+			// names used in generated type annotations
+			"any",
+			"bool",
+			"bytes",
+			"datetime",
+			"dict",
+			"float",
+			"int",
+			"list",
+			"optional",
+			"str",
+			"models",
+			// pydantic base model attributes
+			"copy",
+			"construct",
+			"json",
+			"model_config",
+			"model_fields",
+			"schema",
+			"validate",
+			// generated client internals
+			"self",
+			"request_options",
+		},
+		primitiveTypes: []string{
+			"str",
+			"int",
+			"float",
+			"bool",
+			"bytes",
+			"Any",
+			"UUID",
+			"datetime.date",
+			"datetime.datetime",
+		},
+		typeToImport: map[string]string{},
+	}
+}
@@ -0,0 +1,186 @@
+package openapi_python
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/primelib/primecodegen/pkg/generator/generatortest"
+	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
+	"github.com/primelib/primecodegen/pkg/openapi/openapigenerator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var commonPackages = openapigenerator.CommonPackages{
+	Root:       "pet_client",
+	Client:     "pet_client",
+	Models:     "pet_client.models",
+	Responses:  "pet_client.models",
+	Enums:      "pet_client.models",
+	Operations: "pet_client.services",
+	Auth:       "pet_client.auth",
+}
+
+func TestOperationBasic(t *testing.T) {
+	// arrange
+	v3doc := openapidocument.OpenV3DocumentForTest(generatortest.OperationBasic)
+
+	// act
+	templateData, err := openapigenerator.BuildTemplateData(v3doc, NewGenerator(), commonPackages)
+	assert.NoError(t, err)
+	assert.NotNil(t, templateData)
+
+	// assert
+	assert.Len(t, templateData.Operations, 2)
+	assert.Equal(t, "GetPet", templateData.Operations[0].Name)
+	assert.Equal(t, "get", templateData.Operations[0].Method)
+	assert.Equal(t, "/pets/{pet_id}", templateData.Operations[0].Path)
+	assert.Equal(t, "models.Pet", templateData.Operations[0].ReturnType.QualifiedDeclaration)
+	assert.Len(t, templateData.Operations[0].PathParameters, 1)
+	assert.Equal(t, "pet_id", templateData.Operations[0].PathParameters[0].Name)
+	assert.Equal(t, "str", templateData.Operations[0].PathParameters[0].Type.QualifiedDeclaration)
+	assert.Len(t, templateData.Operations[0].QueryParameters, 1)
+	assert.Equal(t, "fields", templateData.Operations[0].QueryParameters[0].Name)
+	assert.Equal(t, "list[str]", templateData.Operations[0].QueryParameters[0].Type.QualifiedDeclaration)
+	assert.Equal(t, "CreatePet", templateData.Operations[1].Name)
+	assert.Equal(t, "post", templateData.Operations[1].Method)
+	assert.NotNil(t, templateData.Operations[1].BodyParameter)
+	assert.Equal(t, "models.Pet", templateData.Operations[1].BodyParameter.Type.QualifiedDeclaration)
+	assert.True(t, templateData.Operations[1].RequestBodyRequired)
+}
+
+func TestModelBasic(t *testing.T) {
+	// arrange
+	v3doc := openapidocument.OpenV3DocumentForTest(generatortest.ModelBasic)
+
+	// act
+	templateData, err := openapigenerator.BuildTemplateData(v3doc, NewGenerator(), commonPackages)
+	assert.NoError(t, err)
+	assert.NotNil(t, templateData)
+
+	// assert
+	assert.Len(t, templateData.Models, 2)
+	assert.Equal(t, "Pet", templateData.Models[0].Name)
+	properties := templateData.Models[0].Properties
+	assert.Len(t, properties, 9)
+	assert.Equal(t, "name", properties[0].Name)
+	assert.Equal(t, "str", properties[0].Type.QualifiedDeclaration)
+	assert.Equal(t, "age", properties[1].Name)
+	assert.Equal(t, "int", properties[1].Type.QualifiedDeclaration)
+	assert.Equal(t, "tags", properties[2].Name)
+	assert.Equal(t, "list[str]", properties[2].Type.QualifiedDeclaration)
+	assert.Equal(t, "owner", properties[4].Name)
+	assert.Equal(t, "models.Owner", properties[4].Type.QualifiedDeclaration)
+	assert.Equal(t, "metadata", properties[5].Name)
+	assert.Equal(t, "dict[str, Any]", properties[5].Type.QualifiedDeclaration)
+	assert.Equal(t, "type", properties[6].Name)
+	assert.Equal(t, "class_", properties[7].Name)
+	assert.Equal(t, "content_type", properties[8].Name)
+	assert.Equal(t, "content-type", properties[8].FieldName)
+	assert.Equal(t, "Owner", templateData.Models[1].Name)
+	assert.Len(t, templateData.Enums, 1)
+	assert.Equal(t, "PetStatus", templateData.Enums[0].Name)
+}
+
+func TestGenerateOperationBasic(t *testing.T) {
+	// arrange
+	t.Setenv("PRIMECODEGEN_SKIP_POST_PROCESSING", "true")
+	outputDir := t.TempDir()
+
+	// act
+	err := NewGenerator().Generate(openapigenerator.GenerateOpts{
+		Doc:        openapidocument.OpenV3DocumentForTest(generatortest.OperationBasic),
+		OutputDir:  outputDir,
+		TemplateId: "httpclient",
+		ArtifactId: "pet-client",
+	})
+	assert.NoError(t, err)
+
+	// assert
+	service := generatortest.ReadGeneratedFile(t, outputDir, "pet_client/services/pets.py")
+	assert.Contains(t, service, "def _get_pet_request(*, pet_id: str, fields: Optional[list[str]] = None) -> ApiRequest:")
+	assert.Contains(t, service, `path=f"/pets/{encode_path(pet_id)}",`)
+	assert.Contains(t, service, `append_query(query, "fields", fields, True, ",")`)
+	assert.Contains(t, service, "class PetsApi:")
+	assert.Contains(t, service, "    def get_pet(self, *, pet_id: str, fields: Optional[list[str]] = None, request_options: Optional[RequestOptions] = None) -> ApiResponse[models.Pet]:")
+	assert.Contains(t, service, "class AsyncPetsApi:")
+	assert.Contains(t, service, "    async def create_pet(self, *, payload: models.Pet, request_options: Optional[RequestOptions] = None) -> ApiResponse[models.Pet]:")
+	assert.Contains(t, service, "        body=payload,")
+	assert.FileExists(t, filepath.Join(outputDir, "pet_client/py.typed"))
+	assert.Contains(t, generatortest.ReadGeneratedFile(t, outputDir, "pyproject.toml"), `name = "pet-client"`)
+}
+
+func TestGenerateModelNaming(t *testing.T) {
+	// arrange
+	t.Setenv("PRIMECODEGEN_SKIP_POST_PROCESSING", "true")
+	outputDir := t.TempDir()
+
+	// act
+	err := NewGenerator().Generate(openapigenerator.GenerateOpts{
+		Doc:        openapidocument.OpenV3DocumentForTest(generatortest.ModelNaming),
+		OutputDir:  outputDir,
+		TemplateId: "httpclient",
+		ArtifactId: "pet-client",
+	})
+	assert.NoError(t, err)
+
+	// assert: keywords and pydantic internals get a trailing underscore, the wire name is kept as alias
+	models := generatortest.ReadGeneratedFile(t, outputDir, "pet_client/models.py")
+	assert.Contains(t, models, "class ClassModel(ApiModel):")
+	assert.Contains(t, models, `    import_: Optional[str] = Field(default=None, alias="import")`)
+	assert.Contains(t, models, `    self_: Optional[str] = Field(default=None, alias="self")`)
+	assert.Contains(t, models, `    async_: Optional[bool] = Field(default=None, alias="async")`)
+	assert.Contains(t, models, `    type: Optional[str] = Field(default=None, alias="type")`)
+	assert.Contains(t, models, `    n1st_place: Optional[bool] = Field(default=None, alias="1st-place")`)
+	assert.Contains(t, models, "class TaskStatus(str, enum.Enum):")
+	assert.Contains(t, models, `    N2FA_REQUIRED = "2fa-required"`)
+	assert.Contains(t, models, `    CLASS_ = "class"`)
+	assert.Contains(t, models, `    IN_PROGRESS = "in-progress"`)
+}
+
+func TestGenerateModelNullable(t *testing.T) {
+	// arrange
+	t.Setenv("PRIMECODEGEN_SKIP_POST_PROCESSING", "true")
+	outputDir := t.TempDir()
+
+	// act
+	err := NewGenerator().Generate(openapigenerator.GenerateOpts{
+		Doc:        openapidocument.OpenV3DocumentForTest(generatortest.ModelNullable),
+		OutputDir:  outputDir,
+		TemplateId: "httpclient",
+		ArtifactId: "pet-client",
+	})
+	assert.NoError(t, err)
+
+	// assert: all properties are optional with a None default, which also covers nullable properties
+	models := generatortest.ReadGeneratedFile(t, outputDir, "pet_client/models.py")
+	assert.Contains(t, models, `    id: Optional[str] = Field(default=None, alias="id")`)
+	assert.Contains(t, models, `    deleted_at: Optional[datetime.datetime] = Field(default=None, alias="deletedAt")`)
+	assert.Contains(t, models, `    priority: Optional[int] = Field(default=None, alias="priority")`)
+}
+
+func TestOperationMultipart(t *testing.T) {
+	// arrange
+	v3doc := openapidocument.OpenV3DocumentForTest(generatortest.OperationMultipart)
+
+	// act
+	templateData, err := openapigenerator.BuildTemplateData(v3doc, NewGenerator(), commonPackages)
+	assert.NoError(t, err)
+
+	// assert
+	require.Len(t, templateData.Operations, 1)
+	assert.Equal(t, "pet_id", templateData.Operations[0].PathParameters[0].Name)
+	form := templateData.Operations[0].Form
+	require.NotNil(t, form)
+	assert.True(t, form.Multipart)
+	require.Len(t, form.Parts, 3)
+	assert.Equal(t, "file", form.Parts[0].Name)
+	assert.Equal(t, "bytes", form.Parts[0].Type.QualifiedDeclaration)
+	assert.True(t, form.Parts[0].IsFile)
+	assert.Equal(t, "image/png", form.Parts[0].ContentType)
+	assert.Equal(t, "caption", form.Parts[1].Name)
+	assert.Equal(t, "str", form.Parts[1].Type.QualifiedDeclaration)
+	assert.Equal(t, "tags", form.Parts[2].Name)
+	assert.True(t, form.Parts[2].IsArray)
+	assert.Equal(t, "str", form.Parts[2].ItemType.QualifiedDeclaration)
+}
@@ -0,0 +1,509 @@
+package openapi_rust
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+	"unicode"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/primelib/primecodegen/pkg/openapi/openapigenerator"
+	"github.com/primelib/primecodegen/pkg/template/templateapi"
+	"github.com/primelib/primecodegen/pkg/util"
+)
+
+type RustGenerator struct {
+	reservedWords  []string
+	reservedTypes  []string
+	primitiveTypes []string
+	typeToImport   map[string]string
+}
+
+func (g *RustGenerator) Id() string {
+	return "rust"
+}
+
+func (g *RustGenerator) Description() string {
+	return "Generates Rust client code"
+}
+
+func (g *RustGenerator) Generate(opts openapigenerator.GenerateOpts) error {
+	// check opts
+	if opts.Doc == nil {
+		return fmt.Errorf("document is required")
+	}
+
+	// required options
+	if opts.ArtifactId == "" {
+		return fmt.Errorf("artifact id is required, please set the --md-artifact-id flag")
+	}
+
+	// set packages, rust modules are relative to the crate root
+	opts.PackageConfig = openapigenerator.CommonPackages{
+		Root:       ToCrateName(opts.ArtifactId),
+		Client:     "client",
+		Models:     "models",
+		Responses:  "models",
+		Enums:      "models",
+		Operations: "apis",
+		Auth:       "auth",
+	}
+
+	// build template data
+	templateData, err := g.TemplateData(openapigenerator.TemplateDataOpts{
+		Doc:           opts.Doc,
+		PackageConfig: opts.PackageConfig,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build template data in %s: %w", g.Id(), err)
+	}
+
+	// generate files
+	files, err := openapigenerator.GenerateFiles(fmt.Sprintf("openapi-%s-%s", g.Id(), opts.TemplateId), opts.OutputDir, templateData, templateapi.RenderOpts{
+		DryRun:               opts.DryRun,
+		Types:                nil,
+		IgnoreFiles:          nil,
+		IgnoreFileCategories: nil,
+		Properties:           map[string]string{},
+		TemplateFunctions: texttemplate.FuncMap{
+			"toClassName":     g.ToClassName,
+			"toFunctionName":  g.ToFunctionName,
+			"toPropertyName":  g.ToPropertyName,
+			"toParameterName": g.ToParameterName,
+			"toModuleName":    g.ToModuleName,
+			"isPrimitiveType": g.IsPrimitiveType,
+		},
+	}, opts)
+	if err != nil {
+		return fmt.Errorf("failed to generate files: %w", err)
+	}
+	for _, f := range files {
+		slog.Debug("Generated file", "file", f.File, "template-file", f.TemplateFile, "state", string(f.State))
+	}
+	slog.Info(fmt.Sprintf("Generated %d files", len(files)))
+
+	// delete old files (oldfiles - files)
+	oldFiles := openapigenerator.FilesListedInMetadata(opts.OutputDir)
+	for _, f := range oldFiles {
+		if _, ok := files[f]; !ok {
+			slog.Debug("Removing obsolete file", "file", f)
+			if !opts.DryRun {
+				err = openapigenerator.RemoveGeneratedFile(opts.OutputDir, f)
+				if err != nil {
+					return fmt.Errorf("failed to remove generated file: %w", err)
+				}
+			}
+		}
+	}
+
+	// post-processing (formatting)
+	err = g.PostProcessing(files)
+	if err != nil {
+		return fmt.Errorf("failed to run post-processing: %w", err)
+	}
+
+	// write metadata
+	if !opts.DryRun {
+		err = openapigenerator.WriteMetadata(opts.OutputDir, files)
+		if err != nil {
+			return errors.Join(openapigenerator.ErrFailedToWriteMetadata, err)
+		}
+	}
+
+	return nil
+}
+
+func (g *RustGenerator) TemplateData(opts openapigenerator.TemplateDataOpts) (openapigenerator.DocumentModel, error) {
+	return openapigenerator.BuildTemplateData(opts.Doc, g, opts.PackageConfig)
+}
+
+func (g *RustGenerator) ToClassName(name string) string {
+	name = util.ToPascalCase(g.sanitizeName(name))
+
+	if slices.Contains(g.reservedTypes, strings.ToLower(name)) {
+		return name + "Model"
+	}
+	return name
+}
+
+func (g *RustGenerator) ToFunctionName(name string) string {
+	return g.escapeIdentifier(toSnakeCase(g.sanitizeName(name)))
+}
+
+func (g *RustGenerator) ToPropertyName(name string) string {
+	name = strings.TrimLeft(toSnakeCase(g.sanitizeName(name)), "_")
+	if name == "" {
+		name = "field"
+	}
+
+	return g.escapeIdentifier(name)
+}
+
+func (g *RustGenerator) ToParameterName(name string) string {
+	name = strings.TrimLeft(toSnakeCase(g.sanitizeName(name)), "_")
+	if name == "" {
+		name = "param"
+	}
+
+	return g.escapeIdentifier(name)
+}
+
+func (g *RustGenerator) ToConstantName(name string) string {
+	name = util.ToPascalCase(g.sanitizeName(name))
+	if name == "" {
+		return "Empty"
+	}
+	if name == "Self" {
+		return "SelfValue"
+	}
+	return name
+}
+
+// ToModuleName converts a type name into the module name, the module file is named using the snakeCase template function
+func (g *RustGenerator) ToModuleName(name string) string {
+	name = util.ToSnakeCase(name)
+
+	if slices.Contains(g.reservedWords, name) {
+		return "r#" + name
+	}
+	return name
+}
+
+// escapeIdentifier uses raw identifiers (e.g. r#type) for keywords, the few keywords that can not be raw get a suffix instead
+func (g *RustGenerator) escapeIdentifier(name string) string {
+	switch {
+	case name == "self" || name == "super" || name == "crate":
+		return name + "_"
+	case slices.Contains(g.reservedWords, name):
+		return "r#" + name
+	}
+	return name
+}
+
+func (g *RustGenerator) sanitizeName(name string) string {
+	// special case: starts with a digit
+	if len(name) > 0 && name[0] >= '0' && name[0] <= '9' {
+		name = "n" + name
+	}
+
+	// replace everything that is not allowed in an identifier
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || r == '-' || r == ' ' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// toSnakeCase converts a name to snake_case, unlike util.ToSnakeCase digits are not split from the preceding word (e.g. "GetPetsV1" -> "get_pets_v1")
+func toSnakeCase(name string) string {
+	runes := []rune(strings.TrimSpace(name))
+	var sb strings.Builder
+	for i, r := range runes {
+		switch {
+		case r == '-' || r == ' ' || r == '_':
+			if sb.Len() > 0 && !strings.HasSuffix(sb.String(), "_") {
+				sb.WriteRune('_')
+			}
+			continue
+		case unicode.IsUpper(r) && i > 0:
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if (unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower)) && !strings.HasSuffix(sb.String(), "_") {
+				sb.WriteRune('_')
+			}
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+
+	return strings.TrimSuffix(sb.String(), "_")
+}
+
+func (g *RustGenerator) ToCodeType(schema *base.Schema, schemaType openapigenerator.CodeTypeSchemaType, required bool) (openapigenerator.CodeType, error) {
+	if schema == nil {
+		return openapigenerator.DefaultCodeType, fmt.Errorf("schema is nil")
+	}
+
+	// multiple types (e.g., ["string", "integer"])
+	if util.CountExcluding(schema.Type, "null") > 1 {
+		return openapigenerator.CodeType{Name: "serde_json::Value"}, nil
+	}
+
+	switch {
+	case len(schema.Type) == 0 && len(schema.OneOf) > 0:
+		codeTypes := make([]openapigenerator.CodeType, 0, len(schema.OneOf))
+		for _, oneOfSchema := range schema.OneOf {
+			codeType, err := g.ToCodeType(oneOfSchema.Schema(), schemaType, true)
+			if err != nil {
+				return openapigenerator.DefaultCodeType, errors.Join(fmt.Errorf("unhandled oneOf type. schema: %s, format: %s", schema.Type, schema.Format), err)
+			}
+			codeTypes = append(codeTypes, codeType)
+		}
+
+		if openapigenerator.HaveSameCodeTypeName(codeTypes) {
+			return codeTypes[0], nil
+		}
+		return openapigenerator.CodeType{Name: "serde_json::Value"}, nil
+	case slices.Contains(schema.Type, "string"):
+		switch schema.Format {
+		case "date":
+			return openapigenerator.NewSimpleCodeType("chrono::NaiveDate", schema), nil
+		case "date-time":
+			return openapigenerator.NewSimpleCodeType("chrono::DateTime<chrono::Utc>", schema), nil
+		case "uuid":
+			return openapigenerator.NewSimpleCodeType("uuid::Uuid", schema), nil
+		case "binary":
+			return openapigenerator.CodeType{Name: "Vec<u8>"}, nil
+		default:
+			return openapigenerator.NewSimpleCodeType("String", schema), nil
+		}
+	case slices.Contains(schema.Type, "boolean"):
+		return openapigenerator.NewSimpleCodeType("bool", schema), nil
+	case slices.Contains(schema.Type, "integer"):
+		if schema.Format == "int32" {
+			return openapigenerator.NewSimpleCodeType("i32", schema), nil
+		}
+		return openapigenerator.NewSimpleCodeType("i64", schema), nil
+	case slices.Contains(schema.Type, "number"):
+		if schema.Format == "float" {
+			return openapigenerator.NewSimpleCodeType("f32", schema), nil
+		}
+		return openapigenerator.NewSimpleCodeType("f64", schema), nil
+	case slices.Contains(schema.Type, "array"):
+		if schema.Items == nil || schema.Items.A == nil {
+			return openapigenerator.DefaultCodeType, fmt.Errorf("array schema missing items definition")
+		}
+		arrayType, err := g.ToCodeType(schema.Items.A.Schema(), schemaType, true)
+		if err != nil {
+			return openapigenerator.DefaultCodeType, errors.Join(fmt.Errorf("unhandled array type. schema: %s, format: %s", schema.Type, schema.Format), err)
+		}
+		return openapigenerator.NewListCodeType(arrayType, schema), nil
+	case slices.Contains(schema.Type, "object") || schema.Type == nil:
+		if schema.PatternProperties != nil {
+			pp := schema.PatternProperties.First()
+			ppSchema := pp.Value().Schema()
+
+			additionalPropertyType, err := g.ToCodeType(ppSchema, schemaType, true)
+			if err != nil {
+				return openapigenerator.DefaultCodeType, errors.Join(fmt.Errorf("unhandled pattern properties type. schema: %s, format: %s", schema.Type, schema.Format), err)
+			}
+
+			return openapigenerator.NewMapCodeType(openapigenerator.NewSimpleCodeType("String", schema), additionalPropertyType, schema), nil
+		} else if schema.AdditionalProperties != nil && schema.AdditionalProperties.IsA() {
+			additionalPropertyType, err := g.ToCodeType(schema.AdditionalProperties.A.Schema(), schemaType, true)
+			if err != nil {
+				return openapigenerator.DefaultCodeType, errors.Join(fmt.Errorf("unhandled additional properties type. schema: %s, format: %s", schema.Type, schema.Format), err)
+			}
+
+			return openapigenerator.NewMapCodeType(openapigenerator.NewSimpleCodeType("String", schema), additionalPropertyType, schema), nil
+		} else if schema.AdditionalProperties != nil && schema.AdditionalProperties.IsB() && schema.AdditionalProperties.B {
+			return openapigenerator.NewMapCodeType(openapigenerator.NewSimpleCodeType("String", schema), openapigenerator.NewSimpleCodeType("serde_json::Value", schema), schema), nil
+		} else if schema.Properties == nil && len(schema.OneOf) == 0 && len(schema.AnyOf) == 0 && len(schema.AllOf) == 0 {
+			return openapigenerator.CodeType{Name: "serde_json::Value"}, nil
+		}
+
+		if schema.Title == "" {
+			return openapigenerator.DefaultCodeType, fmt.Errorf("schema does not have a title. schema: %s", schema.Type)
+		}
+		return openapigenerator.CodeType{Name: g.ToClassName(schema.Title), ImportPath: "crate::models"}, nil
+	default:
+		return openapigenerator.DefaultCodeType, fmt.Errorf("unhandled type. schema: %s, format: %s", schema.Type, schema.Format)
+	}
+}
+
+func (g *RustGenerator) PostProcessType(codeType openapigenerator.CodeType) openapigenerator.CodeType {
+	if codeType.IsPostProcessed {
+		return codeType
+	}
+
+	// VoidType
+	if codeType.IsVoid {
+		codeType.Declaration = "()"
+		codeType.QualifiedDeclaration = "()"
+		codeType.Type = "()"
+		codeType.QualifiedType = "()"
+		codeType.IsPostProcessed = true
+		return codeType
+	}
+
+	// PostProcess TypeArgs
+	for i, typeArg := range codeType.TypeArgs {
+		codeType.TypeArgs[i] = g.PostProcessType(typeArg)
+	}
+
+	// Qualifier
+	qualifier := ""
+	if codeType.ImportPath != "" {
+		qualifier = codeType.ImportPath + "::"
+	}
+
+	// FullyQualifiedName
+	switch {
+	case codeType.IsArray || codeType.IsList:
+		codeType.Declaration = "Vec<" + codeType.TypeArgs[0].Declaration + ">"
+		codeType.QualifiedDeclaration = "Vec<" + codeType.TypeArgs[0].QualifiedDeclaration + ">"
+		codeType.Type = "Vec<" + codeType.TypeArgs[0].Type + ">"
+		codeType.QualifiedType = "Vec<" + codeType.TypeArgs[0].QualifiedType + ">"
+	case codeType.IsMap:
+		codeType.Declaration = "std::collections::HashMap<" + codeType.TypeArgs[0].Declaration + ", " + codeType.TypeArgs[1].Declaration + ">"
+		codeType.QualifiedDeclaration = "std::collections::HashMap<" + codeType.TypeArgs[0].QualifiedDeclaration + ", " + codeType.TypeArgs[1].QualifiedDeclaration + ">"
+		codeType.Type = "std::collections::HashMap<" + codeType.TypeArgs[0].Type + ", " + codeType.TypeArgs[1].Type + ">"
+		codeType.QualifiedType = "std::collections::HashMap<" + codeType.TypeArgs[0].QualifiedType + ", " + codeType.TypeArgs[1].QualifiedType + ">"
+	default:
+		codeType.Declaration = codeType.Name
+		codeType.QualifiedDeclaration = qualifier + codeType.Name
+		codeType.Type = codeType.Name
+		codeType.QualifiedType = qualifier + codeType.Name
+	}
+
+	codeType.IsPostProcessed = true
+	return codeType
+}
+
+func (g *RustGenerator) IsPrimitiveType(input string) bool {
+	return slices.Contains(g.primitiveTypes, input)
+}
+
+func (g *RustGenerator) TypeToImport(iType openapigenerator.CodeType) string {
+	typeName := iType.Name
+	if typeName == "" {
+		return ""
+	}
+
+	return g.typeToImport[typeName]
+}
+
+const rustfmtBinary = "rustfmt"
+
+func (g *RustGenerator) PostProcessing(files map[string]templateapi.RenderedFile) error {
+	if os.Getenv("PRIMECODEGEN_SKIP_POST_PROCESSING") == "true" {
+		slog.Debug("Skipping post processing rust files")
+		return nil
+	}
+
+	if openapigenerator.IsBinaryAvailable(rustfmtBinary) {
+		var formatFiles []string
+		for _, f := range files {
+			if strings.HasSuffix(f.File, ".rs") && f.State == templateapi.FileRendered {
+				formatFiles = append(formatFiles, f.File)
+			}
+		}
+		if len(formatFiles) == 0 {
+			return nil
+		}
+
+		slog.Debug("Post processing rs files using "+rustfmtBinary, "file_len", len(formatFiles))
+		cmd := exec.Command(rustfmtBinary, "--edition", "2021")
+		cmd.Args = append(cmd.Args, formatFiles...)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		err := cmd.Run()
+		if err != nil {
+			return fmt.Errorf("error running %s: %v", rustfmtBinary, err)
+		}
+	}
+
+	return nil
+}
+
+// ToCrateName converts an artifact id (e.g. "Acme.Petstore") into a valid crate name (e.g. "acme-petstore")
+func ToCrateName(name string) string {
+	name = strings.ToLower(name)
+	name = strings.TrimPrefix(name, "@")
+	name = strings.NewReplacer("_", "-", ".", "-", "/", "-", " ", "-").Replace(name)
+
+	return name
+}
+
+func NewGenerator() *RustGenerator {
+	// references: https://doc.rust-lang.org/reference/keywords.html
+	return &RustGenerator{
+		reservedWords: []string{
+			// strict keywords
+			"as",
+			"async",
+			"await",
+			"break",
+			"const",
+			"continue",
+			"crate",
+			"dyn",
+			"else",
+			"enum",
+			"extern",
+			"false",
+			"fn",
+			"for",
+			"if",
+			"impl",
+			"in",
+			"let",
+			"loop",
+			"match",
+			"mod",
+			"move",
+			"mut",
+			"pub",
+			"ref",
+			"return",
+			"self",
+			"static",
+			"struct",
+			"super",
+			"trait",
+			"true",
+			"type",
+			"unsafe",
+			"use",
+			"where",
+			"while",
+			// reserved keywords
+			"abstract",
+			"become",
+			"box",
+			"do",
+			"final",
+			"gen",
+			"macro",
+			"override",
+			"priv",
+			"try",
+			"typeof",
+			"unsized",
+			"virtual",
+			"yield",
+		},
+		reservedTypes: []string{
+			// prelude and std types that are used by the generated code
+			"box",
+			"crate",
+			"hashmap",
+			"option",
+			"result",
+			"self",
+			"string",
+			"super",
+			"value",
+			"vec",
+		},
+		primitiveTypes: []string{
+			"String",
+			"bool",
+			"i32",
+			"i64",
+			"f32",
+			"f64",
+			"Vec<u8>",
+			"serde_json::Value",
+			"uuid::Uuid",
+			"chrono::NaiveDate",
+			"chrono::DateTime<chrono::Utc>",
+		},
+		typeToImport: map[string]string{},
+	}
+}
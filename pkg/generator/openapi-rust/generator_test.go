@@ -0,0 +1,183 @@
+package openapi_rust
+
+import (
+	"testing"
+
+	"github.com/primelib/primecodegen/pkg/generator/generatortest"
+	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
+	"github.com/primelib/primecodegen/pkg/openapi/openapigenerator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var commonPackages = openapigenerator.CommonPackages{
+	Root:       "pet_client",
+	Client:     "client",
+	Models:     "models",
+	Responses:  "models",
+	Enums:      "models",
+	Operations: "apis",
+	Auth:       "auth",
+}
+
+func TestOperationBasic(t *testing.T) {
+	// arrange
+	v3doc := openapidocument.OpenV3DocumentForTest(generatortest.OperationBasic)
+
+	// act
+	templateData, err := openapigenerator.BuildTemplateData(v3doc, NewGenerator(), commonPackages)
+	assert.NoError(t, err)
+	assert.NotNil(t, templateData)
+
+	// assert
+	assert.Len(t, templateData.Operations, 2)
+	assert.Equal(t, "GetPet", templateData.Operations[0].Name)
+	assert.Equal(t, "get", templateData.Operations[0].Method)
+	assert.Equal(t, "/pets/{pet_id}", templateData.Operations[0].Path)
+	assert.Equal(t, "crate::models::Pet", templateData.Operations[0].ReturnType.QualifiedDeclaration)
+	assert.Len(t, templateData.Operations[0].PathParameters, 1)
+	assert.Equal(t, "pet_id", templateData.Operations[0].PathParameters[0].Name)
+	assert.Equal(t, "String", templateData.Operations[0].PathParameters[0].Type.QualifiedDeclaration)
+	assert.Len(t, templateData.Operations[0].QueryParameters, 1)
+	assert.Equal(t, "fields", templateData.Operations[0].QueryParameters[0].Name)
+	assert.Equal(t, "Vec<String>", templateData.Operations[0].QueryParameters[0].Type.QualifiedDeclaration)
+	assert.Equal(t, "CreatePet", templateData.Operations[1].Name)
+	assert.Equal(t, "post", templateData.Operations[1].Method)
+	assert.NotNil(t, templateData.Operations[1].BodyParameter)
+	assert.Equal(t, "crate::models::Pet", templateData.Operations[1].BodyParameter.Type.QualifiedDeclaration)
+	assert.True(t, templateData.Operations[1].RequestBodyRequired)
+}
+
+func TestModelBasic(t *testing.T) {
+	// arrange
+	v3doc := openapidocument.OpenV3DocumentForTest(generatortest.ModelBasic)
+
+	// act
+	templateData, err := openapigenerator.BuildTemplateData(v3doc, NewGenerator(), commonPackages)
+	assert.NoError(t, err)
+	assert.NotNil(t, templateData)
+
+	// assert
+	assert.Len(t, templateData.Models, 2)
+	assert.Equal(t, "Pet", templateData.Models[0].Name)
+	properties := templateData.Models[0].Properties
+	assert.Len(t, properties, 9)
+	assert.Equal(t, "name", properties[0].Name)
+	assert.Equal(t, "String", properties[0].Type.QualifiedDeclaration)
+	assert.Equal(t, "age", properties[1].Name)
+	assert.Equal(t, "i64", properties[1].Type.QualifiedDeclaration)
+	assert.Equal(t, "tags", properties[2].Name)
+	assert.Equal(t, "Vec<String>", properties[2].Type.QualifiedDeclaration)
+	assert.Equal(t, "owner", properties[4].Name)
+	assert.Equal(t, "crate::models::Owner", properties[4].Type.QualifiedDeclaration)
+	assert.Equal(t, "metadata", properties[5].Name)
+	assert.Equal(t, "std::collections::HashMap<String, serde_json::Value>", properties[5].Type.QualifiedDeclaration)
+	assert.Equal(t, "r#type", properties[6].Name)
+	assert.Equal(t, "class", properties[7].Name)
+	assert.Equal(t, "content_type", properties[8].Name)
+	assert.Equal(t, "content-type", properties[8].FieldName)
+	assert.Equal(t, "Owner", templateData.Models[1].Name)
+	assert.Len(t, templateData.Enums, 1)
+	assert.Equal(t, "PetStatus", templateData.Enums[0].Name)
+}
+
+func TestGenerateOperationBasic(t *testing.T) {
+	// arrange
+	t.Setenv("PRIMECODEGEN_SKIP_POST_PROCESSING", "true")
+	outputDir := t.TempDir()
+
+	// act
+	err := NewGenerator().Generate(openapigenerator.GenerateOpts{
+		Doc:        openapidocument.OpenV3DocumentForTest(generatortest.OperationBasic),
+		OutputDir:  outputDir,
+		TemplateId: "httpclient",
+		ArtifactId: "pet-client",
+	})
+	assert.NoError(t, err)
+
+	// assert
+	api := generatortest.ReadGeneratedFile(t, outputDir, "src/apis/pets.rs")
+	assert.Contains(t, api, "pub struct GetPetParams {\n    pub pet_id: String,\n    pub fields: Option<Vec<String>>,\n}")
+	assert.Contains(t, api, "pub struct PetsApi {")
+	assert.Contains(t, api, "pub async fn get_pet(&self, params: GetPetParams, options: Option<RequestOptions>) -> Result<ApiResponse<crate::models::Pet>> {")
+	assert.Contains(t, api, `ApiRequest::new(reqwest::Method::GET, format!("/pets/{}", encode_path(&params.pet_id)));`)
+	assert.Contains(t, api, `request.add_query("fields", &params.fields, true, ",");`)
+	assert.Contains(t, api, "pub async fn create_pet(&self, params: CreatePetParams, options: Option<RequestOptions>) -> Result<ApiResponse<crate::models::Pet>> {")
+	assert.Contains(t, api, "request.json(&params.payload)?;")
+	assert.Contains(t, generatortest.ReadGeneratedFile(t, outputDir, "src/apis/mod.rs"), "mod pets;\n\npub use pets::*;")
+	assert.Contains(t, generatortest.ReadGeneratedFile(t, outputDir, "src/client.rs"), "pub fn pets(&self) -> PetsApi {")
+	assert.Contains(t, generatortest.ReadGeneratedFile(t, outputDir, "Cargo.toml"), `name = "pet-client"`)
+}
+
+func TestGenerateModelNaming(t *testing.T) {
+	// arrange
+	t.Setenv("PRIMECODEGEN_SKIP_POST_PROCESSING", "true")
+	outputDir := t.TempDir()
+
+	// act
+	err := NewGenerator().Generate(openapigenerator.GenerateOpts{
+		Doc:        openapidocument.OpenV3DocumentForTest(generatortest.ModelNaming),
+		OutputDir:  outputDir,
+		TemplateId: "httpclient",
+		ArtifactId: "pet-client",
+	})
+	assert.NoError(t, err)
+
+	// assert: keywords use raw identifiers, self can not be a raw identifier and gets a suffix
+	model := generatortest.ReadGeneratedFile(t, outputDir, "src/models/class.rs")
+	assert.Contains(t, model, "pub struct Class {")
+	assert.Contains(t, model, "    #[serde(rename = \"type\", default, skip_serializing_if = \"Option::is_none\")]\n    pub r#type: Option<String>,")
+	assert.Contains(t, model, "    #[serde(rename = \"async\", default, skip_serializing_if = \"Option::is_none\")]\n    pub r#async: Option<bool>,")
+	assert.Contains(t, model, "    #[serde(rename = \"self\", default, skip_serializing_if = \"Option::is_none\")]\n    pub self_: Option<String>,")
+	assert.Contains(t, model, "    pub n1st_place: Option<bool>,")
+	enum := generatortest.ReadGeneratedFile(t, outputDir, "src/models/task_status.rs")
+	assert.Contains(t, enum, "    #[serde(rename = \"2fa-required\")]\n    N2FaRequired,")
+	assert.Contains(t, enum, "    #[serde(rename = \"in-progress\")]\n    InProgress,")
+	assert.Contains(t, enum, `            TaskStatus::Class => "class",`)
+}
+
+func TestGenerateModelNullable(t *testing.T) {
+	// arrange
+	t.Setenv("PRIMECODEGEN_SKIP_POST_PROCESSING", "true")
+	outputDir := t.TempDir()
+
+	// act
+	err := NewGenerator().Generate(openapigenerator.GenerateOpts{
+		Doc:        openapidocument.OpenV3DocumentForTest(generatortest.ModelNullable),
+		OutputDir:  outputDir,
+		TemplateId: "httpclient",
+		ArtifactId: "pet-client",
+	})
+	assert.NoError(t, err)
+
+	// assert: all properties are wrapped in Option and skipped when None
+	model := generatortest.ReadGeneratedFile(t, outputDir, "src/models/task.rs")
+	assert.Contains(t, model, "    pub id: Option<String>,")
+	assert.Contains(t, model, "    #[serde(rename = \"deletedAt\", default, skip_serializing_if = \"Option::is_none\")]\n    pub deleted_at: Option<chrono::DateTime<chrono::Utc>>,")
+	assert.Contains(t, model, "    pub priority: Option<i32>,")
+}
+
+func TestOperationMultipart(t *testing.T) {
+	// arrange
+	v3doc := openapidocument.OpenV3DocumentForTest(generatortest.OperationMultipart)
+
+	// act
+	templateData, err := openapigenerator.BuildTemplateData(v3doc, NewGenerator(), commonPackages)
+	assert.NoError(t, err)
+
+	// assert
+	require.Len(t, templateData.Operations, 1)
+	form := templateData.Operations[0].Form
+	require.NotNil(t, form)
+	assert.True(t, form.Multipart)
+	require.Len(t, form.Parts, 3)
+	assert.Equal(t, "file", form.Parts[0].Name)
+	assert.Equal(t, "Vec<u8>", form.Parts[0].Type.QualifiedDeclaration)
+	assert.True(t, form.Parts[0].IsFile)
+	assert.Equal(t, "image/png", form.Parts[0].ContentType)
+	assert.Equal(t, "caption", form.Parts[1].Name)
+	assert.Equal(t, "String", form.Parts[1].Type.QualifiedDeclaration)
+	assert.Equal(t, "tags", form.Parts[2].Name)
+	assert.True(t, form.Parts[2].IsArray)
+	assert.Equal(t, "String", form.Parts[2].ItemType.QualifiedDeclaration)
+}
@@ -0,0 +1,440 @@
+package openapi_typescript
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/primelib/primecodegen/pkg/openapi/openapigenerator"
+	"github.com/primelib/primecodegen/pkg/template/templateapi"
+	"github.com/primelib/primecodegen/pkg/util"
+)
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+type TypeScriptGenerator struct {
+	reservedWords  []string
+	primitiveTypes []string
+	typeToImport   map[string]string
+}
+
+func (g *TypeScriptGenerator) Id() string {
+	return "typescript"
+}
+
+func (g *TypeScriptGenerator) Description() string {
+	return "Generates TypeScript client code"
+}
+
+func (g *TypeScriptGenerator) Generate(opts openapigenerator.GenerateOpts) error {
+	// check opts
+	if opts.Doc == nil {
+		return fmt.Errorf("document is required")
+	}
+
+	// required options
+	if opts.ArtifactId == "" {
+		return fmt.Errorf("artifact id is required, please set the --md-artifact-id flag")
+	}
+
+	// set packages
+	opts.PackageConfig = openapigenerator.CommonPackages{
+		Root:       "",
+		Client:     "client",
+		Models:     "models",
+		Responses:  "models",
+		Enums:      "models",
+		Operations: "services",
+		Auth:       "auth",
+	}
+
+	// build template data
+	templateData, err := g.TemplateData(openapigenerator.TemplateDataOpts{
+		Doc:           opts.Doc,
+		PackageConfig: opts.PackageConfig,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build template data in %s: %w", g.Id(), err)
+	}
+
+	// generate files
+	files, err := openapigenerator.GenerateFiles(fmt.Sprintf("openapi-%s-%s", g.Id(), opts.TemplateId), opts.OutputDir, templateData, templateapi.RenderOpts{
+		DryRun:               opts.DryRun,
+		Types:                nil,
+		IgnoreFiles:          nil,
+		IgnoreFileCategories: nil,
+		Properties:           map[string]string{},
+		TemplateFunctions: texttemplate.FuncMap{
+			"toClassName":     g.ToClassName,
+			"toFunctionName":  g.ToFunctionName,
+			"toPropertyName":  g.ToPropertyName,
+			"toParameterName": g.ToParameterName,
+			"isPrimitiveType": g.IsPrimitiveType,
+		},
+	}, opts)
+	if err != nil {
+		return fmt.Errorf("failed to generate files: %w", err)
+	}
+	for _, f := range files {
+		slog.Debug("Generated file", "file", f.File, "template-file", f.TemplateFile, "state", string(f.State))
+	}
+	slog.Info(fmt.Sprintf("Generated %d files", len(files)))
+
+	// delete old files (oldfiles - files)
+	oldFiles := openapigenerator.FilesListedInMetadata(opts.OutputDir)
+	for _, f := range oldFiles {
+		if _, ok := files[f]; !ok {
+			slog.Debug("Removing obsolete file", "file", f)
+			if !opts.DryRun {
+				err = openapigenerator.RemoveGeneratedFile(opts.OutputDir, f)
+				if err != nil {
+					return fmt.Errorf("failed to remove generated file: %w", err)
+				}
+			}
+		}
+	}
+
+	// post-processing (formatting)
+	err = g.PostProcessing(files)
+	if err != nil {
+		return fmt.Errorf("failed to run post-processing: %w", err)
+	}
+
+	// write metadata
+	if !opts.DryRun {
+		err = openapigenerator.WriteMetadata(opts.OutputDir, files)
+		if err != nil {
+			return errors.Join(openapigenerator.ErrFailedToWriteMetadata, err)
+		}
+	}
+
+	return nil
+}
+
+func (g *TypeScriptGenerator) TemplateData(opts openapigenerator.TemplateDataOpts) (openapigenerator.DocumentModel, error) {
+	return openapigenerator.BuildTemplateData(opts.Doc, g, opts.PackageConfig)
+}
+
+func (g *TypeScriptGenerator) ToClassName(name string) string {
+	name = util.ToPascalCase(g.sanitizeName(name))
+
+	if slices.Contains(g.reservedWords, strings.ToLower(name)) {
+		return name + "Model"
+	}
+	return name
+}
+
+func (g *TypeScriptGenerator) ToFunctionName(name string) string {
+	name = util.ToCamelCase(g.sanitizeName(name))
+
+	if slices.Contains(g.reservedWords, name) {
+		return name + "Func"
+	}
+	return name
+}
+
+// ToPropertyName keeps the original wire name, so that JSON payloads can be used without mapping - invalid identifiers are quoted
+func (g *TypeScriptGenerator) ToPropertyName(name string) string {
+	if identifierPattern.MatchString(name) {
+		return name
+	}
+
+	return strconv.Quote(name)
+}
+
+func (g *TypeScriptGenerator) ToParameterName(name string) string {
+	name = util.ToCamelCase(g.sanitizeName(name))
+
+	if slices.Contains(g.reservedWords, name) {
+		return name + "Param"
+	}
+	return name
+}
+
+func (g *TypeScriptGenerator) ToConstantName(name string) string {
+	name = g.sanitizeName(name)
+
+	if slices.Contains(g.reservedWords, strings.ToLower(name)) {
+		name = name + "_CONST"
+	}
+	return util.ToUpperSnakeCase(name)
+}
+
+func (g *TypeScriptGenerator) sanitizeName(name string) string {
+	// special case: starts with a digit
+	if len(name) > 0 && name[0] >= '0' && name[0] <= '9' {
+		name = "p" + name
+	}
+
+	// replace everything that is not allowed in an identifier
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || r == '-' || r == ' ' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+func (g *TypeScriptGenerator) ToCodeType(schema *base.Schema, schemaType openapigenerator.CodeTypeSchemaType, required bool) (openapigenerator.CodeType, error) {
+	if schema == nil {
+		return openapigenerator.DefaultCodeType, fmt.Errorf("schema is nil")
+	}
+
+	// multiple types (e.g., ["string", "integer"])
+	if util.CountExcluding(schema.Type, "null") > 1 {
+		return openapigenerator.CodeType{Name: "unknown"}, nil
+	}
+
+	switch {
+	case len(schema.Type) == 0 && len(schema.OneOf) > 0:
+		codeTypes := make([]openapigenerator.CodeType, 0, len(schema.OneOf))
+		for _, oneOfSchema := range schema.OneOf {
+			codeType, err := g.ToCodeType(oneOfSchema.Schema(), schemaType, true)
+			if err != nil {
+				return openapigenerator.DefaultCodeType, errors.Join(fmt.Errorf("unhandled oneOf type. schema: %s, format: %s", schema.Type, schema.Format), err)
+			}
+			codeTypes = append(codeTypes, codeType)
+		}
+
+		if openapigenerator.HaveSameCodeTypeName(codeTypes) {
+			return codeTypes[0], nil
+		}
+		return openapigenerator.CodeType{Name: "unknown"}, nil
+	case slices.Contains(schema.Type, "string"):
+		switch schema.Format {
+		case "binary":
+			return openapigenerator.CodeType{Name: "Blob"}, nil
+		default:
+			return openapigenerator.NewSimpleCodeType("string", schema), nil
+		}
+	case slices.Contains(schema.Type, "boolean"):
+		return openapigenerator.NewSimpleCodeType("boolean", schema), nil
+	case slices.Contains(schema.Type, "integer"), slices.Contains(schema.Type, "number"):
+		return openapigenerator.NewSimpleCodeType("number", schema), nil
+	case slices.Contains(schema.Type, "array"):
+		if schema.Items == nil || schema.Items.A == nil {
+			return openapigenerator.DefaultCodeType, fmt.Errorf("array schema missing items definition")
+		}
+		arrayType, err := g.ToCodeType(schema.Items.A.Schema(), schemaType, true)
+		if err != nil {
+			return openapigenerator.DefaultCodeType, errors.Join(fmt.Errorf("unhandled array type. schema: %s, format: %s", schema.Type, schema.Format), err)
+		}
+		return openapigenerator.NewArrayCodeType(arrayType, schema), nil
+	case slices.Contains(schema.Type, "object") || schema.Type == nil:
+		if schema.PatternProperties != nil {
+			pp := schema.PatternProperties.First()
+			ppSchema := pp.Value().Schema()
+
+			additionalPropertyType, err := g.ToCodeType(ppSchema, schemaType, true)
+			if err != nil {
+				return openapigenerator.DefaultCodeType, errors.Join(fmt.Errorf("unhandled pattern properties type. schema: %s, format: %s", schema.Type, schema.Format), err)
+			}
+
+			return openapigenerator.NewMapCodeType(openapigenerator.NewSimpleCodeType("string", schema), additionalPropertyType, schema), nil
+		} else if schema.AdditionalProperties != nil && schema.AdditionalProperties.IsA() {
+			additionalPropertyType, err := g.ToCodeType(schema.AdditionalProperties.A.Schema(), schemaType, true)
+			if err != nil {
+				return openapigenerator.DefaultCodeType, errors.Join(fmt.Errorf("unhandled additional properties type. schema: %s, format: %s", schema.Type, schema.Format), err)
+			}
+
+			return openapigenerator.NewMapCodeType(openapigenerator.NewSimpleCodeType("string", schema), additionalPropertyType, schema), nil
+		} else if schema.AdditionalProperties != nil && schema.AdditionalProperties.IsB() && schema.AdditionalProperties.B {
+			return openapigenerator.NewMapCodeType(openapigenerator.NewSimpleCodeType("string", schema), openapigenerator.NewSimpleCodeType("unknown", schema), schema), nil
+		} else if schema.Properties == nil && len(schema.OneOf) == 0 && len(schema.AnyOf) == 0 && len(schema.AllOf) == 0 {
+			return openapigenerator.CodeType{Name: "unknown"}, nil
+		}
+
+		if schema.Title == "" {
+			return openapigenerator.DefaultCodeType, fmt.Errorf("schema does not have a title. schema: %s", schema.Type)
+		}
+		return openapigenerator.CodeType{Name: g.ToClassName(schema.Title), ImportPath: "models"}, nil
+	default:
+		return openapigenerator.DefaultCodeType, fmt.Errorf("unhandled type. schema: %s, format: %s", schema.Type, schema.Format)
+	}
+}
+
+func (g *TypeScriptGenerator) PostProcessType(codeType openapigenerator.CodeType) openapigenerator.CodeType {
+	if codeType.IsPostProcessed {
+		return codeType
+	}
+
+	// VoidType
+	if codeType.IsVoid {
+		codeType.Declaration = "void"
+		codeType.QualifiedDeclaration = "void"
+		codeType.Type = "void"
+		codeType.QualifiedType = "void"
+		codeType.IsPostProcessed = true
+		return codeType
+	}
+
+	// PostProcess TypeArgs
+	for i, typeArg := range codeType.TypeArgs {
+		codeType.TypeArgs[i] = g.PostProcessType(typeArg)
+	}
+
+	// Qualifier
+	qualifier := ""
+	if codeType.ImportPath != "" {
+		parts := strings.Split(codeType.ImportPath, "/")
+		qualifier = parts[len(parts)-1] + "."
+	}
+
+	// FullyQualifiedName
+	switch {
+	case codeType.IsArray || codeType.IsList:
+		codeType.Declaration = "Array<" + codeType.TypeArgs[0].Declaration + ">"
+		codeType.QualifiedDeclaration = "Array<" + codeType.TypeArgs[0].QualifiedDeclaration + ">"
+		codeType.Type = "Array<" + codeType.TypeArgs[0].Type + ">"
+		codeType.QualifiedType = "Array<" + codeType.TypeArgs[0].QualifiedType + ">"
+	case codeType.IsMap:
+		codeType.Declaration = "Record<" + codeType.TypeArgs[0].Declaration + ", " + codeType.TypeArgs[1].Declaration + ">"
+		codeType.QualifiedDeclaration = "Record<" + codeType.TypeArgs[0].QualifiedDeclaration + ", " + codeType.TypeArgs[1].QualifiedDeclaration + ">"
+		codeType.Type = "Record<" + codeType.TypeArgs[0].Type + ", " + codeType.TypeArgs[1].Type + ">"
+		codeType.QualifiedType = "Record<" + codeType.TypeArgs[0].QualifiedType + ", " + codeType.TypeArgs[1].QualifiedType + ">"
+	default:
+		codeType.Declaration = codeType.Name
+		codeType.QualifiedDeclaration = qualifier + codeType.Name
+		codeType.Type = codeType.Name
+		codeType.QualifiedType = qualifier + codeType.Name
+	}
+
+	codeType.IsPostProcessed = true
+	return codeType
+}
+
+func (g *TypeScriptGenerator) IsPrimitiveType(input string) bool {
+	return slices.Contains(g.primitiveTypes, input)
+}
+
+func (g *TypeScriptGenerator) TypeToImport(iType openapigenerator.CodeType) string {
+	typeName := iType.Name
+	if typeName == "" {
+		return ""
+	}
+
+	return g.typeToImport[typeName]
+}
+
+const prettierBinary = "prettier"
+
+func (g *TypeScriptGenerator) PostProcessing(files map[string]templateapi.RenderedFile) error {
+	if os.Getenv("PRIMECODEGEN_SKIP_POST_PROCESSING") == "true" {
+		slog.Debug("Skipping post processing typescript files")
+		return nil
+	}
+
+	if openapigenerator.IsBinaryAvailable(prettierBinary) {
+		var formatFiles []string
+		for _, f := range files {
+			if strings.HasSuffix(f.File, ".ts") && f.State == templateapi.FileRendered {
+				formatFiles = append(formatFiles, f.File)
+			}
+		}
+		if len(formatFiles) == 0 {
+			return nil
+		}
+
+		slog.Debug("Post processing ts files using "+prettierBinary, "file_len", len(formatFiles))
+		cmd := exec.Command(prettierBinary, "--write", "--log-level", "warn")
+		cmd.Args = append(cmd.Args, formatFiles...)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		err := cmd.Run()
+		if err != nil {
+			return fmt.Errorf("error running %s: %v", prettierBinary, err)
+		}
+	}
+
+	return nil
+}
+
+func NewGenerator() *TypeScriptGenerator {
+	// references: https://openapi-generator.tech/docs/generators/typescript-fetch
+	return &TypeScriptGenerator{
+		reservedWords: []string{
+			"abstract",
+			"any",
+			"as",
+			"async",
+			"await",
+			"boolean",
+			"break",
+			"case",
+			"catch",
+			"class",
+			"const",
+			"constructor",
+			"continue",
+			"debugger",
+			"declare",
+			"default",
+			"delete",
+			"do",
+			"else",
+			"enum",
+			"export",
+			"extends",
+			"false",
+			"finally",
+			"for",
+			"from",
+			"function",
+			"get",
+			"if",
+			"implements",
+			"import",
+			"in",
+			"instanceof",
+			"interface",
+			"let",
+			"module",
+			"new",
+			"null",
+			"number",
+			"object",
+			"of",
+			"package",
+			"private",
+			"protected",
+			"public",
+			"require",
+			"return",
+			"set",
+			"static",
+			"string",
+			"super",
+			"switch",
+			"symbol",
+			"this",
+			"throw",
+			"true",
+			"try",
+			"type",
+			"typeof",
+			"undefined",
+			"unknown",
+			"var",
+			"void",
+			"while",
+			"with",
+			"yield",
+		},
+		primitiveTypes: []string{
+			"string",
+			"number",
+			"boolean",
+			"unknown",
+			"Blob",
+		},
+		typeToImport: map[string]string{},
+	}
+}
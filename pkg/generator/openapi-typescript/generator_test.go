@@ -0,0 +1,182 @@
+package openapi_typescript
+
+import (
+	"testing"
+
+	"github.com/primelib/primecodegen/pkg/generator/generatortest"
+	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
+	"github.com/primelib/primecodegen/pkg/openapi/openapigenerator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var commonPackages = openapigenerator.CommonPackages{
+	Root:       "",
+	Client:     "client",
+	Models:     "models",
+	Responses:  "models",
+	Enums:      "models",
+	Operations: "services",
+	Auth:       "auth",
+}
+
+func TestOperationBasic(t *testing.T) {
+	// arrange
+	v3doc := openapidocument.OpenV3DocumentForTest(generatortest.OperationBasic)
+
+	// act
+	templateData, err := openapigenerator.BuildTemplateData(v3doc, NewGenerator(), commonPackages)
+	assert.NoError(t, err)
+	assert.NotNil(t, templateData)
+
+	// assert
+	assert.Len(t, templateData.Operations, 2)
+	assert.Equal(t, "GetPet", templateData.Operations[0].Name)
+	assert.Equal(t, "get", templateData.Operations[0].Method)
+	assert.Equal(t, "/pets/{petId}", templateData.Operations[0].Path)
+	assert.Equal(t, "models.Pet", templateData.Operations[0].ReturnType.QualifiedDeclaration)
+	assert.Len(t, templateData.Operations[0].PathParameters, 1)
+	assert.Equal(t, "petId", templateData.Operations[0].PathParameters[0].Name)
+	assert.Equal(t, "string", templateData.Operations[0].PathParameters[0].Type.QualifiedDeclaration)
+	assert.Len(t, templateData.Operations[0].QueryParameters, 1)
+	assert.Equal(t, "fields", templateData.Operations[0].QueryParameters[0].Name)
+	assert.Equal(t, "Array<string>", templateData.Operations[0].QueryParameters[0].Type.QualifiedDeclaration)
+	assert.Equal(t, "CreatePet", templateData.Operations[1].Name)
+	assert.Equal(t, "post", templateData.Operations[1].Method)
+	assert.NotNil(t, templateData.Operations[1].BodyParameter)
+	assert.Equal(t, "models.Pet", templateData.Operations[1].BodyParameter.Type.QualifiedDeclaration)
+	assert.True(t, templateData.Operations[1].RequestBodyRequired)
+}
+
+func TestModelBasic(t *testing.T) {
+	// arrange
+	v3doc := openapidocument.OpenV3DocumentForTest(generatortest.ModelBasic)
+
+	// act
+	templateData, err := openapigenerator.BuildTemplateData(v3doc, NewGenerator(), commonPackages)
+	assert.NoError(t, err)
+	assert.NotNil(t, templateData)
+
+	// assert
+	assert.Len(t, templateData.Models, 2)
+	assert.Equal(t, "Pet", templateData.Models[0].Name)
+	properties := templateData.Models[0].Properties
+	assert.Len(t, properties, 9)
+	assert.Equal(t, "name", properties[0].Name)
+	assert.Equal(t, "string", properties[0].Type.QualifiedDeclaration)
+	assert.Equal(t, "age", properties[1].Name)
+	assert.Equal(t, "number", properties[1].Type.QualifiedDeclaration)
+	assert.Equal(t, "tags", properties[2].Name)
+	assert.Equal(t, "Array<string>", properties[2].Type.QualifiedDeclaration)
+	assert.Equal(t, "owner", properties[4].Name)
+	assert.Equal(t, "models.Owner", properties[4].Type.QualifiedDeclaration)
+	assert.Equal(t, "metadata", properties[5].Name)
+	assert.Equal(t, "Record<string, unknown>", properties[5].Type.QualifiedDeclaration)
+	assert.Equal(t, "type", properties[6].Name)
+	assert.Equal(t, "class", properties[7].Name)
+	assert.Equal(t, `"content-type"`, properties[8].Name)
+	assert.Equal(t, "Owner", templateData.Models[1].Name)
+	assert.Len(t, templateData.Enums, 1)
+	assert.Equal(t, "PetStatus", templateData.Enums[0].Name)
+}
+
+func TestGenerateOperationBasic(t *testing.T) {
+	// arrange
+	t.Setenv("PRIMECODEGEN_SKIP_POST_PROCESSING", "true")
+	outputDir := t.TempDir()
+
+	// act
+	err := NewGenerator().Generate(openapigenerator.GenerateOpts{
+		Doc:        openapidocument.OpenV3DocumentForTest(generatortest.OperationBasic),
+		OutputDir:  outputDir,
+		TemplateId: "httpclient",
+		ArtifactId: "pet-client",
+	})
+	assert.NoError(t, err)
+
+	// assert
+	service := generatortest.ReadGeneratedFile(t, outputDir, "src/services/PetsApi.ts")
+	assert.Contains(t, service, "export class PetsApi {")
+	assert.Contains(t, service, "export interface GetPetParams {\n    petId: string;\n    fields?: Array<string>;\n}")
+	assert.Contains(t, service, "async getPet(params: GetPetParams, options?: RequestOptions): Promise<ApiResponse<models.Pet>> {")
+	assert.Contains(t, service, "path: `/pets/${encodeURIComponent(String(params.petId))}`,")
+	assert.Contains(t, service, `appendQuery(query, "fields", params.fields, true, ",");`)
+	assert.Contains(t, service, "async createPet(params: CreatePetParams, options?: RequestOptions): Promise<ApiResponse<models.Pet>> {")
+	assert.Contains(t, service, "body: params.payload,")
+	assert.Contains(t, generatortest.ReadGeneratedFile(t, outputDir, "src/services/index.ts"), `export * from "./PetsApi.js";`)
+	assert.Contains(t, generatortest.ReadGeneratedFile(t, outputDir, "src/client.ts"), "this.pets = new PetsApi(this.http);")
+	assert.Contains(t, generatortest.ReadGeneratedFile(t, outputDir, "package.json"), `"name": "pet-client"`)
+}
+
+func TestGenerateModelNaming(t *testing.T) {
+	// arrange
+	t.Setenv("PRIMECODEGEN_SKIP_POST_PROCESSING", "true")
+	outputDir := t.TempDir()
+
+	// act
+	err := NewGenerator().Generate(openapigenerator.GenerateOpts{
+		Doc:        openapidocument.OpenV3DocumentForTest(generatortest.ModelNaming),
+		OutputDir:  outputDir,
+		TemplateId: "httpclient",
+		ArtifactId: "pet-client",
+	})
+	assert.NoError(t, err)
+
+	// assert: reserved class names get a suffix, properties keep the wire name and are only quoted if they are no identifier
+	model := generatortest.ReadGeneratedFile(t, outputDir, "src/models/ClassModel.ts")
+	assert.Contains(t, model, "export interface ClassModel {")
+	assert.Contains(t, model, "    import?: string;")
+	assert.Contains(t, model, "    default?: string;")
+	assert.Contains(t, model, "    \"1st-place\"?: boolean;")
+	enum := generatortest.ReadGeneratedFile(t, outputDir, "src/models/TaskStatus.ts")
+	assert.Contains(t, enum, `    P_2_FA_REQUIRED: "2fa-required",`)
+	assert.Contains(t, enum, `    CLASS_CONST: "class",`)
+	assert.Contains(t, enum, `    IN_PROGRESS: "in-progress",`)
+}
+
+func TestGenerateModelNullable(t *testing.T) {
+	// arrange
+	t.Setenv("PRIMECODEGEN_SKIP_POST_PROCESSING", "true")
+	outputDir := t.TempDir()
+
+	// act
+	err := NewGenerator().Generate(openapigenerator.GenerateOpts{
+		Doc:        openapidocument.OpenV3DocumentForTest(generatortest.ModelNullable),
+		OutputDir:  outputDir,
+		TemplateId: "httpclient",
+		ArtifactId: "pet-client",
+	})
+	assert.NoError(t, err)
+
+	// assert: all properties are optional, nullable properties additionally accept null
+	model := generatortest.ReadGeneratedFile(t, outputDir, "src/models/Task.ts")
+	assert.Contains(t, model, "    id?: string;")
+	assert.Contains(t, model, "    deletedAt?: string | null;")
+	assert.Contains(t, model, "    nickname?: string;")
+	assert.Contains(t, model, "    priority?: number | null;")
+}
+
+func TestOperationMultipart(t *testing.T) {
+	// arrange
+	v3doc := openapidocument.OpenV3DocumentForTest(generatortest.OperationMultipart)
+
+	// act
+	templateData, err := openapigenerator.BuildTemplateData(v3doc, NewGenerator(), commonPackages)
+	assert.NoError(t, err)
+
+	// assert
+	require.Len(t, templateData.Operations, 1)
+	form := templateData.Operations[0].Form
+	require.NotNil(t, form)
+	assert.True(t, form.Multipart)
+	require.Len(t, form.Parts, 3)
+	assert.Equal(t, "file", form.Parts[0].Name)
+	assert.Equal(t, "Blob", form.Parts[0].Type.QualifiedDeclaration)
+	assert.True(t, form.Parts[0].IsFile)
+	assert.Equal(t, "image/png", form.Parts[0].ContentType)
+	assert.Equal(t, "caption", form.Parts[1].Name)
+	assert.Equal(t, "string", form.Parts[1].Type.QualifiedDeclaration)
+	assert.Equal(t, "tags", form.Parts[2].Name)
+	assert.True(t, form.Parts[2].IsArray)
+	assert.Equal(t, "string", form.Parts[2].ItemType.QualifiedDeclaration)
+}
@@ -0,0 +1,63 @@
+package openapicmd
+
+import (
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/primelib/primecodegen/pkg/app/specutil"
+	"github.com/spf13/cobra"
+)
+
+func ChangelogCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "openapi-changelog",
+		Aliases: []string{},
+		GroupID: "openapi",
+		Short:   "Add the changes between two OpenAPI 3 Specifications to a CHANGELOG.md, grouped by version and change level",
+		Run: func(cmd *cobra.Command, args []string) {
+			// inputs
+			baseFile, _ := cmd.Flags().GetString("base")
+			revisionFile, _ := cmd.Flags().GetString("revision")
+			if baseFile == "" || revisionFile == "" {
+				slog.Error("base and revision specification are required")
+				os.Exit(1)
+			}
+			outputFile, _ := cmd.Flags().GetString("output")
+			version, _ := cmd.Flags().GetString("version")
+			date, _ := cmd.Flags().GetString("date")
+			if version != "" && date == "" {
+				date = time.Now().Format(time.DateOnly)
+			}
+			operationLink, _ := cmd.Flags().GetString("operation-link")
+			serviceLink, _ := cmd.Flags().GetString("service-link")
+
+			// diff
+			diff, err := specutil.DiffSpec("openapi", baseFile, revisionFile)
+			if err != nil {
+				slog.Error("failed to diff api specs", "err", err)
+				os.Exit(1)
+			}
+
+			// changelog
+			err = specutil.UpdateChangelog(outputFile, version, date, diff, specutil.ChangelogOptions{
+				OperationLink: operationLink,
+				ServiceLink:   serviceLink,
+			})
+			if err != nil {
+				slog.Error("failed to update changelog", "err", err)
+				os.Exit(1)
+			}
+			slog.Info("updated changelog", "file", outputFile, "changes", len(diff.OpenAPI))
+		},
+	}
+	cmd.Flags().StringP("base", "b", "", "Base Specification (YAML or JSON)")
+	cmd.Flags().StringP("revision", "r", "", "Revised Specification (YAML or JSON)")
+	cmd.Flags().StringP("output", "o", "CHANGELOG.md", "Changelog File")
+	cmd.Flags().String("version", "", "Released version, the changes are added to the unreleased section if empty")
+	cmd.Flags().String("date", "", "Release date, defaults to today if a version is set")
+	cmd.Flags().String("operation-link", "", "Link for operation ids, supports the {operationId} placeholder")
+	cmd.Flags().String("service-link", "", "Link for the affected services, supports the {service} placeholder")
+
+	return cmd
+}
@@ -56,7 +56,7 @@ func ConvertCmd() *cobra.Command {
 	cmd.Flags().StringP("output-dir", "o", "", "Output Directory")
 	cmd.Flags().StringP("format-in", "f", "swagger20", fmt.Sprintf("Input format (supported: %s)", strings.Join(openapiconvert.SupportedInputFormats, ", ")))
 	cmd.Flags().StringP("format-out", "r", "openapi30", fmt.Sprintf("Output format (supported: %s)", strings.Join(openapiconvert.SupportedOutputFormats, ", ")))
-	cmd.Flags().StringP("converter", "c", "openapi-converter", "Converter to use (supported: openapi-converter, speakeasy)")
+	cmd.Flags().StringP("converter", "c", "", "Swagger 2.0 converter to use (supported: native, openapi-converter, speakeasy), defaults to openapi-converter if PRIMECODEGEN_SWAGGER_CONVERTER is set, otherwise native")
 	return cmd
 }
 
@@ -0,0 +1,67 @@
+package openapicmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/primelib/primecodegen/pkg/app/specutil"
+	"github.com/spf13/cobra"
+)
+
+func DiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "openapi-diff",
+		Aliases: []string{},
+		GroupID: "openapi",
+		Short:   "Compare two OpenAPI 3 Specifications and classify the changes as patch, minor or breaking",
+		Run: func(cmd *cobra.Command, args []string) {
+			// inputs
+			baseFile, _ := cmd.Flags().GetString("base")
+			revisionFile, _ := cmd.Flags().GetString("revision")
+			if baseFile == "" || revisionFile == "" {
+				slog.Error("base and revision specification are required")
+				os.Exit(1)
+			}
+			format, _ := cmd.Flags().GetString("format")
+			failOnBreaking, _ := cmd.Flags().GetBool("fail-on-breaking")
+
+			// diff
+			diff, err := specutil.DiffSpec("openapi", baseFile, revisionFile)
+			if err != nil {
+				slog.Error("failed to diff api specs", "err", err)
+				os.Exit(1)
+			}
+
+			// output
+			switch format {
+			case "json":
+				output, err := json.MarshalIndent(diff.OpenAPI, "", "  ")
+				if err != nil {
+					slog.Error("failed to render diff", "err", err)
+					os.Exit(1)
+				}
+				fmt.Println(string(output))
+			case "text":
+				for _, change := range diff.OpenAPI {
+					fmt.Printf("[%s] %s %s: %s\n", change.LevelAsString(), change.Operation, change.Path, change.Text)
+				}
+			default:
+				slog.Error("unsupported output format", "format", format)
+				os.Exit(1)
+			}
+
+			if failOnBreaking && diff.BreakingChangeCount() > 0 {
+				slog.Error("Breaking changes detected", "count", diff.BreakingChangeCount())
+				os.Exit(1)
+			}
+		},
+	}
+	cmd.Flags().StringP("base", "b", "", "Base Specification (YAML or JSON)")
+	cmd.Flags().StringP("revision", "r", "", "Revised Specification (YAML or JSON)")
+	cmd.Flags().StringP("format", "f", "text", "Output Format (text|json)")
+	cmd.Flags().Bool("fail-on-breaking", false, "Exit with a non-zero exit code if breaking changes are detected")
+
+	return cmd
+}
@@ -6,13 +6,18 @@ import (
 	"log/slog"
 	"os"
 
+	"github.com/cidverse/cidverseutils/core/clioutputwriter"
 	openapi_default "github.com/primelib/primecodegen/pkg/generator/openapi-default"
 	"github.com/primelib/primecodegen/pkg/patch/sharedpatch"
 
+	openapi_csharp "github.com/primelib/primecodegen/pkg/generator/openapi-csharp"
 	openapi_go "github.com/primelib/primecodegen/pkg/generator/openapi-go"
 	openapi_java "github.com/primelib/primecodegen/pkg/generator/openapi-java"
 	openapi_kotlin "github.com/primelib/primecodegen/pkg/generator/openapi-kotlin"
 	openapi_kotlin_multiplatform "github.com/primelib/primecodegen/pkg/generator/openapi-kotlin-multiplatform"
+	openapi_python "github.com/primelib/primecodegen/pkg/generator/openapi-python"
+	openapi_rust "github.com/primelib/primecodegen/pkg/generator/openapi-rust"
+	openapi_typescript "github.com/primelib/primecodegen/pkg/generator/openapi-typescript"
 	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
 	"github.com/primelib/primecodegen/pkg/openapi/openapigenerator"
 	"github.com/primelib/primecodegen/pkg/openapi/openapipatch"
@@ -22,10 +27,14 @@ import (
 
 var generators = []openapigenerator.CodeGenerator{
 	openapi_default.NewGenerator(),
+	openapi_csharp.NewGenerator(),
 	openapi_go.NewGenerator(),
 	openapi_java.NewGenerator(),
 	openapi_kotlin.NewGenerator(),
 	openapi_kotlin_multiplatform.NewGenerator(),
+	openapi_python.NewGenerator(),
+	openapi_rust.NewGenerator(),
+	openapi_typescript.NewGenerator(),
 }
 
 func GenerateCmd() *cobra.Command {
@@ -40,6 +49,8 @@ func GenerateCmd() *cobra.Command {
 			out, _ := cmd.Flags().GetString("output")
 			generatorId, _ := cmd.Flags().GetString("generator")
 			templateId, _ := cmd.Flags().GetString("template")
+			templateDir, _ := cmd.Flags().GetString("template-dir")
+			check, _ := cmd.Flags().GetBool("check")
 			patches, _ := cmd.Flags().GetStringArray("patches")
 			tplProps, _ := cmd.Flags().GetStringArray("tpl-prop")
 			in = util.ResolvePath(in)
@@ -74,19 +85,26 @@ func GenerateCmd() *cobra.Command {
 				RepositoryUrl:      metadataRepositoryUrl,
 				LicenseName:        metadataLicenseName,
 				LicenseUrl:         metadataLicenseUrl,
+				TemplateDir:        util.ResolvePath(templateDir),
 				TemplateProperties: parsedTplProps,
+				Check:              check,
 			})
-			if err != nil {
+			if errors.Is(err, openapigenerator.ErrStaleOutput) {
+				slog.Error("generated output is stale, run openapi-generate without --check to update it", "err", err)
+				os.Exit(1)
+			} else if err != nil {
 				slog.Error("failed to generate code", "err", err)
 				os.Exit(1)
 			}
 		},
 	}
 	cmd.Flags().Bool("dry-run", false, "Perform a dry run without making any changes")
+	cmd.Flags().Bool("check", false, "Exit with a non-zero status if the generated output is stale, without making any changes")
 	cmd.Flags().StringP("input", "i", "", "Input Specification")
 	cmd.Flags().StringP("output", "o", "", "Output Directory")
 	cmd.Flags().StringP("generator", "g", "", "Code Generation Generator ID")
 	cmd.Flags().StringP("template", "t", "", "Code Generation Template ID")
+	cmd.Flags().String("template-dir", "", "Template directory with a template.yaml manifest or files that override the embedded template by name")
 	cmd.Flags().StringArray("patches", openapigenerator.DefaultCodeGenerationPatches, "Code Generation Patches")
 	cmd.Flags().String("md-group-id", "", "Artifact Group ID")
 	cmd.Flags().String("md-artifact-id", "", "Artifact ID")
@@ -95,6 +113,57 @@ func GenerateCmd() *cobra.Command {
 	cmd.Flags().String("md-license-url", "", "License URL")
 	cmd.Flags().StringArray("tpl-prop", []string{}, "Template property override in the form key=value (repeatable, allowed keys depend on template)")
 
+	cmd.AddCommand(GenerateListCmd())
+
+	return cmd
+}
+
+func GenerateListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{},
+		Short:   "List available generators, including generator plugins (" + openapigenerator.PluginPrefix + "<id>) on PATH",
+		Run: func(cmd *cobra.Command, args []string) {
+			format, _ := cmd.Flags().GetString("format")
+			columns, _ := cmd.Flags().GetStringSlice("columns")
+
+			// data
+			data := clioutputwriter.TabularData{
+				Headers: []string{"ID", "TYPE", "Description"},
+				Rows:    [][]interface{}{},
+			}
+			for _, g := range generators {
+				data.Rows = append(data.Rows, []interface{}{
+					g.Id(),
+					"builtin",
+					g.Description(),
+				})
+			}
+			for _, p := range openapigenerator.DiscoverPlugins() {
+				data.Rows = append(data.Rows, []interface{}{
+					p.Id(),
+					"plugin",
+					p.Description(),
+				})
+			}
+
+			// filter columns
+			if len(columns) > 0 {
+				data = clioutputwriter.FilterColumns(data, columns)
+			}
+
+			// print
+			err := clioutputwriter.PrintData(os.Stdout, data, clioutputwriter.Format(format))
+			if err != nil {
+				slog.Error("failed to print data", "err", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringP("format", "f", string(clioutputwriter.DefaultOutputFormat()), fmt.Sprintf("output format %s", clioutputwriter.SupportedOutputFormats()))
+	cmd.Flags().StringSliceP("columns", "c", []string{}, "columns to display")
+
 	return cmd
 }
 
@@ -131,16 +200,19 @@ func Generate(inputSpec string, patches []string, generatorId string, templateId
 		return errors.Join(util.ErrNoGeneratorWithId, err)
 	}
 	generatorOpts := openapigenerator.GenerateOpts{
-		DryRun:             false,
+		DryRun:             opts.Check,
+		Check:              opts.Check,
 		Doc:                v3doc,
 		OutputDir:          outputDir,
 		TemplateId:         templateId,
+		TemplateDir:        opts.TemplateDir,
 		TemplateProperties: opts.TemplateProperties,
 		ArtifactGroupId:    opts.ArtifactGroupId,
 		ArtifactId:         opts.ArtifactId,
 		RepositoryUrl:      opts.RepositoryUrl,
 		LicenseName:        opts.LicenseName,
 		LicenseUrl:         opts.LicenseUrl,
+		Maintainers:        opts.Maintainers,
 		Provider:           opts.Provider,
 		GeneratorNames:     opts.GeneratorNames,
 		GeneratorOutputs:   opts.GeneratorOutputs,
@@ -152,7 +224,7 @@ func Generate(inputSpec string, patches []string, generatorId string, templateId
 	}
 	generatorOpts.TemplateProperties = resolvedTemplateProperties
 
-	slog.Info("running generator", "generator-id", gen.Id(), "template", templateId, "dry-run", generatorOpts.DryRun, "output-dir", generatorOpts.OutputDir)
+	slog.Info("running generator", "generator-id", gen.Id(), "template", templateId, "dry-run", generatorOpts.DryRun, "check", generatorOpts.Check, "output-dir", generatorOpts.OutputDir)
 	err = gen.Generate(generatorOpts)
 	if err != nil {
 		return err
@@ -0,0 +1,126 @@
+package openapicmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/cidverse/cidverseutils/core/clioutputwriter"
+	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
+	"github.com/primelib/primecodegen/pkg/openapi/openapilint"
+	"github.com/spf13/cobra"
+)
+
+func LintCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "openapi-lint",
+		Aliases: []string{},
+		GroupID: "openapi",
+		Short:   "Report problems in an OpenAPI 3 Specification without modifying it",
+		Run: func(cmd *cobra.Command, args []string) {
+			// inputs
+			inputFile, _ := cmd.Flags().GetString("input")
+			if inputFile == "" {
+				slog.Error("input specification is required")
+				os.Exit(1)
+			}
+			format, _ := cmd.Flags().GetString("format")
+			out, _ := cmd.Flags().GetString("output")
+			ruleIds, _ := cmd.Flags().GetStringSlice("rule")
+			failOn, _ := cmd.Flags().GetString("fail-on")
+
+			rules, err := openapilint.RulesByID(ruleIds)
+			if err != nil {
+				slog.Error("failed to select lint rules", "err", err)
+				os.Exit(1)
+			}
+
+			// open document
+			doc, err := openapidocument.OpenDocumentFile(inputFile)
+			if err != nil {
+				slog.Error("failed to open document", "err", err)
+				os.Exit(1)
+			}
+			v3doc, err := doc.BuildV3Model()
+			if err != nil {
+				slog.Error("failed to build v3 high level model", "err", err)
+				os.Exit(1)
+			}
+
+			// lint
+			findings := openapilint.Lint(v3doc, rules)
+			report, err := openapilint.Report(findings, rules, inputFile, format)
+			if err != nil {
+				slog.Error("failed to render lint report", "err", err)
+				os.Exit(1)
+			}
+
+			// output
+			if out != "" {
+				err = os.WriteFile(out, report, 0644)
+				if err != nil {
+					slog.Error("failed to write lint report", "err", err)
+					os.Exit(1)
+				}
+			} else {
+				fmt.Printf("%s", report)
+			}
+
+			if failOn != "none" && openapilint.HasFindings(findings, openapilint.Severity(failOn)) {
+				slog.Error("Lint problems detected", "count", len(findings), "fail-on", failOn)
+				os.Exit(1)
+			}
+		},
+	}
+	cmd.Flags().StringP("input", "i", "", "Input Specification (YAML or JSON)")
+	cmd.Flags().StringP("format", "f", openapilint.ReportFormatText, "Output Format (text|json|sarif)")
+	cmd.Flags().StringP("output", "o", "", "Output File, defaults to stdout")
+	cmd.Flags().StringSliceP("rule", "r", []string{}, "Rules to run, all rules are used if none are specified")
+	cmd.Flags().String("fail-on", string(openapilint.SeverityError), "Exit with a non-zero exit code if findings with this or a higher severity are reported (error|warning|info|none)")
+
+	cmd.AddCommand(LintListCmd())
+
+	return cmd
+}
+
+func LintListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{},
+		Short:   "List available lint rules",
+		Run: func(cmd *cobra.Command, args []string) {
+			format, _ := cmd.Flags().GetString("format")
+			columns, _ := cmd.Flags().GetStringSlice("columns")
+
+			// data
+			data := clioutputwriter.TabularData{
+				Headers: []string{"ID", "SEVERITY", "Description"},
+				Rows:    [][]interface{}{},
+			}
+			for _, r := range openapilint.EmbeddedRules {
+				data.Rows = append(data.Rows, []interface{}{
+					r.GetID(),
+					r.GetSeverity(),
+					r.GetDescription(),
+				})
+			}
+
+			// filter columns
+			if len(columns) > 0 {
+				data = clioutputwriter.FilterColumns(data, columns)
+			}
+
+			// print
+			err := clioutputwriter.PrintData(os.Stdout, data, clioutputwriter.Format(format))
+			if err != nil {
+				slog.Error("failed to print data", "err", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringP("format", "f", string(clioutputwriter.DefaultOutputFormat()), fmt.Sprintf("output format %s", clioutputwriter.SupportedOutputFormats()))
+	cmd.Flags().StringSliceP("columns", "c", []string{}, "columns to display")
+
+	return cmd
+}
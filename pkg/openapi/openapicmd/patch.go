@@ -45,9 +45,29 @@ func PatchCmd() *cobra.Command {
 			inputPatches, _ := cmd.Flags().GetStringSlice("input-patch")
 			out, _ := cmd.Flags().GetString("output")
 			patches, _ := cmd.Flags().GetStringSlice("patch")
+			patchSetIds, _ := cmd.Flags().GetStringSlice("patch-set")
+			patchSetFile, _ := cmd.Flags().GetString("patch-set-file")
+			explain, _ := cmd.Flags().GetBool("explain")
+			stopAfter, _ := cmd.Flags().GetString("stop-after")
+
+			// explain writes to stderr, stdout might be used for the patched document
+			opts := openapipatch.ApplyOptions{Explain: explain, StopAfter: stopAfter}
+			if explain {
+				opts.OnPatchApplied = func(report openapipatch.PatchReport) {
+					fmt.Fprint(os.Stderr, openapipatch.FormatPatchReport(report))
+				}
+			}
+
+			// patch sets are applied before the patches
+			specPatches, err := resolvePatchSetFlags(patchSetIds, patchSetFile)
+			if err != nil {
+				slog.Error("failed to resolve patch sets", "err", err)
+				os.Exit(1)
+			}
+			specPatches = append(specPatches, sharedpatch.ParsePatchSpecsFromStrings(patches)...)
 
 			// run patch command
-			stdout, err := Patch(inputFiles, out, sharedpatch.ParsePatchSpecsFromStrings(inputPatches), sharedpatch.ParsePatchSpecsFromStrings(patches))
+			stdout, err := PatchWithOptions(inputFiles, out, sharedpatch.ParsePatchSpecsFromStrings(inputPatches), specPatches, opts)
 			if err != nil {
 				slog.Error("failed to patch document", "err", err)
 				os.Exit(1)
@@ -61,6 +81,10 @@ func PatchCmd() *cobra.Command {
 	cmd.Flags().StringSlice("input-patch", []string{}, "Patches to apply to the input specification(s) pre-merge (<patchId>, file:<name>.patch, file:<name>.jsonpatch)")
 	cmd.Flags().StringP("output", "o", "", "Output File")
 	cmd.Flags().StringSliceP("patch", "p", []string{}, "Patches to apply in order (<patchId>, file:<name>.patch, file:<name>.jsonpatch)")
+	cmd.Flags().StringSlice("patch-set", []string{}, "Patch sets to apply before the patches (builtin or defined in --patch-set-file)")
+	cmd.Flags().String("patch-set-file", "", "YAML file with user-defined patch sets")
+	cmd.Flags().Bool("explain", false, "Print the structural changes (paths, operations and components added, removed or changed) and the duration of each patch to stderr")
+	cmd.Flags().String("stop-after", "", "Stop after the patch with the given id (<patchId> or <patchType>:<patchId>) to inspect the intermediate document")
 
 	cmd.AddCommand(PatchListCmd())
 	cmd.AddCommand(PatchValidateCmd())
@@ -69,6 +93,24 @@ func PatchCmd() *cobra.Command {
 	return cmd
 }
 
+func resolvePatchSetFlags(patchSetIds []string, patchSetFile string) ([]sharedpatch.SpecPatch, error) {
+	var definitions []openapipatch.PatchPreset
+	if patchSetFile != "" {
+		var err error
+		definitions, err = openapipatch.LoadPatchSetFile(patchSetFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var patchSets []openapipatch.PatchSet
+	for _, id := range patchSetIds {
+		patchSets = append(patchSets, openapipatch.PatchSet{Id: id})
+	}
+
+	return openapipatch.ResolvePatchSets(patchSets, definitions)
+}
+
 func PatchListCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "list",
@@ -80,14 +122,20 @@ func PatchListCmd() *cobra.Command {
 
 			// data
 			data := clioutputwriter.TabularData{
-				Headers: []string{"TYPE", "ID", "Description"},
+				Headers: []string{"TYPE", "ID", "Description", "Config"},
 				Rows:    [][]interface{}{},
 			}
 			for _, p := range openapipatch.EmbeddedPatchers {
+				var config []string
+				for _, field := range p.Config {
+					config = append(config, field.String())
+				}
+
 				data.Rows = append(data.Rows, []interface{}{
 					p.Type,
 					p.ID,
 					p.Description,
+					strings.Join(config, ", "),
 				})
 			}
 
@@ -171,12 +219,31 @@ func PatchValidateCmd() *cobra.Command {
 //   - inputPatches: patches to apply to the input specification(s) pre-merge
 //   - patches: patches to apply to the merged specification
 func Patch(inputFiles []string, output string, inputPatches []sharedpatch.SpecPatch, patches []sharedpatch.SpecPatch) ([]byte, error) {
+	return PatchWithOptions(inputFiles, output, inputPatches, patches, openapipatch.ApplyOptions{})
+}
+
+// PatchWithOptions runs the patch command, see openapipatch.ApplyOptions for the explain and stop-after options.
+// If patching stops during the input patches, the merged document is returned without applying the remaining patches.
+func PatchWithOptions(inputFiles []string, output string, inputPatches []sharedpatch.SpecPatch, patches []sharedpatch.SpecPatch, opts openapipatch.ApplyOptions) ([]byte, error) {
 	slog.Info("patching", "input", inputFiles, "input-patches", sharedpatch.SpecPatchesToStringSlice(inputPatches), "patches", sharedpatch.SpecPatchesToStringSlice(patches), "output-file", output)
 	for i, v := range inputFiles {
 		inputFiles[i] = util.ResolvePath(v)
 	}
 	output = util.ResolvePath(output)
 
+	// stop-after only applies to the run that contains the patch
+	inputOpts, patchOpts := opts, opts
+	if opts.StopAfter != "" {
+		if openapipatch.ContainsPatch(inputPatches, opts.StopAfter) {
+			patchOpts.StopAfter = ""
+		} else if openapipatch.ContainsPatch(patches, opts.StopAfter) {
+			inputOpts.StopAfter = ""
+		} else {
+			return nil, errors.Join(openapipatch.ErrStopAfterPatchNotFound, fmt.Errorf("id: %s", opts.StopAfter))
+		}
+	}
+
+	stopped := false
 	if len(inputPatches) > 0 {
 		for _, f := range inputFiles {
 			bytes, err := os.ReadFile(f)
@@ -184,12 +251,13 @@ func Patch(inputFiles []string, output string, inputPatches []sharedpatch.SpecPa
 				return nil, errors.Join(util.ErrReadDocumentFromFile, err)
 			}
 
-			bytes, err = openapipatch.ApplyPatches(bytes, inputPatches)
+			result, err := openapipatch.ApplyPatchesWithOptions(bytes, inputPatches, inputOpts)
 			if err != nil {
 				return nil, errors.Join(util.ErrFailedToPatchDocument, err)
 			}
+			stopped = stopped || result.Stopped
 
-			err = os.WriteFile(f, bytes, 0644)
+			err = os.WriteFile(f, result.Output, 0644)
 			if err != nil {
 				return nil, errors.Join(util.ErrWriteDocumentToFile, err)
 			}
@@ -207,9 +275,12 @@ func Patch(inputFiles []string, output string, inputPatches []sharedpatch.SpecPa
 	}
 
 	// patch document
-	bytes, err = openapipatch.ApplyPatches(bytes, patches)
-	if err != nil {
-		return nil, errors.Join(util.ErrFailedToPatchDocument, err)
+	if !stopped {
+		result, err := openapipatch.ApplyPatchesWithOptions(bytes, patches, patchOpts)
+		if err != nil {
+			return nil, errors.Join(util.ErrFailedToPatchDocument, err)
+		}
+		bytes = result.Output
 	}
 
 	// write document
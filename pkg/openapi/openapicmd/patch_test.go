@@ -0,0 +1,69 @@
+package openapicmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/primelib/primecodegen/pkg/openapi/openapipatch"
+	"github.com/primelib/primecodegen/pkg/patch/sharedpatch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const patchSpec = `openapi: 3.0.0
+info:
+  title: Sample API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      tags: [pets, animals]
+      responses:
+        200:
+          description: OK
+components:
+  schemas:
+    Pet:
+      type: object
+`
+
+func writePatchSpec(t *testing.T) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "openapi.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(patchSpec), 0644))
+	return file
+}
+
+func TestPatchWithOptions_StopAfterPatchWithInputPatches(t *testing.T) {
+	inputPatches := sharedpatch.ParsePatchSpecsFromStrings([]string{"generate-operation-id"})
+	patches := sharedpatch.ParsePatchSpecsFromStrings([]string{"prune-operation-tags-keep-first", "prune-operation-tags"})
+
+	output, err := PatchWithOptions([]string{writePatchSpec(t)}, "", inputPatches, patches, openapipatch.ApplyOptions{StopAfter: "prune-operation-tags-keep-first"})
+	require.NoError(t, err)
+
+	assert.Contains(t, string(output), "operationId:")
+	assert.Contains(t, string(output), "- pets")
+	assert.NotContains(t, string(output), "animals")
+}
+
+func TestPatchWithOptions_StopAfterInputPatch(t *testing.T) {
+	inputPatches := sharedpatch.ParsePatchSpecsFromStrings([]string{"prune-operation-tags-keep-first"})
+	patches := sharedpatch.ParsePatchSpecsFromStrings([]string{"prune-operation-tags"})
+
+	output, err := PatchWithOptions([]string{writePatchSpec(t)}, "", inputPatches, patches, openapipatch.ApplyOptions{StopAfter: "builtin:prune-operation-tags-keep-first"})
+	require.NoError(t, err)
+
+	assert.Contains(t, string(output), "- pets")
+	assert.NotContains(t, string(output), "animals")
+}
+
+func TestPatchWithOptions_StopAfterUnknownPatch(t *testing.T) {
+	inputPatches := sharedpatch.ParsePatchSpecsFromStrings([]string{"generate-operation-id"})
+	patches := sharedpatch.ParsePatchSpecsFromStrings([]string{"prune-operation-tags"})
+
+	_, err := PatchWithOptions([]string{writePatchSpec(t)}, "", inputPatches, patches, openapipatch.ApplyOptions{StopAfter: "prune-operation-tags-keep-first"})
+
+	assert.ErrorIs(t, err, openapipatch.ErrStopAfterPatchNotFound)
+}
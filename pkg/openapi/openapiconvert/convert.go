@@ -11,6 +11,7 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
 	"github.com/primelib/primecodegen/pkg/tools/speakeasycli"
 	"github.com/primelib/primecodegen/pkg/util"
 	"log/slog"
@@ -25,19 +26,36 @@ const (
 	FormatSwagger20     = "swagger2"
 	FormatOpenAPI30     = "openapi3"
 	FormatOpenAPI30JSON = "openapi3-json"
+	FormatOpenAPI31     = "openapi31"
+	FormatOpenAPI31JSON = "openapi31-json"
 )
 
+const (
+	ConverterNative    = "native"
+	ConverterSwagger   = "openapi-converter"
+	ConverterSpeakeasy = "speakeasy"
+)
+
+// formatAliases maps alternative format names to their canonical format
+var formatAliases = map[string]string{
+	"swagger20":      FormatSwagger20,
+	"openapi30":      FormatOpenAPI30,
+	"openapi30-json": FormatOpenAPI30JSON,
+}
+
 var (
 	ErrInvalidInputFormat    = fmt.Errorf("invalid input format")
 	ErrInvalidOutputFormat   = fmt.Errorf("invalid output format")
 	ErrUnsupportedConversion = fmt.Errorf("unsupported conversion")
-	SupportedInputFormats    = []string{FormatSwagger20}
-	SupportedOutputFormats   = []string{FormatOpenAPI30, FormatOpenAPI30JSON}
+	SupportedInputFormats    = []string{FormatSwagger20, FormatOpenAPI30, FormatOpenAPI31}
+	SupportedOutputFormats   = []string{FormatOpenAPI30, FormatOpenAPI30JSON, FormatOpenAPI31, FormatOpenAPI31JSON}
 )
 
 // ConvertSpec converts an input specification file to the desired output format.
 func ConvertSpec(inputPath, formatIn, formatOut, converter string) ([]byte, error) {
 	var result []byte
+	formatIn = normalizeFormat(formatIn)
+	formatOut = normalizeFormat(formatOut)
 
 	// validate parameters
 	if !slices.Contains(SupportedInputFormats, formatIn) {
@@ -54,28 +72,37 @@ func ConvertSpec(inputPath, formatIn, formatOut, converter string) ([]byte, erro
 	}
 
 	// convert
-	if formatIn == FormatSwagger20 && strings.HasPrefix(formatOut, FormatOpenAPI30) {
-		if converter == "" || converter == "openapi-converter" {
-			result, err = ConvertSwaggerToOpenAPIUsingSwaggerConverter(data, converter)
-			if err != nil {
-				return result, err
-			}
-		} else if converter == "speakeasy" {
+	if formatIn == FormatSwagger20 && (formatOut == FormatOpenAPI30 || formatOut == FormatOpenAPI30JSON) {
+		switch resolveSwaggerConverter(converter) {
+		case ConverterNative:
+			result, err = ConvertSwaggerToOpenAPINative(data)
+		case ConverterSwagger:
+			result, err = ConvertSwaggerToOpenAPIUsingSwaggerConverter(data, "")
+		case ConverterSpeakeasy:
 			result, err = ConvertSwaggerToOpenAPIUsingSpeakeasy(data)
-			if err != nil {
-				return result, err
-			}
-		} else {
+		default:
 			return nil, errors.Join(ErrUnsupportedConversion, fmt.Errorf("converter %s does not exist", converter))
 		}
+		if err != nil {
+			return result, err
+		}
 
-		// ConvertSwaggerToOpenAPIUsingSwaggerConverter returns json, convert to YAML if needed
-		if formatOut == FormatOpenAPI30 {
+		// the converters return either json or yaml, convert to the requested output format if needed
+		if formatOut == FormatOpenAPI30 && util.DetectJSONOrYAML(result) == "json" {
 			result, err = util.JSONToYAML(result)
 			if err != nil {
 				return result, err
 			}
+		} else if formatOut == FormatOpenAPI30JSON && util.DetectJSONOrYAML(result) == "yaml" {
+			result, err = openapidocument.ConvertDocument(result, "json")
+			if err != nil {
+				return result, err
+			}
 		}
+	} else if formatIn == FormatOpenAPI31 && (formatOut == FormatOpenAPI30 || formatOut == FormatOpenAPI30JSON) {
+		return ConvertOpenAPI31To30(data, renderFormat(formatOut))
+	} else if formatIn == FormatOpenAPI30 && (formatOut == FormatOpenAPI31 || formatOut == FormatOpenAPI31JSON) {
+		return ConvertOpenAPI30To31(data, renderFormat(formatOut))
 	} else {
 		return nil, errors.Join(ErrUnsupportedConversion, fmt.Errorf("from %s to %s", formatIn, formatOut))
 	}
@@ -83,6 +110,33 @@ func ConvertSpec(inputPath, formatIn, formatOut, converter string) ([]byte, erro
 	return result, nil
 }
 
+// resolveSwaggerConverter picks the swagger 2.0 converter, without an explicit choice the swagger-converter is only used if its endpoint is configured
+func resolveSwaggerConverter(converter string) string {
+	if converter != "" {
+		return converter
+	}
+	if endpoint, _ := os.LookupEnv(converterEndpointEnvVar); endpoint != "" {
+		return ConverterSwagger
+	}
+	return ConverterNative
+}
+
+// normalizeFormat resolves format aliases (e.g. openapi30) to the canonical format name
+func normalizeFormat(format string) string {
+	if canonical, ok := formatAliases[format]; ok {
+		return canonical
+	}
+	return format
+}
+
+// renderFormat returns the document encoding (yaml or json) for an output format
+func renderFormat(format string) string {
+	if strings.HasSuffix(format, "-json") {
+		return "json"
+	}
+	return "yaml"
+}
+
 func ConvertSwaggerToOpenAPIUsingSwaggerConverter(swaggerData []byte, converterUrl string) ([]byte, error) {
 	if converterUrl == "" {
 		converterUrl, _ = os.LookupEnv(converterEndpointEnvVar)
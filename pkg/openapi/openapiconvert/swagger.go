@@ -0,0 +1,771 @@
+package openapiconvert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+const (
+	mediaTypeJSON           = "application/json"
+	mediaTypeFormURLEncoded = "application/x-www-form-urlencoded"
+	mediaTypeMultipart      = "multipart/form-data"
+)
+
+var (
+	ErrInvalidSwaggerDocument = fmt.Errorf("invalid swagger 2.0 document")
+
+	// swaggerOperationMethods are the http methods supported in swagger 2.0 path items
+	swaggerOperationMethods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+	// swaggerParameterSchemaKeys are the keys of a non-body parameter, header or items object that move into the schema
+	swaggerParameterSchemaKeys = []string{"type", "format", "items", "default", "maximum", "exclusiveMaximum", "minimum", "exclusiveMinimum", "maxLength", "minLength", "pattern", "maxItems", "minItems", "uniqueItems", "enum", "multipleOf"}
+)
+
+// swaggerConverter holds the document wide state required to convert a swagger 2.0 document
+type swaggerConverter struct {
+	consumes   []string
+	produces   []string
+	parameters *yaml.Node // global parameter definitions, used to resolve body and formData references
+}
+
+// ConvertSwaggerToOpenAPINative converts a swagger 2.0 document (yaml or json) into an OpenAPI 3.0 document (yaml) without any external tools
+func ConvertSwaggerToOpenAPINative(swaggerData []byte) ([]byte, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(swaggerData, &root); err != nil {
+		return nil, errors.Join(ErrInvalidSwaggerDocument, err)
+	}
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.Join(ErrInvalidSwaggerDocument, fmt.Errorf("document root must be an object"))
+	}
+	doc := root.Content[0]
+	resetNodeStyle(doc)
+
+	if version := mapGet(doc, "swagger"); version == nil || version.Value != "2.0" {
+		return nil, errors.Join(ErrInvalidSwaggerDocument, fmt.Errorf("missing swagger: \"2.0\" version field"))
+	}
+
+	c := swaggerConverter{
+		consumes:   scalarValues(mapGet(doc, "consumes")),
+		produces:   scalarValues(mapGet(doc, "produces")),
+		parameters: mapGet(doc, "parameters"),
+	}
+	result := c.convertDocument(doc)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(result); err != nil {
+		return nil, fmt.Errorf("failed to render data: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (c *swaggerConverter) convertDocument(doc *yaml.Node) *yaml.Node {
+	result := newMap()
+	mapSet(result, "openapi", newString(openAPI30Version))
+	if info := mapGet(doc, "info"); info != nil {
+		mapSet(result, "info", info)
+	}
+	if servers := convertServers(doc); servers != nil {
+		mapSet(result, "servers", servers)
+	}
+	if tags := mapGet(doc, "tags"); tags != nil {
+		mapSet(result, "tags", tags)
+	}
+	if paths := mapGet(doc, "paths"); paths != nil {
+		mapSet(result, "paths", c.convertPaths(paths))
+	}
+	if components := c.convertComponents(doc); len(components.Content) > 0 {
+		mapSet(result, "components", components)
+	}
+	if security := mapGet(doc, "security"); security != nil {
+		mapSet(result, "security", security)
+	}
+	if externalDocs := mapGet(doc, "externalDocs"); externalDocs != nil {
+		mapSet(result, "externalDocs", externalDocs)
+	}
+	copyExtensions(doc, result)
+
+	return result
+}
+
+// convertServers builds the servers from host, basePath and schemes
+func convertServers(doc *yaml.Node) *yaml.Node {
+	host := mapGet(doc, "host")
+	basePath := mapGet(doc, "basePath")
+	if host == nil && basePath == nil {
+		return nil
+	}
+
+	path := "/"
+	if basePath != nil && basePath.Value != "" {
+		path = basePath.Value
+	}
+	var urls []string
+	if host == nil {
+		urls = append(urls, path)
+	} else {
+		schemes := scalarValues(mapGet(doc, "schemes"))
+		if len(schemes) == 0 {
+			schemes = []string{"https"}
+		}
+		for _, scheme := range schemes {
+			urls = append(urls, scheme+"://"+host.Value+strings.TrimSuffix(path, "/"))
+		}
+	}
+
+	servers := newSeq()
+	for _, url := range urls {
+		server := newMap()
+		mapSet(server, "url", newString(url))
+		servers.Content = append(servers.Content, server)
+	}
+	return servers
+}
+
+func (c *swaggerConverter) convertPaths(paths *yaml.Node) *yaml.Node {
+	result := newMap()
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		key, pathItem := paths.Content[i], paths.Content[i+1]
+		if strings.HasPrefix(key.Value, "x-") || pathItem.Kind != yaml.MappingNode {
+			result.Content = append(result.Content, key, pathItem)
+			continue
+		}
+		result.Content = append(result.Content, key, c.convertPathItem(pathItem))
+	}
+	return result
+}
+
+func (c *swaggerConverter) convertPathItem(pathItem *yaml.Node) *yaml.Node {
+	// body and formData parameters have to move into the request body of each operation
+	var pathParameters, pathBodyParameters []*yaml.Node
+	if parameters := mapGet(pathItem, "parameters"); parameters != nil {
+		for _, parameter := range parameters.Content {
+			if c.isBodyOrFormParameter(parameter) {
+				pathBodyParameters = append(pathBodyParameters, parameter)
+			} else {
+				pathParameters = append(pathParameters, parameter)
+			}
+		}
+	}
+
+	result := newMap()
+	for i := 0; i+1 < len(pathItem.Content); i += 2 {
+		key, value := pathItem.Content[i], pathItem.Content[i+1]
+		switch {
+		case key.Value == "$ref":
+			result.Content = append(result.Content, key, newString(convertReference(value.Value, "")))
+		case key.Value == "parameters":
+			if len(pathParameters) > 0 {
+				mapSet(result, "parameters", c.convertParameterList(pathParameters))
+			}
+		case slices.Contains(swaggerOperationMethods, key.Value):
+			result.Content = append(result.Content, key, c.convertOperation(value, pathBodyParameters))
+		default:
+			result.Content = append(result.Content, key, value)
+		}
+	}
+	return result
+}
+
+func (c *swaggerConverter) convertOperation(operation *yaml.Node, pathBodyParameters []*yaml.Node) *yaml.Node {
+	consumes := c.consumes
+	if opConsumes := mapGet(operation, "consumes"); opConsumes != nil {
+		consumes = scalarValues(opConsumes)
+	}
+	produces := c.produces
+	if opProduces := mapGet(operation, "produces"); opProduces != nil {
+		produces = scalarValues(opProduces)
+	}
+
+	var parameters []*yaml.Node
+	bodyParameters := slices.Clone(pathBodyParameters)
+	if opParameters := mapGet(operation, "parameters"); opParameters != nil {
+		for _, parameter := range opParameters.Content {
+			if c.isBodyOrFormParameter(parameter) {
+				bodyParameters = append(bodyParameters, parameter)
+			} else {
+				parameters = append(parameters, parameter)
+			}
+		}
+	}
+
+	result := newMap()
+	requestBodyWritten := false
+	writeRequestBody := func() {
+		if requestBodyWritten {
+			return
+		}
+		requestBodyWritten = true
+		if requestBody := c.convertRequestBody(bodyParameters, consumes); requestBody != nil {
+			mapSet(result, "requestBody", requestBody)
+		}
+	}
+	for i := 0; i+1 < len(operation.Content); i += 2 {
+		key, value := operation.Content[i], operation.Content[i+1]
+		switch key.Value {
+		case "consumes", "produces", "schemes":
+			continue
+		case "parameters":
+			if len(parameters) > 0 {
+				mapSet(result, "parameters", c.convertParameterList(parameters))
+			}
+			writeRequestBody()
+		case "responses":
+			writeRequestBody()
+			mapSet(result, "responses", c.convertResponses(value, produces))
+		default:
+			result.Content = append(result.Content, key, value)
+		}
+	}
+	writeRequestBody()
+
+	return result
+}
+
+// isBodyOrFormParameter checks if a parameter (or a reference to a global parameter) is a body or formData parameter
+func (c *swaggerConverter) isBodyOrFormParameter(parameter *yaml.Node) bool {
+	parameter = c.resolveParameter(parameter)
+	if in := mapGet(parameter, "in"); in != nil {
+		return in.Value == "body" || in.Value == "formData"
+	}
+	return false
+}
+
+// resolveParameter resolves a reference to a global parameter, other parameters are returned as-is
+func (c *swaggerConverter) resolveParameter(parameter *yaml.Node) *yaml.Node {
+	ref := mapGet(parameter, "$ref")
+	if ref == nil || c.parameters == nil || !strings.HasPrefix(ref.Value, "#/parameters/") {
+		return parameter
+	}
+	if resolved := mapGet(c.parameters, strings.TrimPrefix(ref.Value, "#/parameters/")); resolved != nil {
+		return resolved
+	}
+	return parameter
+}
+
+func (c *swaggerConverter) convertParameterList(parameters []*yaml.Node) *yaml.Node {
+	result := newSeq()
+	for _, parameter := range parameters {
+		if ref := mapGet(parameter, "$ref"); ref != nil {
+			refNode := newMap()
+			mapSet(refNode, "$ref", newString(convertReference(ref.Value, "")))
+			result.Content = append(result.Content, refNode)
+			continue
+		}
+		result.Content = append(result.Content, convertParameter(parameter))
+	}
+	return result
+}
+
+// convertParameter converts a query, header, path or cookie parameter, the type related keys move into the schema
+func convertParameter(parameter *yaml.Node) *yaml.Node {
+	result := newMap()
+	in := ""
+	if inNode := mapGet(parameter, "in"); inNode != nil {
+		in = inNode.Value
+	}
+
+	schemaWritten := false
+	for i := 0; i+1 < len(parameter.Content); i += 2 {
+		key, value := parameter.Content[i], parameter.Content[i+1]
+		switch {
+		case slices.Contains(swaggerParameterSchemaKeys, key.Value):
+			if !schemaWritten {
+				schemaWritten = true
+				mapSet(result, "schema", convertItemsToSchema(parameter))
+			}
+		case key.Value == "collectionFormat":
+			convertCollectionFormat(value.Value, in, result)
+		default:
+			result.Content = append(result.Content, key, value)
+		}
+	}
+
+	return result
+}
+
+// convertCollectionFormat maps the swagger 2.0 collectionFormat to the style and explode parameter fields
+func convertCollectionFormat(collectionFormat string, in string, parameter *yaml.Node) {
+	switch collectionFormat {
+	case "csv":
+		if in == "query" || in == "cookie" {
+			mapSet(parameter, "style", newString("form"))
+			mapSet(parameter, "explode", newBool(false))
+		} else {
+			mapSet(parameter, "style", newString("simple"))
+		}
+	case "ssv":
+		mapSet(parameter, "style", newString("spaceDelimited"))
+		mapSet(parameter, "explode", newBool(false))
+	case "pipes":
+		mapSet(parameter, "style", newString("pipeDelimited"))
+		mapSet(parameter, "explode", newBool(false))
+	case "multi":
+		mapSet(parameter, "style", newString("form"))
+		mapSet(parameter, "explode", newBool(true))
+	default:
+		slog.Warn("Collection format has no OpenAPI 3.0 equivalent, ignoring it", "collectionFormat", collectionFormat)
+	}
+}
+
+// convertItemsToSchema converts a non-body parameter, header or items object into a schema
+func convertItemsToSchema(items *yaml.Node) *yaml.Node {
+	schema := newMap()
+	for i := 0; i+1 < len(items.Content); i += 2 {
+		key, value := items.Content[i], items.Content[i+1]
+		if !slices.Contains(swaggerParameterSchemaKeys, key.Value) {
+			continue
+		}
+		if key.Value == "items" && value.Kind == yaml.MappingNode {
+			value = convertItemsToSchema(value)
+		}
+		schema.Content = append(schema.Content, key, value)
+	}
+	if t := mapGet(schema, "type"); t != nil && t.Value == "file" {
+		mapSet(schema, "type", newString("string"))
+		mapSet(schema, "format", newString("binary"))
+	}
+	return schema
+}
+
+// convertRequestBody merges body and formData parameters into a request body
+func (c *swaggerConverter) convertRequestBody(parameters []*yaml.Node, consumes []string) *yaml.Node {
+	var formParameters []*yaml.Node
+	for _, parameter := range parameters {
+		resolved := c.resolveParameter(parameter)
+		if in := mapGet(resolved, "in"); in != nil && in.Value == "body" {
+			if ref := mapGet(parameter, "$ref"); ref != nil {
+				requestBody := newMap()
+				mapSet(requestBody, "$ref", newString(convertReference(ref.Value, "body")))
+				return requestBody
+			}
+			return convertBodyParameter(resolved, consumes)
+		}
+		formParameters = append(formParameters, resolved)
+	}
+	if len(formParameters) == 0 {
+		return nil
+	}
+
+	return convertFormParameters(formParameters, consumes)
+}
+
+func convertBodyParameter(parameter *yaml.Node, consumes []string) *yaml.Node {
+	if len(consumes) == 0 {
+		consumes = []string{mediaTypeJSON}
+	}
+
+	requestBody := newMap()
+	if description := mapGet(parameter, "description"); description != nil {
+		mapSet(requestBody, "description", description)
+	}
+	content := newMap()
+	schema := convertSchema(mapGet(parameter, "schema"))
+	for _, mediaType := range consumes {
+		mediaTypeNode := newMap()
+		if schema != nil {
+			mapSet(mediaTypeNode, "schema", schema)
+		}
+		mapSet(content, mediaType, mediaTypeNode)
+	}
+	mapSet(requestBody, "content", content)
+	if required := mapGet(parameter, "required"); required != nil {
+		mapSet(requestBody, "required", required)
+	}
+	copyExtensions(parameter, requestBody)
+
+	return requestBody
+}
+
+// convertFormParameters builds an object schema with one property per formData parameter
+func convertFormParameters(parameters []*yaml.Node, consumes []string) *yaml.Node {
+	properties := newMap()
+	required := newSeq()
+	hasFile := false
+	for _, parameter := range parameters {
+		name := mapGet(parameter, "name")
+		if name == nil {
+			continue
+		}
+		property := convertItemsToSchema(parameter)
+		if t := mapGet(parameter, "type"); t != nil && t.Value == "file" {
+			hasFile = true
+		}
+		if description := mapGet(parameter, "description"); description != nil {
+			mapSet(property, "description", description)
+		}
+		copyExtensions(parameter, property)
+		mapSet(properties, name.Value, property)
+		if r := mapGet(parameter, "required"); r != nil && r.Value == "true" {
+			required.Content = append(required.Content, newString(name.Value))
+		}
+	}
+
+	schema := newMap()
+	mapSet(schema, "type", newString("object"))
+	mapSet(schema, "properties", properties)
+	if len(required.Content) > 0 {
+		mapSet(schema, "required", required)
+	}
+
+	var mediaTypes []string
+	for _, mediaType := range consumes {
+		if mediaType == mediaTypeFormURLEncoded || mediaType == mediaTypeMultipart {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+	}
+	if len(mediaTypes) == 0 {
+		mediaTypes = []string{mediaTypeFormURLEncoded}
+		if hasFile {
+			mediaTypes = []string{mediaTypeMultipart}
+		}
+	}
+
+	content := newMap()
+	for _, mediaType := range mediaTypes {
+		mediaTypeNode := newMap()
+		mapSet(mediaTypeNode, "schema", schema)
+		mapSet(content, mediaType, mediaTypeNode)
+	}
+	requestBody := newMap()
+	mapSet(requestBody, "content", content)
+	if len(required.Content) > 0 {
+		mapSet(requestBody, "required", newBool(true))
+	}
+
+	return requestBody
+}
+
+func (c *swaggerConverter) convertResponses(responses *yaml.Node, produces []string) *yaml.Node {
+	result := newMap()
+	for i := 0; i+1 < len(responses.Content); i += 2 {
+		key, value := responses.Content[i], responses.Content[i+1]
+		if strings.HasPrefix(key.Value, "x-") {
+			result.Content = append(result.Content, key, value)
+			continue
+		}
+		result.Content = append(result.Content, key, convertResponse(value, produces))
+	}
+	return result
+}
+
+func convertResponse(response *yaml.Node, produces []string) *yaml.Node {
+	if ref := mapGet(response, "$ref"); ref != nil {
+		result := newMap()
+		mapSet(result, "$ref", newString(convertReference(ref.Value, "")))
+		return result
+	}
+	if len(produces) == 0 {
+		produces = []string{mediaTypeJSON}
+	}
+
+	result := newMap()
+	description := mapGet(response, "description")
+	if description == nil {
+		description = newString("")
+	}
+	mapSet(result, "description", description)
+
+	if headers := mapGet(response, "headers"); headers != nil {
+		convertedHeaders := newMap()
+		for i := 0; i+1 < len(headers.Content); i += 2 {
+			mapSet(convertedHeaders, headers.Content[i].Value, convertHeader(headers.Content[i+1]))
+		}
+		mapSet(result, "headers", convertedHeaders)
+	}
+
+	schema := convertSchema(mapGet(response, "schema"))
+	examples := mapGet(response, "examples")
+	if schema != nil || examples != nil {
+		mediaTypes := slices.Clone(produces)
+		if examples != nil {
+			for i := 0; i+1 < len(examples.Content); i += 2 {
+				if !slices.Contains(mediaTypes, examples.Content[i].Value) {
+					mediaTypes = append(mediaTypes, examples.Content[i].Value)
+				}
+			}
+		}
+
+		content := newMap()
+		for _, mediaType := range mediaTypes {
+			mediaTypeNode := newMap()
+			if schema != nil {
+				mapSet(mediaTypeNode, "schema", schema)
+			}
+			if example := mapGet(examples, mediaType); example != nil {
+				mapSet(mediaTypeNode, "example", example)
+			}
+			mapSet(content, mediaType, mediaTypeNode)
+		}
+		mapSet(result, "content", content)
+	}
+	copyExtensions(response, result)
+
+	return result
+}
+
+func convertHeader(header *yaml.Node) *yaml.Node {
+	result := newMap()
+	if description := mapGet(header, "description"); description != nil {
+		mapSet(result, "description", description)
+	}
+	if collectionFormat := mapGet(header, "collectionFormat"); collectionFormat != nil {
+		convertCollectionFormat(collectionFormat.Value, "header", result)
+	}
+	mapSet(result, "schema", convertItemsToSchema(header))
+	copyExtensions(header, result)
+	return result
+}
+
+func (c *swaggerConverter) convertComponents(doc *yaml.Node) *yaml.Node {
+	components := newMap()
+
+	if definitions := mapGet(doc, "definitions"); definitions != nil {
+		schemas := newMap()
+		for i := 0; i+1 < len(definitions.Content); i += 2 {
+			mapSet(schemas, definitions.Content[i].Value, convertSchema(definitions.Content[i+1]))
+		}
+		mapSet(components, "schemas", schemas)
+	}
+
+	if responses := mapGet(doc, "responses"); responses != nil {
+		mapSet(components, "responses", c.convertResponses(responses, c.produces))
+	}
+
+	if c.parameters != nil {
+		parameters := newMap()
+		requestBodies := newMap()
+		for i := 0; i+1 < len(c.parameters.Content); i += 2 {
+			name, parameter := c.parameters.Content[i].Value, c.parameters.Content[i+1]
+			in := mapGet(parameter, "in")
+			switch {
+			case in != nil && in.Value == "body":
+				mapSet(requestBodies, name, convertBodyParameter(parameter, c.consumes))
+			case in != nil && in.Value == "formData":
+				// formData parameters are inlined into the request body of each operation
+				continue
+			default:
+				mapSet(parameters, name, convertParameter(parameter))
+			}
+		}
+		if len(parameters.Content) > 0 {
+			mapSet(components, "parameters", parameters)
+		}
+		if len(requestBodies.Content) > 0 {
+			mapSet(components, "requestBodies", requestBodies)
+		}
+	}
+
+	if securityDefinitions := mapGet(doc, "securityDefinitions"); securityDefinitions != nil {
+		securitySchemes := newMap()
+		for i := 0; i+1 < len(securityDefinitions.Content); i += 2 {
+			mapSet(securitySchemes, securityDefinitions.Content[i].Value, convertSecurityScheme(securityDefinitions.Content[i+1]))
+		}
+		mapSet(components, "securitySchemes", securitySchemes)
+	}
+
+	return components
+}
+
+// convertSecurityScheme converts a swagger 2.0 security definition, basic auth becomes http and the oauth2 flow moves into flows
+func convertSecurityScheme(definition *yaml.Node) *yaml.Node {
+	t := mapGet(definition, "type")
+	if t == nil {
+		return definition
+	}
+
+	result := newMap()
+	switch t.Value {
+	case "basic":
+		mapSet(result, "type", newString("http"))
+		mapSet(result, "scheme", newString("basic"))
+	case "apiKey":
+		mapSet(result, "type", newString("apiKey"))
+		if name := mapGet(definition, "name"); name != nil {
+			mapSet(result, "name", name)
+		}
+		if in := mapGet(definition, "in"); in != nil {
+			mapSet(result, "in", in)
+		}
+	case "oauth2":
+		mapSet(result, "type", newString("oauth2"))
+		flowName := ""
+		if flow := mapGet(definition, "flow"); flow != nil {
+			flowName = flow.Value
+		}
+		flow := newMap()
+		if flowName == "implicit" || flowName == "accessCode" {
+			if authorizationURL := mapGet(definition, "authorizationUrl"); authorizationURL != nil {
+				mapSet(flow, "authorizationUrl", authorizationURL)
+			}
+		}
+		if flowName == "password" || flowName == "application" || flowName == "accessCode" {
+			if tokenURL := mapGet(definition, "tokenUrl"); tokenURL != nil {
+				mapSet(flow, "tokenUrl", tokenURL)
+			}
+		}
+		scopes := mapGet(definition, "scopes")
+		if scopes == nil {
+			scopes = newMap()
+		}
+		mapSet(flow, "scopes", scopes)
+
+		flows := newMap()
+		switch flowName {
+		case "implicit":
+			mapSet(flows, "implicit", flow)
+		case "password":
+			mapSet(flows, "password", flow)
+		case "application":
+			mapSet(flows, "clientCredentials", flow)
+		case "accessCode":
+			mapSet(flows, "authorizationCode", flow)
+		default:
+			slog.Warn("Unknown oauth2 flow, skipping it", "flow", flowName)
+		}
+		mapSet(result, "flows", flows)
+	default:
+		return definition
+	}
+	if description := mapGet(definition, "description"); description != nil {
+		mapSet(result, "description", description)
+	}
+	copyExtensions(definition, result)
+
+	return result
+}
+
+// convertSchema rewrites the swagger 2.0 specifics of a schema (references, x-nullable, discriminator, file type) in place
+func convertSchema(schema *yaml.Node) *yaml.Node {
+	if schema == nil || schema.Kind != yaml.MappingNode {
+		return schema
+	}
+
+	for i := 0; i+1 < len(schema.Content); i += 2 {
+		key, value := schema.Content[i], schema.Content[i+1]
+		switch key.Value {
+		case "$ref":
+			schema.Content[i+1] = newString(convertReference(value.Value, ""))
+		case "x-nullable":
+			key.Value = "nullable"
+		case "type":
+			if value.Value == "file" {
+				value.Value = "string"
+				mapSet(schema, "format", newString("binary"))
+			}
+		case "discriminator":
+			if value.Kind == yaml.ScalarNode {
+				discriminator := newMap()
+				mapSet(discriminator, "propertyName", value)
+				schema.Content[i+1] = discriminator
+			}
+		case "properties":
+			for j := 1; j < len(value.Content); j += 2 {
+				convertSchema(value.Content[j])
+			}
+		case "items", "additionalProperties", "not":
+			if value.Kind == yaml.SequenceNode {
+				for _, item := range value.Content {
+					convertSchema(item)
+				}
+			} else {
+				convertSchema(value)
+			}
+		case "allOf", "anyOf", "oneOf":
+			for _, item := range value.Content {
+				convertSchema(item)
+			}
+		}
+	}
+
+	return schema
+}
+
+// convertReference rewrites a local swagger 2.0 reference into the matching components reference, kind "body" marks request body references
+func convertReference(ref string, kind string) string {
+	switch {
+	case strings.HasPrefix(ref, "#/definitions/"):
+		return "#/components/schemas/" + strings.TrimPrefix(ref, "#/definitions/")
+	case strings.HasPrefix(ref, "#/parameters/") && kind == "body":
+		return "#/components/requestBodies/" + strings.TrimPrefix(ref, "#/parameters/")
+	case strings.HasPrefix(ref, "#/parameters/"):
+		return "#/components/parameters/" + strings.TrimPrefix(ref, "#/parameters/")
+	case strings.HasPrefix(ref, "#/responses/"):
+		return "#/components/responses/" + strings.TrimPrefix(ref, "#/responses/")
+	}
+	return ref
+}
+
+// copyExtensions copies all x- extensions from source to target
+func copyExtensions(source *yaml.Node, target *yaml.Node) {
+	for i := 0; i+1 < len(source.Content); i += 2 {
+		if strings.HasPrefix(source.Content[i].Value, "x-") && source.Content[i].Value != "x-nullable" {
+			mapSet(target, source.Content[i].Value, source.Content[i+1])
+		}
+	}
+}
+
+// resetNodeStyle removes the json flow and quoting styles, so the document is rendered as regular yaml
+func resetNodeStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetNodeStyle(child)
+	}
+}
+
+func mapGet(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func mapSet(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, newString(key), value)
+}
+
+func scalarValues(node *yaml.Node) []string {
+	if node == nil {
+		return nil
+	}
+	var values []string
+	for _, item := range node.Content {
+		values = append(values, item.Value)
+	}
+	return values
+}
+
+func newMap() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+func newSeq() *yaml.Node {
+	return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+}
+
+func newString(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+func newBool(value bool) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprintf("%t", value)}
+}
@@ -0,0 +1,176 @@
+package openapiconvert
+
+import (
+	"testing"
+
+	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+const swaggerTestSpec = `{
+  "swagger": "2.0",
+  "info": {"title": "Petstore", "version": "1.0.0"},
+  "host": "petstore.example.com",
+  "basePath": "/v1",
+  "schemes": ["https"],
+  "consumes": ["application/json"],
+  "produces": ["application/json"],
+  "x-api-id": "petstore",
+  "paths": {
+    "/pets": {
+      "get": {
+        "operationId": "listPets",
+        "parameters": [
+          {"$ref": "#/parameters/limit"},
+          {"name": "tags", "in": "query", "type": "array", "items": {"type": "string"}, "collectionFormat": "multi"}
+        ],
+        "responses": {
+          "200": {
+            "description": "pets",
+            "headers": {"X-Total": {"type": "integer", "description": "total count"}},
+            "schema": {"type": "array", "items": {"$ref": "#/definitions/Pet"}}
+          },
+          "default": {"$ref": "#/responses/Error"}
+        }
+      },
+      "post": {
+        "operationId": "createPet",
+        "x-audit": true,
+        "parameters": [
+          {"name": "pet", "in": "body", "required": true, "schema": {"$ref": "#/definitions/Pet"}}
+        ],
+        "responses": {"201": {"description": "created"}}
+      }
+    },
+    "/pets/{petId}/photo": {
+      "parameters": [{"name": "petId", "in": "path", "required": true, "type": "string"}],
+      "put": {
+        "operationId": "uploadPhoto",
+        "consumes": ["multipart/form-data"],
+        "parameters": [
+          {"name": "file", "in": "formData", "required": true, "type": "file"},
+          {"name": "caption", "in": "formData", "type": "string", "description": "photo caption"}
+        ],
+        "responses": {"204": {"description": "uploaded"}}
+      }
+    }
+  },
+  "parameters": {
+    "limit": {"name": "limit", "in": "query", "type": "integer", "format": "int32", "maximum": 100}
+  },
+  "responses": {
+    "Error": {"description": "error", "schema": {"$ref": "#/definitions/Error"}}
+  },
+  "definitions": {
+    "Pet": {
+      "type": "object",
+      "discriminator": "kind",
+      "required": ["name", "kind"],
+      "properties": {
+        "name": {"type": "string", "x-nullable": true},
+        "kind": {"type": "string"},
+        "owner": {"$ref": "#/definitions/Owner"}
+      }
+    },
+    "Owner": {"type": "object", "properties": {"name": {"type": "string"}}},
+    "Error": {"type": "object", "properties": {"message": {"type": "string"}}}
+  },
+  "securityDefinitions": {
+    "basic": {"type": "basic"},
+    "key": {"type": "apiKey", "name": "X-API-Key", "in": "header"},
+    "oauth": {"type": "oauth2", "flow": "accessCode", "authorizationUrl": "https://example.com/authorize", "tokenUrl": "https://example.com/token", "scopes": {"read": "read access"}}
+  },
+  "security": [{"key": []}]
+}`
+
+func TestConvertSwaggerToOpenAPINative(t *testing.T) {
+	// act
+	result, err := ConvertSwaggerToOpenAPINative([]byte(swaggerTestSpec))
+
+	// assert
+	require.NoError(t, err)
+	var doc map[string]any
+	require.NoError(t, yaml.Unmarshal(result, &doc))
+
+	assert.Equal(t, "3.0.3", doc["openapi"])
+	assert.Equal(t, []any{map[string]any{"url": "https://petstore.example.com/v1"}}, doc["servers"])
+	assert.Equal(t, "petstore", doc["x-api-id"])
+	assert.Equal(t, []any{map[string]any{"key": []any{}}}, doc["security"])
+
+	paths := doc["paths"].(map[string]any)
+	listPets := paths["/pets"].(map[string]any)["get"].(map[string]any)
+	assert.Equal(t, []any{
+		map[string]any{"$ref": "#/components/parameters/limit"},
+		map[string]any{"name": "tags", "in": "query", "schema": map[string]any{"type": "array", "items": map[string]any{"type": "string"}}, "style": "form", "explode": true},
+	}, listPets["parameters"])
+	listResponses := listPets["responses"].(map[string]any)
+	assert.Equal(t, map[string]any{
+		"description": "pets",
+		"headers":     map[string]any{"X-Total": map[string]any{"description": "total count", "schema": map[string]any{"type": "integer"}}},
+		"content": map[string]any{"application/json": map[string]any{
+			"schema": map[string]any{"type": "array", "items": map[string]any{"$ref": "#/components/schemas/Pet"}},
+		}},
+	}, listResponses["200"])
+	assert.Equal(t, map[string]any{"$ref": "#/components/responses/Error"}, listResponses["default"])
+
+	createPet := paths["/pets"].(map[string]any)["post"].(map[string]any)
+	assert.Equal(t, true, createPet["x-audit"])
+	assert.NotContains(t, createPet, "parameters")
+	assert.Equal(t, map[string]any{
+		"required": true,
+		"content":  map[string]any{"application/json": map[string]any{"schema": map[string]any{"$ref": "#/components/schemas/Pet"}}},
+	}, createPet["requestBody"])
+
+	photo := paths["/pets/{petId}/photo"].(map[string]any)
+	assert.Equal(t, []any{map[string]any{"name": "petId", "in": "path", "required": true, "schema": map[string]any{"type": "string"}}}, photo["parameters"])
+	assert.Equal(t, map[string]any{
+		"required": true,
+		"content": map[string]any{"multipart/form-data": map[string]any{"schema": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"file":    map[string]any{"type": "string", "format": "binary"},
+				"caption": map[string]any{"type": "string", "description": "photo caption"},
+			},
+			"required": []any{"file"},
+		}}},
+	}, photo["put"].(map[string]any)["requestBody"])
+
+	components := doc["components"].(map[string]any)
+	pet := components["schemas"].(map[string]any)["Pet"].(map[string]any)
+	assert.Equal(t, map[string]any{"propertyName": "kind"}, pet["discriminator"])
+	assert.Equal(t, map[string]any{"type": "string", "nullable": true}, pet["properties"].(map[string]any)["name"])
+	assert.Equal(t, map[string]any{"$ref": "#/components/schemas/Owner"}, pet["properties"].(map[string]any)["owner"])
+	assert.Equal(t, map[string]any{"name": "limit", "in": "query", "schema": map[string]any{"type": "integer", "format": "int32", "maximum": 100}}, components["parameters"].(map[string]any)["limit"])
+	assert.Equal(t, map[string]any{
+		"basic": map[string]any{"type": "http", "scheme": "basic"},
+		"key":   map[string]any{"type": "apiKey", "name": "X-API-Key", "in": "header"},
+		"oauth": map[string]any{"type": "oauth2", "flows": map[string]any{"authorizationCode": map[string]any{
+			"authorizationUrl": "https://example.com/authorize",
+			"tokenUrl":         "https://example.com/token",
+			"scopes":           map[string]any{"read": "read access"},
+		}}},
+	}, components["securitySchemes"])
+
+	// the result must be a valid openapi 3 document
+	v3doc := openapidocument.OpenV3DocumentForTest(result)
+	assert.Equal(t, 2, v3doc.Model.Paths.PathItems.Len())
+}
+
+func TestConvertSwaggerToOpenAPINativeInvalidVersion(t *testing.T) {
+	// act
+	_, err := ConvertSwaggerToOpenAPINative([]byte(`openapi: 3.0.3`))
+
+	// assert
+	assert.ErrorIs(t, err, ErrInvalidSwaggerDocument)
+}
+
+func TestResolveSwaggerConverter(t *testing.T) {
+	t.Setenv(converterEndpointEnvVar, "")
+	assert.Equal(t, ConverterNative, resolveSwaggerConverter(""))
+	assert.Equal(t, ConverterSpeakeasy, resolveSwaggerConverter(ConverterSpeakeasy))
+
+	t.Setenv(converterEndpointEnvVar, "http://localhost:8080/api/convert")
+	assert.Equal(t, ConverterSwagger, resolveSwaggerConverter(""))
+}
@@ -0,0 +1,280 @@
+package openapiconvert
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/cidverse/go-ptr"
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
+	"go.yaml.in/yaml/v4"
+)
+
+const (
+	openAPI30Version = "3.0.3"
+	openAPI31Version = "3.1.0"
+)
+
+// ConvertOpenAPI31To30 downgrades an OpenAPI 3.1 document to OpenAPI 3.0, format is the output format (yaml or json)
+func ConvertOpenAPI31To30(data []byte, format string) ([]byte, error) {
+	doc, err := openVersionedDocument(data, "3.1")
+	if err != nil {
+		return nil, err
+	}
+
+	// 3.1 only document fields
+	// the renderer falls back to the low-level value for empty high-level fields, so both have to be cleared
+	doc.Model.Version = openAPI30Version
+	doc.Model.JsonSchemaDialect = ""
+	if lowDoc := doc.Model.GoLow(); lowDoc != nil {
+		lowDoc.JsonSchemaDialect = low.NodeReference[string]{}
+	}
+	if doc.Model.Webhooks != nil && doc.Model.Webhooks.Len() > 0 {
+		slog.Warn("Webhooks are not supported in OpenAPI 3.0, removing them", "count", doc.Model.Webhooks.Len())
+		doc.Model.Webhooks = nil
+	}
+	if doc.Model.Components != nil && doc.Model.Components.PathItems != nil && doc.Model.Components.PathItems.Len() > 0 {
+		slog.Warn("Component path items are not supported in OpenAPI 3.0, removing them", "count", doc.Model.Components.PathItems.Len())
+		doc.Model.Components.PathItems = nil
+	}
+	if doc.Model.Info != nil {
+		doc.Model.Info.Summary = ""
+		if lowInfo := doc.Model.Info.GoLow(); lowInfo != nil {
+			lowInfo.Summary = low.NodeReference[string]{}
+		}
+		if doc.Model.Info.License != nil {
+			doc.Model.Info.License.Identifier = ""
+			if lowLicense := doc.Model.Info.License.GoLow(); lowLicense != nil {
+				lowLicense.Identifier = low.NodeReference[string]{}
+			}
+		}
+	}
+
+	// schemas
+	visitVersionSchemas(doc, func(name string, schema *base.SchemaProxy) *base.SchemaProxy {
+		if schema.IsReference() {
+			return downgradeReferenceSiblings(schema)
+		}
+		if s := schema.Schema(); s != nil {
+			downgradeSchema(s)
+		}
+		return schema
+	})
+
+	return openapidocument.RenderV3ModelFormat(doc, format)
+}
+
+// ConvertOpenAPI30To31 upgrades an OpenAPI 3.0 document to OpenAPI 3.1, format is the output format (yaml or json)
+func ConvertOpenAPI30To31(data []byte, format string) ([]byte, error) {
+	doc, err := openVersionedDocument(data, "3.0")
+	if err != nil {
+		return nil, err
+	}
+
+	doc.Model.Version = openAPI31Version
+	visitVersionSchemas(doc, func(name string, schema *base.SchemaProxy) *base.SchemaProxy {
+		if schema.IsReference() {
+			return schema
+		}
+		if s := schema.Schema(); s != nil {
+			upgradeSchema(s)
+		}
+		return schema
+	})
+
+	return openapidocument.RenderV3ModelFormat(doc, format)
+}
+
+// openVersionedDocument opens the document and ensures the openapi version matches the expected major.minor version
+func openVersionedDocument(data []byte, version string) (*libopenapi.DocumentModel[v3.Document], error) {
+	document, err := openapidocument.OpenDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(document.GetVersion(), version+".") {
+		return nil, errors.Join(ErrInvalidInputFormat, fmt.Errorf("expected an openapi %s document, got version %s", version, document.GetVersion()))
+	}
+
+	doc, err := document.BuildV3Model()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build v3 high level model: %w", err)
+	}
+
+	return doc, nil
+}
+
+// visitVersionSchemas visits all schemas in the document, including path, operation and response header schemas that are not covered by openapidocument.VisitAllSchemas
+func visitVersionSchemas(doc *libopenapi.DocumentModel[v3.Document], visitor func(name string, schema *base.SchemaProxy) *base.SchemaProxy) {
+	openapidocument.VisitAllSchemas(doc, visitor)
+
+	if doc.Model.Paths == nil || doc.Model.Paths.PathItems == nil {
+		return
+	}
+	for path := doc.Model.Paths.PathItems.Oldest(); path != nil; path = path.Next() {
+		if path.Value == nil {
+			continue
+		}
+		visitParameterSchemas(path.Value.Parameters, visitor)
+
+		for op := path.Value.GetOperations().Oldest(); op != nil; op = op.Next() {
+			if op.Value == nil {
+				continue
+			}
+			visitParameterSchemas(op.Value.Parameters, visitor)
+
+			if op.Value.Responses == nil || op.Value.Responses.Codes == nil {
+				continue
+			}
+			for response := op.Value.Responses.Codes.Oldest(); response != nil; response = response.Next() {
+				if response.Value == nil || response.Value.Headers == nil {
+					continue
+				}
+				for header := response.Value.Headers.Oldest(); header != nil; header = header.Next() {
+					if header.Value != nil && header.Value.Schema != nil {
+						header.Value.Schema = openapidocument.VisitSchema(header.Key, header.Value.Schema, visitor)
+					}
+				}
+			}
+		}
+	}
+}
+
+func visitParameterSchemas(parameters []*v3.Parameter, visitor func(name string, schema *base.SchemaProxy) *base.SchemaProxy) {
+	for _, parameter := range parameters {
+		if parameter != nil && parameter.Schema != nil {
+			parameter.Schema = openapidocument.VisitSchema(parameter.Name, parameter.Schema, visitor)
+		}
+	}
+}
+
+// downgradeSchema rewrites the JSON Schema 2020-12 keywords of a single schema into their OpenAPI 3.0 equivalents
+func downgradeSchema(s *base.Schema) {
+	// type: [string, "null"] -> type: string, nullable: true
+	if slices.Contains(s.Type, "null") {
+		s.Nullable = ptr.Ptr(true)
+		s.Type = slices.DeleteFunc(slices.Clone(s.Type), func(t string) bool { return t == "null" })
+		if len(s.Type) == 0 {
+			clearSchemaType(s)
+		}
+	}
+	if len(s.Type) > 1 {
+		if len(s.OneOf) == 0 {
+			for _, t := range s.Type {
+				s.OneOf = append(s.OneOf, base.CreateSchemaProxy(&base.Schema{Type: []string{t}}))
+			}
+			clearSchemaType(s)
+		} else {
+			slog.Warn("Schema with multiple types and oneOf can not be represented in OpenAPI 3.0, keeping the first type", "types", s.Type)
+			s.Type = s.Type[:1]
+		}
+	}
+
+	// const: x -> enum: [x]
+	if s.Const != nil {
+		if len(s.Enum) == 0 {
+			s.Enum = []*yaml.Node{s.Const}
+		}
+		s.Const = nil
+	}
+
+	// examples: [x, y] -> example: x
+	if len(s.Examples) > 0 {
+		if s.Example == nil {
+			s.Example = s.Examples[0]
+		}
+		s.Examples = nil
+	}
+
+	// exclusiveMinimum: 5 -> minimum: 5, exclusiveMinimum: true
+	if s.ExclusiveMinimum != nil && s.ExclusiveMinimum.IsB() {
+		s.Minimum = ptr.Ptr(s.ExclusiveMinimum.B)
+		s.ExclusiveMinimum = &base.DynamicValue[bool, float64]{N: 0, A: true}
+	}
+	if s.ExclusiveMaximum != nil && s.ExclusiveMaximum.IsB() {
+		s.Maximum = ptr.Ptr(s.ExclusiveMaximum.B)
+		s.ExclusiveMaximum = &base.DynamicValue[bool, float64]{N: 0, A: true}
+	}
+}
+
+// clearSchemaType removes the type of a schema, the renderer would otherwise fall back to the low-level type
+func clearSchemaType(s *base.Schema) {
+	s.Type = nil
+	if lowSchema := s.GoLow(); lowSchema != nil {
+		lowSchema.Type = low.NodeReference[lowbase.SchemaDynamicValue[string, []low.ValueReference[string]]]{}
+	}
+}
+
+// downgradeReferenceSiblings wraps a $ref with sibling keywords into an allOf, OpenAPI 3.0 ignores all siblings of a $ref
+func downgradeReferenceSiblings(schema *base.SchemaProxy) *base.SchemaProxy {
+	lowProxy := schema.GoLow()
+	if lowProxy == nil {
+		return schema
+	}
+	refNode := schema.GetReferenceNode()
+	if refNode == nil || refNode.Kind != yaml.MappingNode || len(refNode.Content) <= 2 {
+		return schema
+	}
+
+	siblings := &yaml.Node{Kind: yaml.MappingNode}
+	for i := 0; i+1 < len(refNode.Content); i += 2 {
+		if refNode.Content[i].Value != "$ref" {
+			siblings.Content = append(siblings.Content, refNode.Content[i], refNode.Content[i+1])
+		}
+	}
+
+	lowSchema := new(lowbase.Schema)
+	if err := lowSchema.Build(lowProxy.GetContext(), siblings, lowProxy.GetIndex()); err != nil {
+		slog.Warn("Failed to build $ref sibling schema, dropping siblings", "ref", schema.GetReference(), "err", err)
+		return base.CreateSchemaProxyRef(schema.GetReference())
+	}
+
+	s := base.NewSchema(lowSchema)
+	downgradeSchema(s)
+	s.AllOf = append([]*base.SchemaProxy{base.CreateSchemaProxyRef(schema.GetReference())}, s.AllOf...)
+	return base.CreateSchemaProxy(s)
+}
+
+// upgradeSchema rewrites the OpenAPI 3.0 specific keywords of a single schema into their JSON Schema 2020-12 equivalents
+func upgradeSchema(s *base.Schema) {
+	// type: string, nullable: true -> type: [string, "null"]
+	// nullable without a type (e.g. next to allOf) has no 3.1 equivalent and is kept as-is
+	if s.Nullable != nil && len(s.Type) > 0 {
+		if *s.Nullable && !slices.Contains(s.Type, "null") {
+			s.Type = append(slices.Clone(s.Type), "null")
+		}
+		s.Nullable = nil
+	}
+
+	// example: x -> examples: [x]
+	if s.Example != nil {
+		if len(s.Examples) == 0 {
+			s.Examples = []*yaml.Node{s.Example}
+		}
+		s.Example = nil
+	}
+
+	// minimum: 5, exclusiveMinimum: true -> exclusiveMinimum: 5
+	if s.ExclusiveMinimum != nil && s.ExclusiveMinimum.IsA() {
+		if s.ExclusiveMinimum.A && s.Minimum != nil {
+			s.ExclusiveMinimum = &base.DynamicValue[bool, float64]{N: 1, B: *s.Minimum}
+			s.Minimum = nil
+		} else {
+			s.ExclusiveMinimum = nil
+		}
+	}
+	if s.ExclusiveMaximum != nil && s.ExclusiveMaximum.IsA() {
+		if s.ExclusiveMaximum.A && s.Maximum != nil {
+			s.ExclusiveMaximum = &base.DynamicValue[bool, float64]{N: 1, B: *s.Maximum}
+			s.Maximum = nil
+		} else {
+			s.ExclusiveMaximum = nil
+		}
+	}
+}
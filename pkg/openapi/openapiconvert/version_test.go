@@ -0,0 +1,173 @@
+package openapiconvert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func TestConvertOpenAPI31To30(t *testing.T) {
+	// arrange
+	spec := []byte(`openapi: 3.1.0
+info:
+  title: Test
+  version: "1.0"
+jsonSchemaDialect: https://spec.openapis.org/oas/3.1/dialect/base
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: [integer, "null"]
+            exclusiveMinimum: 0
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+webhooks:
+  newPet:
+    post:
+      responses:
+        "200":
+          description: ok
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        kind:
+          const: dog
+        name:
+          type: [string, "null"]
+          examples: [Rex, Bello]
+        owner:
+          $ref: '#/components/schemas/Owner'
+          description: the owner of the pet
+    Owner:
+      type: object
+`)
+
+	// act
+	result, err := ConvertOpenAPI31To30(spec, "yaml")
+
+	// assert
+	require.NoError(t, err)
+	var doc map[string]any
+	require.NoError(t, yaml.Unmarshal(result, &doc))
+	assert.Equal(t, "3.0.3", doc["openapi"])
+	assert.NotContains(t, doc, "webhooks")
+	assert.NotContains(t, doc, "jsonSchemaDialect")
+
+	properties := doc["components"].(map[string]any)["schemas"].(map[string]any)["Pet"].(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"enum": []any{"dog"}}, properties["kind"])
+	assert.Equal(t, map[string]any{"type": "string", "nullable": true, "example": "Rex"}, properties["name"])
+	assert.Equal(t, map[string]any{
+		"allOf":       []any{map[string]any{"$ref": "#/components/schemas/Owner"}},
+		"description": "the owner of the pet",
+	}, properties["owner"])
+
+	parameter := doc["paths"].(map[string]any)["/pets"].(map[string]any)["get"].(map[string]any)["parameters"].([]any)[0].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "integer", "nullable": true, "minimum": 0, "exclusiveMinimum": true}, parameter["schema"])
+}
+
+func TestConvertOpenAPI31To30MultipleTypes(t *testing.T) {
+	// arrange
+	spec := []byte(`openapi: 3.1.0
+info:
+  title: Test
+  version: "1.0"
+paths: {}
+components:
+  schemas:
+    Value:
+      type: [string, integer]
+`)
+
+	// act
+	result, err := ConvertOpenAPI31To30(spec, "yaml")
+
+	// assert
+	require.NoError(t, err)
+	var doc map[string]any
+	require.NoError(t, yaml.Unmarshal(result, &doc))
+	value := doc["components"].(map[string]any)["schemas"].(map[string]any)["Value"]
+	assert.Equal(t, map[string]any{"oneOf": []any{
+		map[string]any{"type": "string"},
+		map[string]any{"type": "integer"},
+	}}, value)
+}
+
+func TestConvertOpenAPI30To31(t *testing.T) {
+	// arrange
+	spec := []byte(`openapi: 3.0.3
+info:
+  title: Test
+  version: "1.0"
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            minimum: 0
+            exclusiveMinimum: true
+      responses:
+        "200":
+          description: ok
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+          nullable: true
+          example: Rex
+`)
+
+	// act
+	result, err := ConvertOpenAPI30To31(spec, "yaml")
+
+	// assert
+	require.NoError(t, err)
+	var doc map[string]any
+	require.NoError(t, yaml.Unmarshal(result, &doc))
+	assert.Equal(t, "3.1.0", doc["openapi"])
+
+	properties := doc["components"].(map[string]any)["schemas"].(map[string]any)["Pet"].(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": []any{"string", "null"}, "examples": []any{"Rex"}}, properties["name"])
+
+	parameter := doc["paths"].(map[string]any)["/pets"].(map[string]any)["get"].(map[string]any)["parameters"].([]any)[0].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "integer", "exclusiveMinimum": 0}, parameter["schema"])
+}
+
+func TestConvertOpenAPIVersionMismatch(t *testing.T) {
+	// arrange
+	spec := []byte(`openapi: 3.0.3
+info:
+  title: Test
+  version: "1.0"
+paths: {}
+`)
+
+	// act
+	_, err := ConvertOpenAPI31To30(spec, "yaml")
+
+	// assert
+	assert.ErrorIs(t, err, ErrInvalidInputFormat)
+}
+
+func TestNormalizeFormat(t *testing.T) {
+	assert.Equal(t, FormatSwagger20, normalizeFormat("swagger20"))
+	assert.Equal(t, FormatOpenAPI30, normalizeFormat("openapi30"))
+	assert.Equal(t, FormatOpenAPI31, normalizeFormat(FormatOpenAPI31))
+}
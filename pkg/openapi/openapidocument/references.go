@@ -2,6 +2,7 @@ package openapidocument
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/pb33f/doctor/model"
 	"github.com/pb33f/libopenapi"
@@ -63,10 +64,51 @@ func CollectSchemas(doc *libopenapi.DocumentModel[v3.Document]) map[string]*base
 func CollectOperations(doc *libopenapi.DocumentModel[v3.Document]) []*v3.Operation {
 	var operations []*v3.Operation
 
-	// requests parameters
+	for _, op := range CollectOperationRefs(doc) {
+		operations = append(operations, op.Operation)
+	}
+
+	return operations
+}
+
+// OperationRef is an operation together with its path, method and path item
+type OperationRef struct {
+	Path      string
+	Method    string
+	PathItem  *v3.PathItem
+	Operation *v3.Operation
+}
+
+// Pointer returns the location of the operation within the document
+func (o OperationRef) Pointer() string {
+	return "#/paths/" + EscapeJSONPointer(o.Path) + "/" + EscapeJSONPointer(o.Method)
+}
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// EscapeJSONPointer escapes a single JSON pointer reference token (RFC 6901)
+func EscapeJSONPointer(token string) string {
+	return jsonPointerEscaper.Replace(token)
+}
+
+// CollectOperationRefs collects all operations with their path and method from the OpenAPI document.
+func CollectOperationRefs(doc *libopenapi.DocumentModel[v3.Document]) []OperationRef {
+	var operations []OperationRef
+	if doc.Model.Paths == nil || doc.Model.Paths.PathItems == nil {
+		return operations
+	}
+
 	for path := doc.Model.Paths.PathItems.Oldest(); path != nil; path = path.Next() {
+		if path.Value == nil {
+			continue
+		}
 		for op := path.Value.GetOperations().Oldest(); op != nil; op = op.Next() {
-			operations = append(operations, op.Value)
+			operations = append(operations, OperationRef{
+				Path:      path.Key,
+				Method:    op.Key,
+				PathItem:  path.Value,
+				Operation: op.Value,
+			})
 		}
 	}
 
@@ -111,3 +153,26 @@ func CollectSchemaProxies(doc *libopenapi.DocumentModel[v3.Document]) map[string
 
 	return proxies
 }
+
+// HasDetachedSchemas checks if the document contains schemas or references that were created in-memory (e.g. by base.CreateSchemaProxyRef).
+// Those are not backed by the parsed document, references return a nil Schema() and new schemas render differently until the document is parsed again.
+func HasDetachedSchemas(doc *libopenapi.DocumentModel[v3.Document]) bool {
+	detached := false
+	visitor := func(name string, schema *base.SchemaProxy) *base.SchemaProxy {
+		if schema.GoLow() == nil {
+			detached = true
+		}
+		return schema
+	}
+
+	VisitAllSchemas(doc, visitor)
+	for _, op := range CollectOperationRefs(doc) {
+		for _, param := range op.Operation.Parameters {
+			if param != nil && param.Schema != nil {
+				VisitSchema(param.Name, param.Schema, visitor)
+			}
+		}
+	}
+
+	return detached
+}
@@ -0,0 +1,40 @@
+package openapidocument
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationRefPointer(t *testing.T) {
+	ref := OperationRef{Path: "/pets/{id}/tags~old", Method: "get"}
+
+	assert.Equal(t, "#/paths/~1pets~1{id}~1tags~0old/get", ref.Pointer())
+}
+
+func TestEscapeJSONPointer(t *testing.T) {
+	assert.Equal(t, "~01", EscapeJSONPointer("~1"))
+	assert.Equal(t, "a~1b", EscapeJSONPointer("a/b"))
+	assert.Equal(t, "plain", EscapeJSONPointer("plain"))
+}
+
+func TestHasDetachedSchemas(t *testing.T) {
+	doc := OpenV3DocumentForTest([]byte(`openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+`))
+	assert.False(t, HasDetachedSchemas(doc))
+
+	doc.Model.Components.Schemas.Set("Pets", base.CreateSchemaProxyRef("#/components/schemas/Pet"))
+	assert.True(t, HasDetachedSchemas(doc))
+}
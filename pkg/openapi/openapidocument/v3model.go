@@ -15,7 +15,7 @@ func RenderV3ModelFormat(doc *libopenapi.DocumentModel[v3.Document], format stri
 		var buf bytes.Buffer
 		yamlEncoder := yaml.NewEncoder(&buf)
 		yamlEncoder.SetIndent(2)
-		err := yamlEncoder.Encode(doc.Model)
+		err := yamlEncoder.Encode(&doc.Model)
 		if err != nil {
 			return nil, fmt.Errorf("failed to render data: %w", err)
 		}
@@ -2,10 +2,12 @@ package openapidocument
 
 import (
 	"log/slog"
+	"strconv"
 
 	"github.com/pb33f/libopenapi"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
 )
 
 func VisitAllSchemas(
@@ -98,6 +100,15 @@ func VisitAllSchemas(
 	}
 }
 
+// VisitSchema visits a single schema and all of its nested schemas, references are not followed
+func VisitSchema(
+	name string,
+	schema *base.SchemaProxy,
+	visitor func(name string, schema *base.SchemaProxy) *base.SchemaProxy,
+) *base.SchemaProxy {
+	return visitNestedSchemas(name, schema, visitor)
+}
+
 func visitNestedSchemas(
 	key string,
 	schema *base.SchemaProxy,
@@ -157,3 +168,131 @@ func visitNestedSchemas(
 
 	return schema
 }
+
+// VisitAllSchemasWithPointer visits the same schemas as VisitAllSchemas without modifying them.
+// The visitor receives the escaped json pointer (RFC 6901) of each schema, references are not followed.
+func VisitAllSchemasWithPointer(
+	doc *libopenapi.DocumentModel[v3.Document],
+	visitor func(pointer string, schema *base.SchemaProxy),
+) {
+	if doc == nil {
+		return
+	}
+
+	visitContent := func(pointer string, content *orderedmap.Map[string, *v3.MediaType]) {
+		if content == nil {
+			return
+		}
+		for contentType := content.Oldest(); contentType != nil; contentType = contentType.Next() {
+			if contentType.Value != nil && contentType.Value.Schema != nil {
+				visitNestedSchemasWithPointer(pointer+"/content/"+EscapeJSONPointer(contentType.Key)+"/schema", contentType.Value.Schema, visitor)
+			}
+		}
+	}
+
+	if doc.Model.Paths != nil && doc.Model.Paths.PathItems != nil {
+		for path := doc.Model.Paths.PathItems.Oldest(); path != nil; path = path.Next() {
+			if path.Value == nil {
+				continue
+			}
+			for op := path.Value.GetOperations().Oldest(); op != nil; op = op.Next() {
+				if op.Value == nil {
+					continue
+				}
+				pointer := "#/paths/" + EscapeJSONPointer(path.Key) + "/" + EscapeJSONPointer(op.Key)
+
+				if op.Value.RequestBody != nil {
+					visitContent(pointer+"/requestBody", op.Value.RequestBody.Content)
+				}
+				if op.Value.Responses != nil && op.Value.Responses.Codes != nil {
+					for response := op.Value.Responses.Codes.Oldest(); response != nil; response = response.Next() {
+						if response.Value != nil {
+							visitContent(pointer+"/responses/"+EscapeJSONPointer(response.Key), response.Value.Content)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	if doc.Model.Components == nil {
+		return
+	}
+	if doc.Model.Components.Schemas != nil {
+		for schema := doc.Model.Components.Schemas.Oldest(); schema != nil; schema = schema.Next() {
+			visitNestedSchemasWithPointer("#/components/schemas/"+EscapeJSONPointer(schema.Key), schema.Value, visitor)
+		}
+	}
+	if doc.Model.Components.Responses != nil {
+		for response := doc.Model.Components.Responses.Oldest(); response != nil; response = response.Next() {
+			if response.Value != nil {
+				visitContent("#/components/responses/"+EscapeJSONPointer(response.Key), response.Value.Content)
+			}
+		}
+	}
+	if doc.Model.Components.Parameters != nil {
+		for parameter := doc.Model.Components.Parameters.Oldest(); parameter != nil; parameter = parameter.Next() {
+			if parameter.Value != nil {
+				visitNestedSchemasWithPointer("#/components/parameters/"+EscapeJSONPointer(parameter.Key)+"/schema", parameter.Value.Schema, visitor)
+			}
+		}
+	}
+	if doc.Model.Components.RequestBodies != nil {
+		for requestBody := doc.Model.Components.RequestBodies.Oldest(); requestBody != nil; requestBody = requestBody.Next() {
+			if requestBody.Value != nil {
+				visitContent("#/components/requestBodies/"+EscapeJSONPointer(requestBody.Key), requestBody.Value.Content)
+			}
+		}
+	}
+	if doc.Model.Components.Headers != nil {
+		for header := doc.Model.Components.Headers.Oldest(); header != nil; header = header.Next() {
+			if header.Value != nil {
+				visitNestedSchemasWithPointer("#/components/headers/"+EscapeJSONPointer(header.Key)+"/schema", header.Value.Schema, visitor)
+			}
+		}
+	}
+}
+
+func visitNestedSchemasWithPointer(
+	pointer string,
+	schema *base.SchemaProxy,
+	visitor func(pointer string, schema *base.SchemaProxy),
+) {
+	if schema == nil {
+		return
+	}
+
+	visitor(pointer, schema)
+	if schema.IsReference() {
+		return
+	}
+
+	s := schema.Schema()
+	if s == nil {
+		return
+	}
+
+	if s.Properties != nil {
+		for prop := s.Properties.Oldest(); prop != nil; prop = prop.Next() {
+			visitNestedSchemasWithPointer(pointer+"/properties/"+EscapeJSONPointer(prop.Key), prop.Value, visitor)
+		}
+	}
+	for i, sp := range s.AllOf {
+		visitNestedSchemasWithPointer(pointer+"/allOf/"+strconv.Itoa(i), sp, visitor)
+	}
+	for i, sp := range s.AnyOf {
+		visitNestedSchemasWithPointer(pointer+"/anyOf/"+strconv.Itoa(i), sp, visitor)
+	}
+	for i, sp := range s.OneOf {
+		visitNestedSchemasWithPointer(pointer+"/oneOf/"+strconv.Itoa(i), sp, visitor)
+	}
+	if s.Items != nil && s.Items.IsA() {
+		visitNestedSchemasWithPointer(pointer+"/items", s.Items.A, visitor)
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.IsA() {
+		visitNestedSchemasWithPointer(pointer+"/additionalProperties", s.AdditionalProperties.A, visitor)
+	}
+	if s.Not != nil {
+		visitNestedSchemasWithPointer(pointer+"/not", s.Not, visitor)
+	}
+}
@@ -0,0 +1,56 @@
+package openapidocument
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVisitAllSchemasWithPointer(t *testing.T) {
+	doc := OpenV3DocumentForTest([]byte(`openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              allOf:
+                - $ref: '#/components/schemas/Pet'
+                - type: object
+                  properties:
+                    tags:
+                      type: array
+                      items:
+                        type: string
+      responses:
+        "200":
+          description: ok
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        a/b:
+          type: string
+`))
+
+	var pointers []string
+	VisitAllSchemasWithPointer(doc, func(pointer string, schema *base.SchemaProxy) {
+		pointers = append(pointers, pointer)
+	})
+
+	assert.Equal(t, []string{
+		"#/paths/~1pets~1{id}/post/requestBody/content/application~1json/schema",
+		"#/paths/~1pets~1{id}/post/requestBody/content/application~1json/schema/allOf/0",
+		"#/paths/~1pets~1{id}/post/requestBody/content/application~1json/schema/allOf/1",
+		"#/paths/~1pets~1{id}/post/requestBody/content/application~1json/schema/allOf/1/properties/tags",
+		"#/paths/~1pets~1{id}/post/requestBody/content/application~1json/schema/allOf/1/properties/tags/items",
+		"#/components/schemas/Pet",
+		"#/components/schemas/Pet/properties/a~1b",
+	}, pointers)
+}
@@ -65,9 +65,11 @@ const (
 
 type GenerateOpts struct {
 	DryRun             bool
-	Doc                *libopenapi.DocumentModel[v3.Document]
+	Check              bool                                   // Check renders without writing files and fails with ErrStaleOutput if the output directory is outdated
+	Doc                *libopenapi.DocumentModel[v3.Document] `json:"-"`
 	OutputDir          string
 	TemplateId         string
+	TemplateDir        string // TemplateDir is a directory with a template manifest or files that override the embedded template
 	TemplateProperties map[string]string
 	PackageConfig      CommonPackages
 	ArtifactGroupId    string
@@ -75,6 +77,7 @@ type GenerateOpts struct {
 	RepositoryUrl      string
 	LicenseName        string
 	LicenseUrl         string
+	Maintainers        []appconf.MaintainerConf
 	Provider           appconf.ProviderConf
 	GeneratorNames     []string
 	GeneratorOutputs   []string
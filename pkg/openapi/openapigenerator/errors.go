@@ -6,4 +6,9 @@ import (
 
 var (
 	ErrFailedToWriteMetadata = errors.New("failed to write metadata")
+	ErrStaleOutput           = errors.New("generated output is stale")
+	ErrPluginNotFound        = errors.New("generator plugin not found")
+	ErrPluginFailed          = errors.New("generator plugin failed")
+	ErrPluginInvalidResponse = errors.New("generator plugin returned an invalid response")
+	ErrPluginInvalidFilePath = errors.New("generator plugin returned a file outside of the output directory")
 )
@@ -0,0 +1,110 @@
+package openapigenerator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// problemDetailsMediaType is the media type of RFC 9457 problem details
+const problemDetailsMediaType = "application/problem+json"
+
+// buildErrorResponses collects the 4xx, 5xx and default responses of an operation with a body, ordered by precedence: status codes, ranges and the default response
+func buildErrorResponses(gen CodeGenerator, op *v3.Operation) ([]ErrorResponse, error) {
+	if op.Responses == nil {
+		return nil, nil
+	}
+
+	var codes []string
+	if op.Responses.Codes != nil {
+		for resp := op.Responses.Codes.Oldest(); resp != nil; resp = resp.Next() {
+			if isErrorStatusCode(resp.Key) {
+				codes = append(codes, resp.Key)
+			}
+		}
+	}
+	sort.SliceStable(codes, func(i, j int) bool {
+		iRange, jRange := isStatusCodeRange(codes[i]), isStatusCodeRange(codes[j])
+		if iRange != jRange {
+			return !iRange
+		}
+		return codes[i] < codes[j]
+	})
+
+	var errorResponses []ErrorResponse
+	for _, code := range codes {
+		resp, _ := op.Responses.Codes.Get(code)
+		errorResponse, err := buildErrorResponse(gen, code, resp)
+		if err != nil {
+			return nil, fmt.Errorf("error converting type of %s response: %w", code, err)
+		}
+		if errorResponse != nil {
+			errorResponses = append(errorResponses, *errorResponse)
+		}
+	}
+	if op.Responses.Default != nil {
+		errorResponse, err := buildErrorResponse(gen, "default", op.Responses.Default)
+		if err != nil {
+			return nil, fmt.Errorf("error converting type of default response: %w", err)
+		}
+		if errorResponse != nil {
+			errorResponses = append(errorResponses, *errorResponse)
+		}
+	}
+
+	return errorResponses, nil
+}
+
+// buildErrorResponse describes the body of a single error response, problem details are preferred over other media types, returns nil if the response has no body
+func buildErrorResponse(gen CodeGenerator, code string, resp *v3.Response) (*ErrorResponse, error) {
+	if resp == nil || resp.Content == nil || resp.Content.First() == nil {
+		return nil, nil
+	}
+
+	content := resp.Content.First()
+	for c := resp.Content.First(); c != nil; c = c.Next() {
+		if isProblemDetailsMediaType(c.Key()) {
+			content = c
+			break
+		}
+	}
+
+	errorResponse := ErrorResponse{
+		Code:           code,
+		Description:    resp.Description,
+		ContentType:    content.Key(),
+		Type:           gen.PostProcessType(VoidCodeType),
+		ProblemDetails: isProblemDetailsMediaType(content.Key()),
+	}
+	if content.Value().Schema != nil && !isEmptySchema(content.Value().Schema.Schema()) {
+		bodyType, err := gen.ToCodeType(content.Value().Schema.Schema(), CodeTypeSchemaResponse, false)
+		if err != nil {
+			return nil, err
+		}
+		errorResponse.Type = gen.PostProcessType(bodyType)
+	}
+
+	return &errorResponse, nil
+}
+
+// isErrorStatusCode returns true for 4xx and 5xx status codes and the ranges 4XX and 5XX
+func isErrorStatusCode(code string) bool {
+	return len(code) == 3 && (code[0] == '4' || code[0] == '5')
+}
+
+// isStatusCodeRange returns true for status code ranges like 4XX
+func isStatusCodeRange(code string) bool {
+	return strings.HasSuffix(strings.ToUpper(code), "XX")
+}
+
+// isEmptySchema returns true if the schema does not describe the body, e.g. a media type without schema
+func isEmptySchema(schema *base.Schema) bool {
+	return schema == nil || (len(schema.Type) == 0 && schema.Properties == nil && schema.Items == nil && len(schema.AllOf) == 0 && len(schema.OneOf) == 0 && len(schema.AnyOf) == 0)
+}
+
+func isProblemDetailsMediaType(mediaType string) bool {
+	return strings.ToLower(strings.TrimSpace(strings.Split(mediaType, ";")[0])) == problemDetailsMediaType
+}
@@ -0,0 +1,139 @@
+package openapigenerator_test
+
+import (
+	"testing"
+
+	openapi_go "github.com/primelib/primecodegen/pkg/generator/openapi-go"
+	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
+	"github.com/primelib/primecodegen/pkg/openapi/openapigenerator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const errorTypesSpec = `
+openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+  x-name: pets
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        "4XX":
+          description: client error
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Error'
+        "422":
+          description: invalid
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Error'
+            application/problem+json:
+              schema:
+                $ref: '#/components/schemas/Problem'
+        "404":
+          description: not found
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/NotFound'
+        "503":
+          description: unavailable
+        default:
+          description: unexpected error
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Error'
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      title: Pet
+      type: object
+      properties:
+        id:
+          type: string
+    Error:
+      title: Error
+      type: object
+      properties:
+        message:
+          type: string
+    NotFound:
+      title: NotFound
+      type: object
+      properties:
+        id:
+          type: string
+    Problem:
+      title: Problem
+      type: object
+      properties:
+        type:
+          type: string
+        title:
+          type: string
+`
+
+func TestBuildTemplateDataErrorTypes(t *testing.T) {
+	doc := openapidocument.OpenV3DocumentForTest([]byte(errorTypesSpec))
+	require.NotNil(t, doc)
+
+	data, err := openapigenerator.BuildTemplateData(doc, openapi_go.NewGenerator(), openapigenerator.CommonPackages{})
+	require.NoError(t, err)
+	require.Len(t, data.Operations, 2)
+
+	op := data.Operations[0]
+	require.Len(t, op.ErrorResponses, 4)
+	assert.Equal(t, "404", op.ErrorResponses[0].Code)
+	assert.Equal(t, "NotFound", op.ErrorResponses[0].Type.Name)
+	assert.Equal(t, "422", op.ErrorResponses[1].Code)
+	assert.True(t, op.ErrorResponses[1].ProblemDetails)
+	assert.Equal(t, "application/problem+json", op.ErrorResponses[1].ContentType)
+	assert.Equal(t, "Problem", op.ErrorResponses[1].Type.Name)
+	assert.Equal(t, "4XX", op.ErrorResponses[2].Code)
+	assert.True(t, op.ErrorResponses[2].IsRange())
+	assert.False(t, op.ErrorResponses[2].ProblemDetails)
+	assert.True(t, op.ErrorResponses[3].IsDefault())
+
+	require.Len(t, op.ErrorTypesByCode, 3)
+	assert.Equal(t, "NotFound", op.ErrorTypesByCode["404"].Name)
+	assert.Equal(t, "Error", op.ErrorTypesByCode["4XX"].Name)
+	require.NotNil(t, op.DefaultErrorType)
+	assert.Equal(t, "Error", op.DefaultErrorType.Name)
+
+	require.Len(t, op.ErrorTypes, 3)
+	assert.Equal(t, "NotFound", op.ErrorTypes[0].Name)
+	assert.Equal(t, "Problem", op.ErrorTypes[1].Name)
+	assert.Equal(t, "Error", op.ErrorTypes[2].Name)
+
+	assert.Empty(t, data.Operations[1].ErrorResponses)
+	assert.Nil(t, data.Operations[1].DefaultErrorType)
+}
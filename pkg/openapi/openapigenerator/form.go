@@ -0,0 +1,106 @@
+package openapigenerator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/primelib/primecodegen/pkg/openapi/openapiutil"
+)
+
+const (
+	formContentTypeMultipart  = "multipart/form-data"
+	formContentTypeURLEncoded = "application/x-www-form-urlencoded"
+)
+
+// requestContentTypes returns all media types of the request body
+func requestContentTypes(rb *v3.RequestBody) []string {
+	var contentTypes []string
+	for content := rb.Content.First(); content != nil; content = content.Next() {
+		contentTypes = append(contentTypes, content.Key())
+	}
+	return contentTypes
+}
+
+// buildForm describes the fields of a form request body, returns nil if the media type is not a form
+func buildForm(gen CodeGenerator, contentType string, mediaType *v3.MediaType) (*Form, error) {
+	normalized := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	if normalized != formContentTypeMultipart && normalized != formContentTypeURLEncoded {
+		return nil, nil
+	}
+
+	form := Form{
+		ContentType: contentType,
+		Multipart:   normalized == formContentTypeMultipart,
+	}
+	if mediaType == nil || mediaType.Schema == nil {
+		return &form, nil
+	}
+	schema := mediaType.Schema.Schema()
+	if schema == nil || schema.Properties == nil {
+		return &form, nil
+	}
+
+	for prop := schema.Properties.First(); prop != nil; prop = prop.Next() {
+		fieldName := prop.Key()
+		pSchema, err := prop.Value().BuildSchema()
+		if err != nil {
+			return nil, fmt.Errorf("error building schema of form field [%s]: %w", fieldName, err)
+		}
+		pType, err := gen.ToCodeType(pSchema, CodeTypeSchemaProperty, false)
+		if err != nil {
+			return nil, fmt.Errorf("error converting type of form field [%s]: %w", fieldName, err)
+		}
+
+		part := FormPart{
+			Name:      gen.ToPropertyName(fieldName),
+			FieldName: fieldName,
+			Type:      gen.PostProcessType(pType),
+			Required:  slices.Contains(schema.Required, fieldName),
+			Nullable:  openapiutil.IsSchemaNullable(pSchema),
+			Explode:   true,
+		}
+
+		valueSchema := pSchema
+		if slices.Contains(pSchema.Type, "array") && pSchema.Items != nil && pSchema.Items.IsA() {
+			valueSchema = pSchema.Items.A.Schema()
+			part.IsArray = true
+			if len(part.Type.TypeArgs) > 0 {
+				part.ItemType = part.Type.TypeArgs[0]
+			}
+		}
+		part.IsFile = isBinarySchema(valueSchema)
+		part.IsJSON = !part.IsFile && valueSchema != nil && (slices.Contains(valueSchema.Type, "object") || valueSchema.Properties != nil)
+
+		switch {
+		case part.IsFile:
+			part.ContentType = "application/octet-stream"
+		case part.IsJSON:
+			part.ContentType = "application/json"
+		}
+		if mediaType.Encoding != nil {
+			if encoding, ok := mediaType.Encoding.Get(fieldName); ok && encoding != nil {
+				if encoding.ContentType != "" {
+					part.ContentType = strings.TrimSpace(strings.Split(encoding.ContentType, ",")[0])
+				}
+				if encoding.Explode != nil {
+					part.Explode = *encoding.Explode
+				}
+			}
+		}
+
+		form.Parts = append(form.Parts, part)
+	}
+
+	return &form, nil
+}
+
+// isBinarySchema returns true if the schema describes file content
+func isBinarySchema(schema *base.Schema) bool {
+	if schema == nil || !slices.Contains(schema.Type, "string") {
+		return false
+	}
+	return schema.Format == "binary"
+}
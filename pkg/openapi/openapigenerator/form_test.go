@@ -0,0 +1,157 @@
+package openapigenerator_test
+
+import (
+	"testing"
+
+	openapi_go "github.com/primelib/primecodegen/pkg/generator/openapi-go"
+	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
+	"github.com/primelib/primecodegen/pkg/openapi/openapigenerator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const formSpec = `
+openapi: 3.0.3
+info:
+  title: Files
+  version: 1.0.0
+  x-name: files
+paths:
+  /files:
+    post:
+      operationId: uploadFile
+      requestBody:
+        required: true
+        content:
+          multipart/form-data:
+            schema:
+              $ref: '#/components/schemas/UploadRequest'
+            encoding:
+              file:
+                contentType: image/png
+              tags:
+                explode: false
+      responses:
+        "201":
+          description: created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/FileInfo'
+  /tokens:
+    post:
+      operationId: createToken
+      requestBody:
+        required: true
+        content:
+          application/x-www-form-urlencoded:
+            schema:
+              $ref: '#/components/schemas/TokenRequest'
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/FileInfo'
+  /info:
+    put:
+      operationId: updateInfo
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/FileInfo'
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/FileInfo'
+components:
+  schemas:
+    UploadRequest:
+      title: UploadRequest
+      type: object
+      required: [file]
+      properties:
+        file:
+          type: string
+          format: binary
+        tags:
+          type: array
+          items:
+            type: string
+        attachments:
+          type: array
+          items:
+            type: string
+            format: binary
+        metadata:
+          $ref: '#/components/schemas/FileInfo'
+    TokenRequest:
+      title: TokenRequest
+      type: object
+      required: [grant_type]
+      properties:
+        grant_type:
+          type: string
+        expires_in:
+          type: integer
+    FileInfo:
+      title: FileInfo
+      type: object
+      properties:
+        id:
+          type: string
+`
+
+func TestBuildTemplateDataForm(t *testing.T) {
+	doc := openapidocument.OpenV3DocumentForTest([]byte(formSpec))
+	require.NotNil(t, doc)
+
+	data, err := openapigenerator.BuildTemplateData(doc, openapi_go.NewGenerator(), openapigenerator.CommonPackages{})
+	require.NoError(t, err)
+	require.Len(t, data.Operations, 3)
+
+	upload := data.Operations[0]
+	assert.Equal(t, []string{"multipart/form-data"}, upload.RequestContentTypes)
+	require.NotNil(t, upload.Form)
+	assert.True(t, upload.Form.Multipart)
+	require.Len(t, upload.Form.Parts, 4)
+
+	file := upload.Form.Parts[0]
+	assert.Equal(t, "file", file.FieldName)
+	assert.True(t, file.IsFile)
+	assert.True(t, file.Required)
+	assert.False(t, file.IsArray)
+	assert.Equal(t, "image/png", file.ContentType)
+
+	tags := upload.Form.Parts[1]
+	assert.True(t, tags.IsArray)
+	assert.False(t, tags.IsFile)
+	assert.False(t, tags.Explode)
+
+	attachments := upload.Form.Parts[2]
+	assert.True(t, attachments.IsArray)
+	assert.True(t, attachments.IsFile)
+	assert.True(t, attachments.Explode)
+	assert.Equal(t, "application/octet-stream", attachments.ContentType)
+
+	metadata := upload.Form.Parts[3]
+	assert.True(t, metadata.IsJSON)
+	assert.Equal(t, "application/json", metadata.ContentType)
+
+	token := data.Operations[1].Form
+	require.NotNil(t, token)
+	assert.False(t, token.Multipart)
+	assert.Equal(t, "application/x-www-form-urlencoded", token.ContentType)
+	require.Len(t, token.Parts, 2)
+	assert.Equal(t, "grant_type", token.Parts[0].FieldName)
+	assert.True(t, token.Parts[0].Required)
+	assert.False(t, token.Parts[1].Required)
+
+	assert.Nil(t, data.Operations[2].Form)
+	assert.Equal(t, []string{"application/json"}, data.Operations[2].RequestContentTypes)
+}
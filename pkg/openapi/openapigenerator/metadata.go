@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/primelib/primecodegen/pkg/template"
 	"github.com/primelib/primecodegen/pkg/template/templateapi"
 	"log/slog"
 )
@@ -45,6 +46,9 @@ func RemoveGeneratedFile(outputDir string, file string) error {
 	}
 
 	if fileInfo, err := os.Stat(file); err == nil && fileInfo.Mode().IsRegular() {
+		if keepErr := template.KeepCustomRegions(file); keepErr != nil {
+			return fmt.Errorf("failed to keep custom regions: %w", keepErr)
+		}
 		remErr := os.Remove(file)
 		if remErr != nil {
 			return fmt.Errorf("failed to remove file: %w", remErr)
@@ -57,6 +61,7 @@ func RemoveGeneratedFile(outputDir string, file string) error {
 // WriteMetadata generates metadata about the generated files for the output directory
 func WriteMetadata(outputDir string, files map[string]templateapi.RenderedFile) error {
 	writtenFiles := path.Join(outputDir, ".openapi-generator", "FILES")
+	writtenChecksums := path.Join(outputDir, ".openapi-generator", "CHECKSUMS")
 
 	// ensure output directory exists
 	err := os.MkdirAll(path.Dir(writtenFiles), os.ModePerm)
@@ -64,28 +69,92 @@ func WriteMetadata(outputDir string, files map[string]templateapi.RenderedFile)
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	// open the file for writing
-	file, err := os.Create(writtenFiles)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
-	}
-	defer file.Close()
-
-	// write each file name to FILES
+	// collect rendered and unchanged files
 	var fileList []string
+	checksums := make(map[string]templateapi.FileChecksum)
 	for _, f := range files {
-		if f.State == templateapi.FileRendered {
+		if f.State == templateapi.FileRendered || f.State == templateapi.FileUnchanged {
 			relativeFile := strings.TrimPrefix(strings.TrimPrefix(f.File, outputDir), "/")
 			fileList = append(fileList, relativeFile)
+
+			// the file checksum is taken after post-processing, to detect changes made on disk
+			content, err := os.ReadFile(f.File)
+			if f.Checksum != "" && err == nil {
+				checksums[relativeFile] = templateapi.FileChecksum{Rendered: f.Checksum, File: template.FileChecksum(content)}
+			}
 		}
 	}
 	sort.Strings(fileList)
+
+	// write each file name to FILES
+	var filesContent strings.Builder
+	for _, fileName := range fileList {
+		filesContent.WriteString(fileName + "\n")
+	}
+	if err = os.WriteFile(writtenFiles, []byte(filesContent.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write to file: %w", err)
+	}
+
+	// write the checksums of each file to CHECKSUMS, in the form "<rendered> <file>  <name>"
+	var checksumsContent strings.Builder
 	for _, fileName := range fileList {
-		_, err = file.WriteString(fileName + "\n")
-		if err != nil {
-			return fmt.Errorf("failed to write to file: %w", err)
+		if checksum, ok := checksums[fileName]; ok {
+			checksumsContent.WriteString(checksum.Rendered + " " + checksum.File + "  " + fileName + "\n")
 		}
 	}
+	if err = os.WriteFile(writtenChecksums, []byte(checksumsContent.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write to file: %w", err)
+	}
 
 	return nil
 }
+
+// ChecksumsListedInMetadata returns the checksums of the previous run, keyed by file path
+func ChecksumsListedInMetadata(outputDir string) map[string]templateapi.FileChecksum {
+	writtenChecksums := path.Join(outputDir, ".openapi-generator", "CHECKSUMS")
+
+	file, err := os.Open(writtenChecksums)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	checksums := make(map[string]templateapi.FileChecksum)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		checksum, fileName, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "  ")
+		rendered, fileChecksum, _ := strings.Cut(checksum, " ")
+		if ok && fileName != "" {
+			checksums[path.Join(outputDir, fileName)] = templateapi.FileChecksum{Rendered: rendered, File: fileChecksum}
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		slog.Error("Error reading checksum metadata", "err", err)
+		return nil
+	}
+
+	return checksums
+}
+
+// StaleFiles returns the files that differ from the output directory, these are the files that would be written, restored or removed
+func StaleFiles(outputDir string, files map[string]templateapi.RenderedFile) []string {
+	checksums := ChecksumsListedInMetadata(outputDir)
+
+	var stale []string
+	for _, f := range files {
+		if f.State == templateapi.FileDryRun || f.State == templateapi.FileRendered {
+			stale = append(stale, f.File)
+		} else if f.State == templateapi.FileUnchanged && !template.IsUnchanged(f.File, f.Checksum, checksums) {
+			stale = append(stale, f.File)
+		}
+	}
+	for _, f := range FilesListedInMetadata(outputDir) {
+		if _, ok := files[f]; !ok {
+			stale = append(stale, f)
+		}
+	}
+	sort.Strings(stale)
+
+	return stale
+}
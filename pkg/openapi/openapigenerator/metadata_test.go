@@ -0,0 +1,87 @@
+package openapigenerator_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/primelib/primecodegen/pkg/openapi/openapigenerator"
+	"github.com/primelib/primecodegen/pkg/template"
+	"github.com/primelib/primecodegen/pkg/template/templateapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeGeneratedFiles(t *testing.T, files map[string]string) {
+	for file, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+		require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+	}
+}
+
+func TestWriteMetadata_Checksums(t *testing.T) {
+	outputDir := t.TempDir()
+	rendered := filepath.Join(outputDir, "models", "pet.go")
+	unchanged := filepath.Join(outputDir, "client.go")
+	skipped := filepath.Join(outputDir, "README.md")
+	writeGeneratedFiles(t, map[string]string{rendered: "package models\n", unchanged: "package client\n"})
+
+	err := openapigenerator.WriteMetadata(outputDir, map[string]templateapi.RenderedFile{
+		rendered:  {File: rendered, State: templateapi.FileRendered, Checksum: "aaa"},
+		unchanged: {File: unchanged, State: templateapi.FileUnchanged, Checksum: "bbb"},
+		skipped:   {File: skipped, State: templateapi.FileSkippedName, Checksum: "ccc"},
+	})
+	require.NoError(t, err)
+
+	renderedChecksum := templateapi.Checksum([]byte("package models\n"))
+	unchangedChecksum := templateapi.Checksum([]byte("package client\n"))
+	content, err := os.ReadFile(filepath.Join(outputDir, ".openapi-generator", "CHECKSUMS"))
+	require.NoError(t, err)
+	assert.Equal(t, "bbb "+unchangedChecksum+"  client.go\naaa "+renderedChecksum+"  models/pet.go\n", string(content))
+	assert.Equal(t, []string{unchanged, rendered}, openapigenerator.FilesListedInMetadata(outputDir))
+	assert.Equal(t, map[string]templateapi.FileChecksum{
+		rendered:  {Rendered: "aaa", File: renderedChecksum},
+		unchanged: {Rendered: "bbb", File: unchangedChecksum},
+	}, openapigenerator.ChecksumsListedInMetadata(outputDir))
+}
+
+func TestStaleFiles(t *testing.T) {
+	outputDir := t.TempDir()
+	current := filepath.Join(outputDir, "current.go")
+	obsolete := filepath.Join(outputDir, "obsolete.go")
+	changed := filepath.Join(outputDir, "changed.go")
+	writeGeneratedFiles(t, map[string]string{current: "package current\n", obsolete: "package obsolete\n"})
+	require.NoError(t, openapigenerator.WriteMetadata(outputDir, map[string]templateapi.RenderedFile{
+		current:  {File: current, State: templateapi.FileRendered, Checksum: "aaa"},
+		obsolete: {File: obsolete, State: templateapi.FileRendered, Checksum: "bbb"},
+	}))
+
+	stale := openapigenerator.StaleFiles(outputDir, map[string]templateapi.RenderedFile{
+		current: {File: current, State: templateapi.FileUnchanged, Checksum: "aaa"},
+		changed: {File: changed, State: templateapi.FileDryRun, Checksum: "ccc"},
+	})
+	assert.Equal(t, []string{changed, obsolete}, stale)
+
+	upToDate := map[string]templateapi.RenderedFile{
+		current:  {File: current, State: templateapi.FileUnchanged, Checksum: "aaa"},
+		obsolete: {File: obsolete, State: templateapi.FileUnchanged, Checksum: "bbb"},
+	}
+	assert.Empty(t, openapigenerator.StaleFiles(outputDir, upToDate))
+
+	// files modified on disk are stale
+	writeGeneratedFiles(t, map[string]string{current: "package modified\n"})
+	assert.Equal(t, []string{current}, openapigenerator.StaleFiles(outputDir, upToDate))
+}
+
+func TestRemoveGeneratedFile_KeepsCustomRegions(t *testing.T) {
+	outputDir := t.TempDir()
+	file := filepath.Join(outputDir, "pet.go")
+	writeGeneratedFiles(t, map[string]string{file: "package models\n\n// primecodegen:custom-begin methods\nfunc (p Pet) Name() {}\n// primecodegen:custom-end methods\n\n// primecodegen:custom-begin imports\n// primecodegen:custom-end imports\n"})
+
+	require.NoError(t, openapigenerator.RemoveGeneratedFile(outputDir, "pet.go"))
+
+	assert.NoFileExists(t, file)
+	orphaned, err := os.ReadFile(template.OrphanedRegionsFile(file))
+	require.NoError(t, err)
+	assert.Equal(t, "// primecodegen:custom-begin methods\nfunc (p Pet) Name() {}\n// primecodegen:custom-end methods\n", string(orphaned))
+}
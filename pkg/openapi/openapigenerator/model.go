@@ -38,11 +38,19 @@ func BuildTemplateData(doc *libopenapi.DocumentModel[v3.Document], generator Cod
 		Packages:         packageConfig,
 	}
 
+	// retries
+	retries, err := buildRetryPolicy(doc.Model.Extensions, nil)
+	if err != nil {
+		return template, fmt.Errorf("error processing retries of document: %w", err)
+	}
+	template.Retries = retries
+
 	// all operations
 	operations, err := BuildOperations(OperationOpts{
 		Generator:     generator,
 		Doc:           doc,
 		PackageConfig: packageConfig,
+		Retries:       retries,
 	})
 	if err != nil {
 		return template, err
@@ -103,6 +111,17 @@ func BuildTemplateData(doc *libopenapi.DocumentModel[v3.Document], generator Cod
 	}
 	template.Enums = append(template.Enums, enums...)
 
+	// webhooks
+	webhooks, err := BuildWebhooks(OperationOpts{
+		Generator:     generator,
+		Doc:           doc,
+		PackageConfig: packageConfig,
+	})
+	if err != nil {
+		return template, err
+	}
+	template.Webhooks = append(template.Webhooks, webhooks...)
+
 	return template, nil
 }
 
@@ -189,6 +208,7 @@ type OperationOpts struct {
 	Generator     CodeGenerator
 	Doc           *libopenapi.DocumentModel[v3.Document]
 	PackageConfig CommonPackages
+	Retries       *RetryPolicy // Retries is the document retry policy, operation level x-retries are applied on top of it
 }
 
 func BuildOperations(opts OperationOpts) ([]Operation, error) {
@@ -314,6 +334,15 @@ func BuildOperations(opts OperationOpts) ([]Operation, error) {
 					Required:    true,
 				}
 				operation.AddParameter(bodyParam)
+
+				// form fields
+				operation.RequestContentTypes = requestContentTypes(rb)
+				operation.RequestBodyRequired = rb.Required != nil && *rb.Required
+				form, err := buildForm(gen, requestBody.Key(), requestBody.Value())
+				if err != nil {
+					return operations, fmt.Errorf("error processing form body of [%s:%s]: %w", path.Key, op.Key, err)
+				}
+				operation.Form = form
 			}
 
 			// response type
@@ -372,6 +401,46 @@ func BuildOperations(opts OperationOpts) ([]Operation, error) {
 				}
 			}
 
+			// error responses
+			errorResponses, err := buildErrorResponses(gen, op.Value)
+			if err != nil {
+				return operations, fmt.Errorf("error processing error responses of [%s:%s]: %w", path.Key, op.Key, err)
+			}
+			operation.ErrorResponses = errorResponses
+			operation.ErrorTypesByCode = make(map[string]*CodeType)
+			for _, errorResponse := range errorResponses {
+				errorType := errorResponse.Type
+				if errorResponse.IsDefault() {
+					operation.DefaultErrorType = &errorType
+				} else {
+					operation.ErrorTypesByCode[errorResponse.Code] = &errorType
+				}
+				if !errorType.IsVoid && !slices.ContainsFunc(operation.ErrorTypes, func(t CodeType) bool { return t.QualifiedType == errorType.QualifiedType }) {
+					operation.ErrorTypes = append(operation.ErrorTypes, errorType)
+				}
+			}
+
+			// pagination
+			pagination, err := buildPagination(gen, op.Value, operation)
+			if err != nil {
+				return operations, fmt.Errorf("error processing pagination of [%s:%s]: %w", path.Key, op.Key, err)
+			}
+			operation.Pagination = pagination
+
+			// streaming
+			streaming, err := buildStreaming(gen, op.Value)
+			if err != nil {
+				return operations, fmt.Errorf("error processing streaming of [%s:%s]: %w", path.Key, op.Key, err)
+			}
+			operation.Streaming = streaming
+
+			// retries
+			retries, err := buildOperationRetryPolicy(op.Key, op.Value.Extensions, opts.Retries)
+			if err != nil {
+				return operations, fmt.Errorf("error processing retries of [%s:%s]: %w", path.Key, op.Key, err)
+			}
+			operation.Retries = retries
+
 			operation.PathSegments = BuildPathSegments(path.Key, operation.PathParameters)
 			operation.Imports = uniqueSortImports(operation.Imports)
 			operation.Extensions = op.Value.Extensions
@@ -0,0 +1,194 @@
+package openapigenerator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/primelib/primecodegen/pkg/openapi/openapiutil"
+)
+
+// paginationExtension is the content of the x-pagination operation extension
+//
+// Example:
+//
+//	x-pagination:
+//	  style: cursor
+//	  inputs:
+//	    cursor: after
+//	    limit: limit
+//	  outputs:
+//	    results: $.data
+//	    nextCursor: $.meta.nextCursor
+type paginationExtension struct {
+	Style  string `yaml:"style"`
+	Inputs struct {
+		Cursor string `yaml:"cursor"`
+		Offset string `yaml:"offset"`
+		Page   string `yaml:"page"`
+		Limit  string `yaml:"limit"`
+	} `yaml:"inputs"`
+	Outputs struct {
+		Results    string `yaml:"results"`
+		NextCursor string `yaml:"nextCursor"`
+	} `yaml:"outputs"`
+}
+
+// buildPagination parses the x-pagination extension of an operation, returns nil if the operation is not paginated
+func buildPagination(gen CodeGenerator, op *v3.Operation, operation Operation) (*Pagination, error) {
+	if op.Extensions == nil {
+		return nil, nil
+	}
+	node, ok := op.Extensions.Get("x-pagination")
+	if !ok || node == nil {
+		return nil, nil
+	}
+
+	var ext paginationExtension
+	if err := node.Decode(&ext); err != nil {
+		return nil, fmt.Errorf("unable to decode x-pagination: %w", err)
+	}
+
+	pagination := Pagination{Style: PaginationStyle(ext.Style)}
+	var input string
+	switch pagination.Style {
+	case PaginationStyleCursor:
+		input = ext.Inputs.Cursor
+		if ext.Outputs.NextCursor == "" {
+			return nil, fmt.Errorf("x-pagination style cursor requires outputs.nextCursor")
+		}
+	case PaginationStyleOffset:
+		input = ext.Inputs.Offset
+	case PaginationStylePage:
+		input = ext.Inputs.Page
+	default:
+		return nil, fmt.Errorf("unsupported x-pagination style [%s], supported styles are cursor, offset and page", ext.Style)
+	}
+	if input == "" {
+		return nil, fmt.Errorf("x-pagination style %s requires inputs.%s", ext.Style, ext.Style)
+	}
+
+	// input parameters
+	param, ok := findQueryParameter(operation, input)
+	if !ok {
+		return nil, fmt.Errorf("x-pagination input parameter [%s] is not a query parameter of the operation", input)
+	}
+	pagination.Parameter = param
+	if ext.Inputs.Limit != "" {
+		limitParam, ok := findQueryParameter(operation, ext.Inputs.Limit)
+		if !ok {
+			return nil, fmt.Errorf("x-pagination limit parameter [%s] is not a query parameter of the operation", ext.Inputs.Limit)
+		}
+		pagination.LimitParameter = &limitParam
+	}
+
+	// response body
+	responseSchema, responseCode := paginatedResponseSchema(op)
+	if responseSchema == nil {
+		return nil, fmt.Errorf("x-pagination requires a 200 or 201 response with content")
+	}
+	pagination.ResponseCode = responseCode
+	bodyType := operation.ReturnTypeByCode[responseCode]
+	if bodyType == nil {
+		return nil, fmt.Errorf("x-pagination requires a typed %s response", responseCode)
+	}
+
+	// results
+	resultsPath, resultsSchema, resultsType, err := resolvePropertyPath(gen, responseSchema, *bodyType, ext.Outputs.Results)
+	if err != nil {
+		return nil, fmt.Errorf("x-pagination outputs.results: %w", err)
+	}
+	if !slices.Contains(resultsSchema.Type, "array") || len(resultsType.TypeArgs) == 0 {
+		return nil, fmt.Errorf("x-pagination outputs.results [%s] must point to an array", ext.Outputs.Results)
+	}
+	pagination.ResultsPath = resultsPath
+	pagination.ResultsType = resultsType
+	pagination.ItemType = resultsType.TypeArgs[0]
+
+	// next cursor
+	if pagination.Style == PaginationStyleCursor {
+		nextCursorPath, _, nextCursorType, err := resolvePropertyPath(gen, responseSchema, *bodyType, ext.Outputs.NextCursor)
+		if err != nil {
+			return nil, fmt.Errorf("x-pagination outputs.nextCursor: %w", err)
+		}
+		if len(nextCursorPath) == 0 {
+			return nil, fmt.Errorf("x-pagination outputs.nextCursor must point to a property of the response")
+		}
+		pagination.NextCursorPath = nextCursorPath
+		pagination.NextCursorType = nextCursorType
+	}
+
+	return &pagination, nil
+}
+
+func findQueryParameter(operation Operation, fieldName string) (Parameter, bool) {
+	for _, p := range operation.MutableQueryParameters {
+		if p.FieldName == fieldName {
+			return p, true
+		}
+	}
+	return Parameter{}, false
+}
+
+// paginatedResponseSchema returns the schema and status code of the successful response
+func paginatedResponseSchema(op *v3.Operation) (*base.Schema, string) {
+	if op.Responses == nil || op.Responses.Codes == nil {
+		return nil, ""
+	}
+	for _, code := range []string{"200", "201"} {
+		resp, ok := op.Responses.Codes.Get(code)
+		if !ok || resp.Content == nil || resp.Content.First() == nil {
+			continue
+		}
+		mediaType := resp.Content.First().Value()
+		if mediaType.Schema == nil {
+			continue
+		}
+		return mediaType.Schema.Schema(), code
+	}
+	return nil, ""
+}
+
+// resolvePropertyPath walks a path expression (e.g. $.meta.nextCursor) through the properties of the given schema
+func resolvePropertyPath(gen CodeGenerator, schema *base.Schema, schemaType CodeType, expression string) ([]PropertyPath, *base.Schema, CodeType, error) {
+	expression = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(expression), "$"), ".")
+	if expression == "" {
+		return nil, schema, schemaType, nil
+	}
+
+	var path []PropertyPath
+	current := schema
+	currentType := schemaType
+	for _, fieldName := range strings.Split(expression, ".") {
+		if current == nil || current.Properties == nil {
+			return nil, nil, currentType, fmt.Errorf("can not resolve [%s], parent is not an object", fieldName)
+		}
+		proxy, ok := current.Properties.Get(fieldName)
+		if !ok || proxy == nil {
+			return nil, nil, currentType, fmt.Errorf("property [%s] does not exist", fieldName)
+		}
+		pSchema, err := proxy.BuildSchema()
+		if err != nil {
+			return nil, nil, currentType, fmt.Errorf("error building property schema [%s]: %w", fieldName, err)
+		}
+
+		pType, err := gen.ToCodeType(pSchema, CodeTypeSchemaProperty, false)
+		if err != nil {
+			return nil, nil, currentType, fmt.Errorf("error converting type of property [%s]: %w", fieldName, err)
+		}
+		pType = gen.PostProcessType(pType)
+
+		path = append(path, PropertyPath{
+			Name:      gen.ToPropertyName(fieldName),
+			FieldName: fieldName,
+			Type:      pType,
+			Nullable:  openapiutil.IsSchemaNullable(pSchema),
+		})
+		current = pSchema
+		currentType = pType
+	}
+
+	return path, current, currentType, nil
+}
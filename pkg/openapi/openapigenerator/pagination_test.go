@@ -0,0 +1,104 @@
+package openapigenerator_test
+
+import (
+	"fmt"
+	"testing"
+
+	openapi_go "github.com/primelib/primecodegen/pkg/generator/openapi-go"
+	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
+	"github.com/primelib/primecodegen/pkg/openapi/openapigenerator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const paginationSpec = `
+openapi: 3.0.3
+info:
+  title: Paged
+  version: 1.0.0
+paths:
+  /items:
+    get:
+      operationId: listItems
+      x-pagination:
+        style: %s
+        inputs:
+          cursor: after
+          offset: offset
+          limit: limit
+        outputs:
+          results: $.data
+          nextCursor: $.meta.nextCursor
+      parameters:
+        - {name: after, in: query, schema: {type: string}}
+        - {name: offset, in: query, schema: {type: integer}}
+        - {name: limit, in: query, schema: {type: integer}}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                title: ItemPage
+                type: object
+                properties:
+                  data:
+                    type: array
+                    items: {type: string}
+                  meta:
+                    title: PageMeta
+                    type: object
+                    properties:
+                      nextCursor: {type: string}
+`
+
+func buildPaginatedOperation(t *testing.T, style string) ([]openapigenerator.Operation, error) {
+	t.Helper()
+	doc := openapidocument.OpenV3DocumentForTest([]byte(fmt.Sprintf(paginationSpec, style)))
+	require.NotNil(t, doc)
+
+	return openapigenerator.BuildOperations(openapigenerator.OperationOpts{
+		Doc:       doc,
+		Generator: openapi_go.NewGenerator(),
+	})
+}
+
+func TestBuildOperationsCursorPagination(t *testing.T) {
+	operations, err := buildPaginatedOperation(t, "cursor")
+	require.NoError(t, err)
+	require.Len(t, operations, 1)
+
+	pagination := operations[0].Pagination
+	require.NotNil(t, pagination)
+	assert.Equal(t, openapigenerator.PaginationStyleCursor, pagination.Style)
+	assert.Equal(t, "after", pagination.Parameter.FieldName)
+	require.NotNil(t, pagination.LimitParameter)
+	assert.Equal(t, "limit", pagination.LimitParameter.FieldName)
+	assert.Equal(t, "200", pagination.ResponseCode)
+	require.Len(t, pagination.ResultsPath, 1)
+	assert.Equal(t, "Data", pagination.ResultsPath[0].Name)
+	assert.Equal(t, "[]*string", pagination.ResultsType.QualifiedDeclaration)
+	assert.Equal(t, "*string", pagination.ItemType.QualifiedDeclaration)
+	require.Len(t, pagination.NextCursorPath, 2)
+	assert.Equal(t, "Meta", pagination.NextCursorPath[0].Name)
+	assert.Equal(t, "NextCursor", pagination.NextCursorPath[1].Name)
+}
+
+func TestBuildOperationsOffsetPagination(t *testing.T) {
+	operations, err := buildPaginatedOperation(t, "offset")
+	require.NoError(t, err)
+
+	pagination := operations[0].Pagination
+	require.NotNil(t, pagination)
+	assert.Equal(t, openapigenerator.PaginationStyleOffset, pagination.Style)
+	assert.Equal(t, "offset", pagination.Parameter.FieldName)
+	assert.Empty(t, pagination.NextCursorPath)
+}
+
+func TestBuildOperationsInvalidPagination(t *testing.T) {
+	_, err := buildPaginatedOperation(t, "page")
+	assert.ErrorContains(t, err, "x-pagination style page requires inputs.page")
+
+	_, err = buildPaginatedOperation(t, "unknown")
+	assert.ErrorContains(t, err, "unsupported x-pagination style [unknown]")
+}
@@ -0,0 +1,320 @@
+package openapigenerator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/primelib/primecodegen/pkg/template"
+	"github.com/primelib/primecodegen/pkg/template/templateapi"
+	"github.com/primelib/primecodegen/pkg/util"
+)
+
+// PluginPrefix is the executable name prefix of generator plugins, the generator id is the remaining part of the name
+const PluginPrefix = "primecodegen-gen-"
+
+// PluginProtocolVersion is incremented on incompatible changes of PluginRequest or PluginResponse
+const PluginProtocolVersion = 1
+
+// PluginRequest is written as JSON to the stdin of the plugin
+type PluginRequest struct {
+	ProtocolVersion int
+	Generator       string
+	TemplateData    DocumentModel
+	Opts            GenerateOpts
+}
+
+// PluginResponse is read as JSON from the stdout of the plugin
+type PluginResponse struct {
+	Files []PluginFile
+}
+
+type PluginFile struct {
+	Path    string // Path is relative to the output directory, using forward slashes
+	Content string
+}
+
+// PluginGenerator runs an external executable that renders the files, the template data is built with language-neutral names and types
+type PluginGenerator struct {
+	ID   string
+	Path string
+}
+
+// LookupPlugin finds the executable primecodegen-gen-<id> on PATH
+func LookupPlugin(id string) (*PluginGenerator, error) {
+	if id == "" {
+		return nil, errors.Join(ErrPluginNotFound, fmt.Errorf("generator id is empty"))
+	}
+
+	path, err := exec.LookPath(PluginPrefix + id)
+	if err != nil {
+		return nil, errors.Join(ErrPluginNotFound, err)
+	}
+	return &PluginGenerator{ID: id, Path: path}, nil
+}
+
+// DiscoverPlugins returns all generator plugins on PATH, if a plugin is found in multiple directories the first one wins
+func DiscoverPlugins() []*PluginGenerator {
+	var plugins []*PluginGenerator
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			id, ok := pluginIdFromFileName(entry.Name())
+			if !ok || entry.IsDir() || slices.ContainsFunc(plugins, func(p *PluginGenerator) bool { return p.ID == id }) {
+				continue
+			}
+
+			info, err := entry.Info()
+			if err != nil || (runtime.GOOS != "windows" && info.Mode()&0111 == 0) {
+				continue
+			}
+			plugins = append(plugins, &PluginGenerator{ID: id, Path: filepath.Join(dir, entry.Name())})
+		}
+	}
+
+	return plugins
+}
+
+func pluginIdFromFileName(name string) (string, bool) {
+	if runtime.GOOS == "windows" {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	id, ok := strings.CutPrefix(name, PluginPrefix)
+	return id, ok && id != ""
+}
+
+func (g *PluginGenerator) Id() string {
+	return g.ID
+}
+
+func (g *PluginGenerator) Description() string {
+	return "Generator plugin " + g.Path
+}
+
+func (g *PluginGenerator) Generate(opts GenerateOpts) error {
+	// check opts
+	if opts.Doc == nil {
+		return fmt.Errorf("document is required")
+	}
+
+	// set packages
+	if opts.PackageConfig == (CommonPackages{}) {
+		opts.PackageConfig = CommonPackages{
+			Root:       "client",
+			Client:     "client",
+			Models:     "models",
+			Responses:  "responses",
+			Enums:      "enums",
+			Operations: "operations",
+			Auth:       "auth",
+		}
+	}
+
+	// build template data
+	templateData, err := g.TemplateData(TemplateDataOpts{
+		Doc:           opts.Doc,
+		PackageConfig: opts.PackageConfig,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build template data in %s: %w", g.Id(), err)
+	}
+
+	// run plugin
+	response, err := g.run(PluginRequest{
+		ProtocolVersion: PluginProtocolVersion,
+		Generator:       g.ID,
+		TemplateData:    templateData,
+		Opts:            opts,
+	})
+	if err != nil {
+		return err
+	}
+
+	// write files, files with unchanged content are not written again
+	dryRun := opts.DryRun || opts.Check
+	checksums := ChecksumsListedInMetadata(opts.OutputDir)
+	files := make(map[string]templateapi.RenderedFile)
+	for _, f := range response.Files {
+		targetFile, err := pluginTargetFile(opts.OutputDir, f.Path)
+		if err != nil {
+			return err
+		}
+
+		checksum := templateapi.Checksum([]byte(f.Content))
+		state := templateapi.FileDryRun
+		if template.IsUnchanged(targetFile, checksum, checksums) {
+			state = templateapi.FileUnchanged
+		} else if !dryRun {
+			if err = os.MkdirAll(filepath.Dir(targetFile), 0755); err != nil {
+				return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(targetFile), err)
+			}
+			if err = os.WriteFile(targetFile, []byte(f.Content), 0644); err != nil {
+				return fmt.Errorf("failed to write rendered file %s: %w", targetFile, err)
+			}
+			state = templateapi.FileRendered
+		}
+		files[targetFile] = templateapi.RenderedFile{File: targetFile, State: state, Checksum: checksum}
+	}
+	slog.Info(fmt.Sprintf("Generated %d files", len(files)), "plugin", g.Path)
+
+	// check mode, fail if any file would be written or removed
+	if opts.Check {
+		if stale := StaleFiles(opts.OutputDir, files); len(stale) > 0 {
+			return errors.Join(ErrStaleOutput, fmt.Errorf("files: %s", strings.Join(stale, ", ")))
+		}
+	}
+
+	// delete old files (oldfiles - files)
+	for _, f := range FilesListedInMetadata(opts.OutputDir) {
+		if _, ok := files[f]; !ok {
+			slog.Debug("Removing obsolete file", "file", f)
+			if !dryRun {
+				if err = RemoveGeneratedFile(opts.OutputDir, f); err != nil {
+					return fmt.Errorf("failed to remove generated file: %w", err)
+				}
+			}
+		}
+	}
+
+	// write metadata
+	if !dryRun {
+		if err = WriteMetadata(opts.OutputDir, files); err != nil {
+			return errors.Join(ErrFailedToWriteMetadata, err)
+		}
+	}
+
+	return nil
+}
+
+// run passes the request to the plugin, the stderr of the plugin is forwarded
+func (g *PluginGenerator) run(request PluginRequest) (PluginResponse, error) {
+	input, err := json.Marshal(request)
+	if err != nil {
+		return PluginResponse{}, errors.Join(ErrPluginFailed, fmt.Errorf("failed to marshal plugin request: %w", err))
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.Command(g.Path)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	slog.Debug("running generator plugin", "plugin", g.Path)
+	if err = cmd.Run(); err != nil {
+		return PluginResponse{}, errors.Join(ErrPluginFailed, fmt.Errorf("plugin: %s", g.Path), err)
+	}
+
+	var response PluginResponse
+	if err = json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return PluginResponse{}, errors.Join(ErrPluginInvalidResponse, fmt.Errorf("plugin: %s", g.Path), err)
+	}
+	return response, nil
+}
+
+// pluginTargetFile resolves the file path of the plugin response, paths must stay within the output directory
+func pluginTargetFile(outputDir string, file string) (string, error) {
+	file = filepath.Clean(filepath.FromSlash(file))
+	if file == "." || filepath.IsAbs(file) || file == ".." || strings.HasPrefix(file, ".."+string(filepath.Separator)) {
+		return "", errors.Join(ErrPluginInvalidFilePath, fmt.Errorf("path: %s", file))
+	}
+	return filepath.Join(outputDir, file), nil
+}
+
+func (g *PluginGenerator) TemplateData(opts TemplateDataOpts) (DocumentModel, error) {
+	return BuildTemplateData(opts.Doc, g, opts.PackageConfig)
+}
+
+func (g *PluginGenerator) ToClassName(name string) string {
+	return util.ToPascalCase(name)
+}
+
+func (g *PluginGenerator) ToFunctionName(name string) string {
+	return util.ToCamelCase(name)
+}
+
+func (g *PluginGenerator) ToPropertyName(name string) string {
+	return util.ToCamelCase(name)
+}
+
+func (g *PluginGenerator) ToParameterName(name string) string {
+	return util.ToCamelCase(name)
+}
+
+func (g *PluginGenerator) ToConstantName(name string) string {
+	return util.ToUpperSnakeCase(name)
+}
+
+// ToCodeType returns the openapi type names (string, integer, number, boolean), arrays and maps use the TypeArgs and objects the class name
+func (g *PluginGenerator) ToCodeType(schema *base.Schema, schemaType CodeTypeSchemaType, required bool) (CodeType, error) {
+	if schema == nil {
+		return DefaultCodeType, fmt.Errorf("schema is nil")
+	}
+
+	switch {
+	case util.CountExcluding(schema.Type, "null") > 1:
+		return CodeType{Name: "any"}, nil
+	case slices.Contains(schema.Type, "string"), slices.Contains(schema.Type, "integer"), slices.Contains(schema.Type, "number"), slices.Contains(schema.Type, "boolean"):
+		for _, t := range schema.Type {
+			if t != "null" {
+				return NewSimpleCodeType(t, schema), nil
+			}
+		}
+		return CodeType{Name: "any"}, nil
+	case slices.Contains(schema.Type, "array"):
+		if schema.Items == nil || schema.Items.A == nil {
+			return DefaultCodeType, fmt.Errorf("array schema missing items definition")
+		}
+		itemType, err := g.ToCodeType(schema.Items.A.Schema(), schemaType, true)
+		if err != nil {
+			return DefaultCodeType, err
+		}
+		return NewArrayCodeType(itemType, schema), nil
+	case schema.AdditionalProperties != nil && schema.AdditionalProperties.IsA() && schema.Properties == nil:
+		valueType, err := g.ToCodeType(schema.AdditionalProperties.A.Schema(), schemaType, true)
+		if err != nil {
+			return DefaultCodeType, err
+		}
+		return NewMapCodeType(NewSimpleCodeType("string", schema), valueType, schema), nil
+	case schema.Title != "":
+		return CodeType{Name: g.ToClassName(schema.Title), ImportPath: "models"}, nil
+	default:
+		return CodeType{Name: "any"}, nil
+	}
+}
+
+func (g *PluginGenerator) PostProcessType(codeType CodeType) CodeType {
+	if codeType.IsPostProcessed {
+		return codeType
+	}
+
+	for i, typeArg := range codeType.TypeArgs {
+		codeType.TypeArgs[i] = g.PostProcessType(typeArg)
+	}
+	codeType.Declaration = codeType.Name
+	codeType.QualifiedDeclaration = codeType.Name
+	codeType.Type = codeType.Name
+	codeType.QualifiedType = codeType.Name
+	codeType.IsPostProcessed = true
+
+	return codeType
+}
+
+func (g *PluginGenerator) IsPrimitiveType(input string) bool {
+	return slices.Contains([]string{"string", "integer", "number", "boolean"}, input)
+}
+
+func (g *PluginGenerator) TypeToImport(iType CodeType) string {
+	return ""
+}
@@ -0,0 +1,151 @@
+package openapigenerator_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
+	"github.com/primelib/primecodegen/pkg/openapi/openapigenerator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePlugin creates a plugin on PATH that stores the request next to the executable and prints the response
+func writePlugin(t *testing.T, id string, response string) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin test uses a shell script")
+	}
+
+	dir := t.TempDir()
+	script := "#!/bin/sh\ncat > \"$(dirname \"$0\")/request.json\"\ncat <<'EOF'\n" + response + "\nEOF\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, openapigenerator.PluginPrefix+id), []byte(script), 0755))
+	// not executable, must be ignored
+	require.NoError(t, os.WriteFile(filepath.Join(dir, openapigenerator.PluginPrefix+"disabled"), []byte(script), 0644))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	return dir
+}
+
+func TestPluginGenerator(t *testing.T) {
+	pluginDir := writePlugin(t, "cobol", `{"Files": [{"Path": "src/PETS.cbl", "Content": "IDENTIFICATION DIVISION."}]}`)
+	outputDir := t.TempDir()
+
+	// discovery
+	var ids []string
+	for _, p := range openapigenerator.DiscoverPlugins() {
+		ids = append(ids, p.Id())
+	}
+	assert.Contains(t, ids, "cobol")
+	assert.NotContains(t, ids, "disabled")
+
+	// generator lookup falls back to plugins
+	gen, err := openapigenerator.GeneratorById("cobol", nil)
+	require.NoError(t, err)
+	_, err = openapigenerator.GeneratorById("disabled", nil)
+	assert.Error(t, err)
+
+	// generate
+	doc := openapidocument.OpenV3DocumentForTest([]byte(webhookSpec))
+	require.NotNil(t, doc)
+	err = gen.Generate(openapigenerator.GenerateOpts{Doc: doc, OutputDir: outputDir, ArtifactId: "pets-client"})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "src", "PETS.cbl"))
+	require.NoError(t, err)
+	assert.Equal(t, "IDENTIFICATION DIVISION.", string(content))
+	assert.Equal(t, []string{filepath.Join(outputDir, "src/PETS.cbl")}, openapigenerator.FilesListedInMetadata(outputDir))
+
+	// request
+	requestContent, err := os.ReadFile(filepath.Join(pluginDir, "request.json"))
+	require.NoError(t, err)
+	var request openapigenerator.PluginRequest
+	require.NoError(t, json.Unmarshal(requestContent, &request))
+	assert.Equal(t, openapigenerator.PluginProtocolVersion, request.ProtocolVersion)
+	assert.Equal(t, "cobol", request.Generator)
+	assert.Equal(t, "pets-client", request.Opts.ArtifactId)
+	assert.Equal(t, "Pets", request.TemplateData.Name)
+	require.Len(t, request.TemplateData.Models, 1)
+	assert.Equal(t, "Pet", request.TemplateData.Models[0].Name)
+	require.Len(t, request.TemplateData.Models[0].Properties, 1)
+	assert.Equal(t, "string", request.TemplateData.Models[0].Properties[0].Type.Name)
+}
+
+func TestPluginGenerator_InvalidResponse(t *testing.T) {
+	doc := openapidocument.OpenV3DocumentForTest([]byte(webhookSpec))
+	require.NotNil(t, doc)
+
+	tests := []struct {
+		name     string
+		response string
+		err      error
+	}{
+		{name: "no json", response: "hello", err: openapigenerator.ErrPluginInvalidResponse},
+		{name: "file outside of output dir", response: `{"Files": [{"Path": "../escape.txt", "Content": ""}]}`, err: openapigenerator.ErrPluginInvalidFilePath},
+		{name: "absolute file", response: `{"Files": [{"Path": "/tmp/escape.txt", "Content": ""}]}`, err: openapigenerator.ErrPluginInvalidFilePath},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writePlugin(t, "broken", tt.response)
+
+			gen, err := openapigenerator.GeneratorById("broken", nil)
+			require.NoError(t, err)
+
+			err = gen.Generate(openapigenerator.GenerateOpts{Doc: doc, OutputDir: t.TempDir()})
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestPluginGenerator_Incremental(t *testing.T) {
+	writePlugin(t, "cobol", `{"Files": [{"Path": "src/PETS.cbl", "Content": "IDENTIFICATION DIVISION."}]}`)
+	outputDir := t.TempDir()
+	doc := openapidocument.OpenV3DocumentForTest([]byte(webhookSpec))
+	require.NotNil(t, doc)
+	gen, err := openapigenerator.GeneratorById("cobol", nil)
+	require.NoError(t, err)
+
+	// check fails before the first run
+	err = gen.Generate(openapigenerator.GenerateOpts{Doc: doc, OutputDir: outputDir, Check: true})
+	assert.ErrorIs(t, err, openapigenerator.ErrStaleOutput)
+	assert.NoFileExists(t, filepath.Join(outputDir, "src", "PETS.cbl"))
+
+	require.NoError(t, gen.Generate(openapigenerator.GenerateOpts{Doc: doc, OutputDir: outputDir}))
+	targetFile := filepath.Join(outputDir, "src", "PETS.cbl")
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(targetFile, modTime, modTime))
+
+	// unchanged files are not written again
+	require.NoError(t, gen.Generate(openapigenerator.GenerateOpts{Doc: doc, OutputDir: outputDir}))
+	info, err := os.Stat(targetFile)
+	require.NoError(t, err)
+	assert.Equal(t, modTime, info.ModTime())
+	assert.Equal(t, []string{targetFile}, openapigenerator.FilesListedInMetadata(outputDir))
+
+	// check passes on up-to-date output
+	require.NoError(t, gen.Generate(openapigenerator.GenerateOpts{Doc: doc, OutputDir: outputDir, Check: true}))
+
+	// check fails if a generated file was edited by hand, generating again restores it
+	require.NoError(t, os.WriteFile(targetFile, []byte("IDENTIFICATION DIVISION. EDITED"), 0644))
+	err = gen.Generate(openapigenerator.GenerateOpts{Doc: doc, OutputDir: outputDir, Check: true})
+	assert.ErrorIs(t, err, openapigenerator.ErrStaleOutput)
+	require.NoError(t, gen.Generate(openapigenerator.GenerateOpts{Doc: doc, OutputDir: outputDir}))
+	content, err := os.ReadFile(targetFile)
+	require.NoError(t, err)
+	assert.Equal(t, "IDENTIFICATION DIVISION.", string(content))
+	require.NoError(t, gen.Generate(openapigenerator.GenerateOpts{Doc: doc, OutputDir: outputDir, Check: true}))
+
+	// check fails if the output changes, without touching the output directory
+	writePlugin(t, "cobol", `{"Files": [{"Path": "src/PETS.cbl", "Content": "PROCEDURE DIVISION."}]}`)
+	gen, err = openapigenerator.GeneratorById("cobol", nil)
+	require.NoError(t, err)
+	err = gen.Generate(openapigenerator.GenerateOpts{Doc: doc, OutputDir: outputDir, Check: true})
+	assert.ErrorIs(t, err, openapigenerator.ErrStaleOutput)
+	content, err = os.ReadFile(targetFile)
+	require.NoError(t, err)
+	assert.Equal(t, "IDENTIFICATION DIVISION.", string(content))
+}
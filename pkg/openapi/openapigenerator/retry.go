@@ -0,0 +1,136 @@
+package openapigenerator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pb33f/libopenapi/orderedmap"
+	"go.yaml.in/yaml/v4"
+)
+
+// DefaultRetryPolicy is used for all values that are not set in the x-retries extension
+var DefaultRetryPolicy = RetryPolicy{
+	StatusCodes:       []int{408, 429, 502, 503, 504},
+	MaxAttempts:       3,
+	InitialBackoffMs:  500,
+	MaxBackoffMs:      30000,
+	Multiplier:        2,
+	RespectRetryAfter: true,
+}
+
+// noRetryPolicy is used for operations that must not be retried, it sends the request exactly once
+var noRetryPolicy = RetryPolicy{
+	StatusCodes: []int{},
+	MaxAttempts: 1,
+	Multiplier:  1,
+}
+
+// idempotentMethods are retried with the retry policy of the document, all other methods have to opt in with their own x-retries
+var idempotentMethods = []string{"get", "head", "put", "delete", "options"}
+
+// retriesExtension is the content of the x-retries document or operation extension
+//
+// Example:
+//
+//	x-retries:
+//	  statusCodes: [429, 503]
+//	  maxAttempts: 3
+//	  backoff:
+//	    initial: 500ms
+//	    max: 30s
+//	    multiplier: 2
+//	  respectRetryAfter: true
+type retriesExtension struct {
+	StatusCodes []int `yaml:"statusCodes"`
+	MaxAttempts *int  `yaml:"maxAttempts"`
+	Backoff     struct {
+		Initial    string   `yaml:"initial"`
+		Max        string   `yaml:"max"`
+		Multiplier *float64 `yaml:"multiplier"`
+	} `yaml:"backoff"`
+	RespectRetryAfter *bool `yaml:"respectRetryAfter"`
+}
+
+// buildRetryPolicy parses the x-retries extension on top of the parent policy, returns nil if the extension is not present
+func buildRetryPolicy(extensions *orderedmap.Map[string, *yaml.Node], parent *RetryPolicy) (*RetryPolicy, error) {
+	if extensions == nil {
+		return nil, nil
+	}
+	node, ok := extensions.Get("x-retries")
+	if !ok || node == nil {
+		return nil, nil
+	}
+
+	var ext retriesExtension
+	if err := node.Decode(&ext); err != nil {
+		return nil, fmt.Errorf("unable to decode x-retries: %w", err)
+	}
+
+	policy := DefaultRetryPolicy
+	if parent != nil {
+		policy = *parent
+	}
+	policy.StatusCodes = append([]int(nil), policy.StatusCodes...)
+
+	if ext.StatusCodes != nil {
+		for _, code := range ext.StatusCodes {
+			if code < 100 || code > 599 {
+				return nil, fmt.Errorf("x-retries status code [%d] is not a valid http status code", code)
+			}
+		}
+		policy.StatusCodes = ext.StatusCodes
+	}
+	if ext.MaxAttempts != nil {
+		if *ext.MaxAttempts < 1 {
+			return nil, fmt.Errorf("x-retries maxAttempts must be at least 1, got %d", *ext.MaxAttempts)
+		}
+		policy.MaxAttempts = *ext.MaxAttempts
+	}
+	if ext.Backoff.Initial != "" {
+		d, err := time.ParseDuration(ext.Backoff.Initial)
+		if err != nil {
+			return nil, fmt.Errorf("x-retries backoff.initial: %w", err)
+		}
+		policy.InitialBackoffMs = d.Milliseconds()
+	}
+	if ext.Backoff.Max != "" {
+		d, err := time.ParseDuration(ext.Backoff.Max)
+		if err != nil {
+			return nil, fmt.Errorf("x-retries backoff.max: %w", err)
+		}
+		policy.MaxBackoffMs = d.Milliseconds()
+	}
+	if ext.Backoff.Multiplier != nil {
+		if *ext.Backoff.Multiplier < 1 {
+			return nil, fmt.Errorf("x-retries backoff.multiplier must be at least 1, got %v", *ext.Backoff.Multiplier)
+		}
+		policy.Multiplier = *ext.Backoff.Multiplier
+	}
+	if ext.RespectRetryAfter != nil {
+		policy.RespectRetryAfter = *ext.RespectRetryAfter
+	}
+	if policy.MaxBackoffMs < policy.InitialBackoffMs {
+		return nil, fmt.Errorf("x-retries backoff.max must not be lower than backoff.initial")
+	}
+
+	return &policy, nil
+}
+
+// buildOperationRetryPolicy returns the retry policy of an operation, nil if the operation uses the policy of the document
+//
+// Requests with a non-idempotent method (e.g. POST, PATCH) are not retried unless the operation defines its own x-retries,
+// a retried request could otherwise be processed twice by the server.
+func buildOperationRetryPolicy(method string, extensions *orderedmap.Map[string, *yaml.Node], parent *RetryPolicy) (*RetryPolicy, error) {
+	policy, err := buildRetryPolicy(extensions, parent)
+	if err != nil || policy != nil {
+		return policy, err
+	}
+	if parent == nil || slices.Contains(idempotentMethods, strings.ToLower(method)) {
+		return nil, nil
+	}
+
+	single := noRetryPolicy
+	return &single, nil
+}
@@ -0,0 +1,108 @@
+package openapigenerator_test
+
+import (
+	"testing"
+
+	openapi_go "github.com/primelib/primecodegen/pkg/generator/openapi-go"
+	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
+	"github.com/primelib/primecodegen/pkg/openapi/openapigenerator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const retriesSpec = `
+openapi: 3.0.3
+info:
+  title: Retries
+  version: 1.0.0
+  x-name: retries
+x-retries:
+  statusCodes: [429, 503]
+  maxAttempts: 4
+  backoff:
+    initial: 250ms
+    max: 5s
+paths:
+  /items:
+    get:
+      operationId: listItems
+      responses:
+        "204":
+          description: ok
+    post:
+      operationId: createItem
+      x-retries:
+        maxAttempts: 1
+        respectRetryAfter: false
+      responses:
+        "204":
+          description: ok
+  /items/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    put:
+      operationId: replaceItem
+      responses:
+        "204":
+          description: ok
+    patch:
+      operationId: updateItem
+      responses:
+        "204":
+          description: ok
+`
+
+func TestBuildTemplateDataRetries(t *testing.T) {
+	doc := openapidocument.OpenV3DocumentForTest([]byte(retriesSpec))
+	require.NotNil(t, doc)
+
+	data, err := openapigenerator.BuildTemplateData(doc, openapi_go.NewGenerator(), openapigenerator.CommonPackages{})
+	require.NoError(t, err)
+
+	require.NotNil(t, data.Retries)
+	assert.Equal(t, []int{429, 503}, data.Retries.StatusCodes)
+	assert.Equal(t, 4, data.Retries.MaxAttempts)
+	assert.Equal(t, int64(250), data.Retries.InitialBackoffMs)
+	assert.Equal(t, int64(5000), data.Retries.MaxBackoffMs)
+	assert.Equal(t, float64(2), data.Retries.Multiplier)
+	assert.True(t, data.Retries.RespectRetryAfter)
+
+	require.Len(t, data.Operations, 4)
+	assert.Nil(t, data.Operations[0].Retries)
+	override := data.Operations[1].Retries
+	require.NotNil(t, override)
+	assert.Equal(t, []int{429, 503}, override.StatusCodes)
+	assert.Equal(t, 1, override.MaxAttempts)
+	assert.False(t, override.RespectRetryAfter)
+
+	// idempotent methods use the policy of the document, other methods are sent once unless they opt in
+	assert.Equal(t, "ReplaceItem", data.Operations[2].Name)
+	assert.Nil(t, data.Operations[2].Retries)
+	assert.Equal(t, "UpdateItem", data.Operations[3].Name)
+	single := data.Operations[3].Retries
+	require.NotNil(t, single)
+	assert.Empty(t, single.StatusCodes)
+	assert.Equal(t, 1, single.MaxAttempts)
+	assert.False(t, single.RespectRetryAfter)
+}
+
+func TestBuildTemplateDataInvalidRetries(t *testing.T) {
+	doc := openapidocument.OpenV3DocumentForTest([]byte(`
+openapi: 3.0.3
+info:
+  title: Retries
+  version: 1.0.0
+  x-name: retries
+x-retries:
+  maxAttempts: 0
+paths: {}
+`))
+	require.NotNil(t, doc)
+
+	_, err := openapigenerator.BuildTemplateData(doc, openapi_go.NewGenerator(), openapigenerator.CommonPackages{})
+	assert.ErrorContains(t, err, "maxAttempts must be at least 1")
+}
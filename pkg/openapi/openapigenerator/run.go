@@ -1,6 +1,7 @@
 package openapigenerator
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// GeneratorById returns the generator with the given id, falls back to a generator plugin on PATH if no built-in generator matches
 func GeneratorById(id string, allGenerators []CodeGenerator) (CodeGenerator, error) {
 	for _, g := range allGenerators {
 		if g.Id() == id {
@@ -21,6 +23,10 @@ func GeneratorById(id string, allGenerators []CodeGenerator) (CodeGenerator, err
 		}
 	}
 
+	if plugin, err := LookupPlugin(id); err == nil {
+		return plugin, nil
+	}
+
 	return nil, fmt.Errorf("generator with id %s not found", id)
 }
 
@@ -53,6 +59,8 @@ func GenerateFiles(templateId string, outputDir string, templateData DocumentMod
 		Operations:          templateData.Operations,
 		Models:              templateData.Models,
 		Enums:               templateData.Enums,
+		Webhooks:            templateData.Webhooks,
+		Retries:             templateData.Retries,
 	}
 	metadata := Metadata{
 		ArtifactGroupId:  generatorOpts.ArtifactGroupId,
@@ -66,6 +74,7 @@ func GenerateFiles(templateId string, outputDir string, templateData DocumentMod
 		RepositoryUrl:    generatorOpts.RepositoryUrl,
 		LicenseName:      generatorOpts.LicenseName,
 		LicenseUrl:       generatorOpts.LicenseUrl,
+		Maintainers:      generatorOpts.Maintainers,
 		GeneratorNames:   generatorOpts.GeneratorNames,
 		GeneratorOutputs: generatorOpts.GeneratorOutputs,
 	}
@@ -121,8 +130,32 @@ func GenerateFiles(templateId string, outputDir string, templateData DocumentMod
 		})
 	}
 
+	for _, webhook := range templateData.Webhooks {
+		data = append(data, WebhookEachTemplate{
+			Metadata: metadata,
+			Common:   common,
+			Package:  common.Packages.Client,
+			Name:     webhook.Name,
+			Webhook:  webhook,
+		})
+	}
+
+	// resolve template, a template directory overrides the embedded template
+	templateConfig, err := template.ResolveTemplate(templateId, generatorOpts.TemplateDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// checksums of the previous run, files with unchanged content are not written again
+	if renderOpts.Checksums == nil {
+		renderOpts.Checksums = ChecksumsListedInMetadata(outputDir)
+	}
+	if generatorOpts.Check {
+		renderOpts.DryRun = true
+	}
+
 	// render files
-	slog.Debug("rendering template files", "templateId", templateId, "outputDir", outputDir, "files", len(data))
+	slog.Debug("rendering template files", "templateId", templateConfig.ID, "templateDir", templateConfig.Dir, "outputDir", outputDir, "files", len(data))
 	var waitGroup sync.WaitGroup
 	sem := make(chan struct{}, 6)
 	errCh := make(chan error, 1)
@@ -140,17 +173,19 @@ func GenerateFiles(templateId string, outputDir string, templateData DocumentMod
 
 			switch d.(type) {
 			case SupportOnceTemplate:
-				renderedFiles, renderErr = template.RenderTemplateById(templateId, outputDir, templateapi.TypeSupportOnce, d, renderOpts)
+				renderedFiles, renderErr = template.RenderTemplate(templateConfig, outputDir, templateapi.TypeSupportOnce, d, renderOpts)
 			case APIOnceTemplate:
-				renderedFiles, renderErr = template.RenderTemplateById(templateId, outputDir, templateapi.TypeAPIOnce, d, renderOpts)
+				renderedFiles, renderErr = template.RenderTemplate(templateConfig, outputDir, templateapi.TypeAPIOnce, d, renderOpts)
 			case APIEachTemplate:
-				renderedFiles, renderErr = template.RenderTemplateById(templateId, outputDir, templateapi.TypeAPIEach, d, renderOpts)
+				renderedFiles, renderErr = template.RenderTemplate(templateConfig, outputDir, templateapi.TypeAPIEach, d, renderOpts)
 			case OperationEachTemplate:
-				renderedFiles, renderErr = template.RenderTemplateById(templateId, outputDir, templateapi.TypeOperationEach, d, renderOpts)
+				renderedFiles, renderErr = template.RenderTemplate(templateConfig, outputDir, templateapi.TypeOperationEach, d, renderOpts)
 			case ModelEachTemplate:
-				renderedFiles, renderErr = template.RenderTemplateById(templateId, outputDir, templateapi.TypeModelEach, d, renderOpts)
+				renderedFiles, renderErr = template.RenderTemplate(templateConfig, outputDir, templateapi.TypeModelEach, d, renderOpts)
 			case EnumEachTemplate:
-				renderedFiles, renderErr = template.RenderTemplateById(templateId, outputDir, templateapi.TypeEnumEach, d, renderOpts)
+				renderedFiles, renderErr = template.RenderTemplate(templateConfig, outputDir, templateapi.TypeEnumEach, d, renderOpts)
+			case WebhookEachTemplate:
+				renderedFiles, renderErr = template.RenderTemplate(templateConfig, outputDir, templateapi.TypeWebhookEach, d, renderOpts)
 			}
 
 			if renderErr != nil {
@@ -176,5 +211,12 @@ func GenerateFiles(templateId string, outputDir string, templateData DocumentMod
 	default:
 	}
 
+	// check mode, fail if any file would be written or removed
+	if generatorOpts.Check {
+		if stale := StaleFiles(outputDir, files); len(stale) > 0 {
+			return nil, errors.Join(ErrStaleOutput, fmt.Errorf("files: %s", strings.Join(stale, ", ")))
+		}
+	}
+
 	return files, nil
 }
@@ -0,0 +1,75 @@
+package openapigenerator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// streamingMediaTypes maps the supported streaming media types to the format of their items
+var streamingMediaTypes = map[string]StreamingFormat{
+	"text/event-stream":       StreamingFormatSSE,
+	"application/x-ndjson":    StreamingFormatNDJSON,
+	"application/ndjson":      StreamingFormatNDJSON,
+	"application/jsonl":       StreamingFormatNDJSON,
+	"application/x-jsonlines": StreamingFormatNDJSON,
+}
+
+// buildStreaming detects streaming media types in the successful responses of an operation, returns nil if the operation does not stream
+func buildStreaming(gen CodeGenerator, op *v3.Operation) (*Streaming, error) {
+	if op.Responses == nil || op.Responses.Codes == nil {
+		return nil, nil
+	}
+
+	for _, code := range []string{"200", "201"} {
+		resp, ok := op.Responses.Codes.Get(code)
+		if !ok || resp.Content == nil {
+			continue
+		}
+
+		for content := resp.Content.First(); content != nil; content = content.Next() {
+			mediaType := strings.ToLower(strings.TrimSpace(strings.Split(content.Key(), ";")[0]))
+			format, ok := streamingMediaTypes[mediaType]
+			if !ok {
+				continue
+			}
+
+			itemType, err := streamingItemType(gen, content.Value())
+			if err != nil {
+				return nil, fmt.Errorf("error converting item type of %s response: %w", mediaType, err)
+			}
+
+			return &Streaming{
+				Format:       format,
+				MediaType:    content.Key(),
+				ResponseCode: code,
+				ItemType:     itemType,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// streamingItemType returns the type of a single event or line, the items of an array schema describe a single item
+func streamingItemType(gen CodeGenerator, mediaType *v3.MediaType) (CodeType, error) {
+	var schema *base.Schema
+	if mediaType != nil && mediaType.Schema != nil {
+		schema = mediaType.Schema.Schema()
+	}
+	if schema == nil {
+		schema = &base.Schema{Type: []string{"string"}}
+	}
+	if slices.Contains(schema.Type, "array") && schema.Items != nil && schema.Items.IsA() {
+		schema = schema.Items.A.Schema()
+	}
+
+	itemType, err := gen.ToCodeType(schema, CodeTypeSchemaResponse, false)
+	if err != nil {
+		return CodeType{}, err
+	}
+	return gen.PostProcessType(itemType), nil
+}
@@ -0,0 +1,100 @@
+package openapigenerator_test
+
+import (
+	"testing"
+
+	openapi_go "github.com/primelib/primecodegen/pkg/generator/openapi-go"
+	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
+	"github.com/primelib/primecodegen/pkg/openapi/openapigenerator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const streamingSpec = `
+openapi: 3.0.3
+info:
+  title: Streaming
+  version: 1.0.0
+  x-name: streaming
+paths:
+  /events:
+    get:
+      operationId: listEvents
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Event'
+            text/event-stream:
+              schema:
+                $ref: '#/components/schemas/Event'
+  /logs:
+    get:
+      operationId: tailLogs
+      responses:
+        "200":
+          description: ok
+          content:
+            application/x-ndjson:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Event'
+  /ticks:
+    get:
+      operationId: ticks
+      responses:
+        "200":
+          description: ok
+          content:
+            text/event-stream:
+              schema:
+                type: string
+  /status:
+    get:
+      operationId: status
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Event'
+components:
+  schemas:
+    Event:
+      title: Event
+      type: object
+      properties:
+        id:
+          type: string
+`
+
+func TestBuildTemplateDataStreaming(t *testing.T) {
+	doc := openapidocument.OpenV3DocumentForTest([]byte(streamingSpec))
+	require.NotNil(t, doc)
+
+	data, err := openapigenerator.BuildTemplateData(doc, openapi_go.NewGenerator(), openapigenerator.CommonPackages{})
+	require.NoError(t, err)
+	require.Len(t, data.Operations, 4)
+
+	events := data.Operations[0].Streaming
+	require.NotNil(t, events)
+	assert.Equal(t, openapigenerator.StreamingFormatSSE, events.Format)
+	assert.Equal(t, "text/event-stream", events.MediaType)
+	assert.Equal(t, "200", events.ResponseCode)
+	assert.Equal(t, "Event", events.ItemType.Name)
+
+	logs := data.Operations[1].Streaming
+	require.NotNil(t, logs)
+	assert.Equal(t, openapigenerator.StreamingFormatNDJSON, logs.Format)
+	assert.Equal(t, "Event", logs.ItemType.Name)
+
+	ticks := data.Operations[2].Streaming
+	require.NotNil(t, ticks)
+	assert.Equal(t, "string", ticks.ItemType.Type)
+
+	assert.Nil(t, data.Operations[3].Streaming)
+}
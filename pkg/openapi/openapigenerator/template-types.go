@@ -11,6 +11,8 @@ type GlobalTemplate struct {
 	Operations          []Operation
 	Models              []Model
 	Enums               []Enum
+	Webhooks            []Webhook
+	Retries             *RetryPolicy
 }
 
 func (g GlobalTemplate) HasParametersWithType(paramType string) bool {
@@ -76,3 +78,11 @@ type EnumEachTemplate struct {
 	Name     string
 	Enum     Enum
 }
+
+type WebhookEachTemplate struct {
+	Metadata Metadata // Metadata for the template, like artifact group and ID
+	Common   GlobalTemplate
+	Package  string
+	Name     string
+	Webhook  Webhook
+}
@@ -1,11 +1,13 @@
 package openapigenerator
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/pb33f/libopenapi/orderedmap"
 	"go.yaml.in/yaml/v4"
 
+	"github.com/primelib/primecodegen/pkg/app/appconf"
 	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
 )
 
@@ -24,7 +26,9 @@ type DocumentModel struct {
 	OperationsByTag  map[string][]Operation
 	Models           []Model
 	Enums            []Enum
+	Webhooks         []Webhook      // Webhooks are the requests sent by the API to the consumer, from the webhooks section and operation callbacks
 	Packages         CommonPackages // Packages holds the import paths for output packages
+	Retries          *RetryPolicy   // Retries is the default retry policy of the client (x-retries), nil if the document does not define one
 }
 
 type CommonPackages struct {
@@ -44,13 +48,14 @@ type Metadata struct {
 	DisplayName      string // DisplayName is the human-readable name of the library, can be used in generated code
 	Title            string // Title is the human-readable title of the library, can be used in documentation and comments
 	Description      string
-	APISpecVersion   string   // APISpecVersion is the version of the API specification (e.g. version in the OpenAPI document info section)
-	GeneratorVersion string   // GeneratorVersion is the version of the code generator
-	RepositoryUrl    string   // RepositoryUrl is the URL to the repository (without protocol or .git suffix)
-	LicenseName      string   // LicenseName is the name of the license (MIT, Apache-2.0, etc.)
-	LicenseUrl       string   // LicenseUrl is the URL to the license
-	GeneratorNames   []string // GeneratorNames is a list of all active generators
-	GeneratorOutputs []string // GeneratorOutputs is a list of all output directories for the active generators
+	APISpecVersion   string                   // APISpecVersion is the version of the API specification (e.g. version in the OpenAPI document info section)
+	GeneratorVersion string                   // GeneratorVersion is the version of the code generator
+	RepositoryUrl    string                   // RepositoryUrl is the URL to the repository (without protocol or .git suffix)
+	LicenseName      string                   // LicenseName is the name of the license (MIT, Apache-2.0, etc.)
+	LicenseUrl       string                   // LicenseUrl is the URL to the license
+	Maintainers      []appconf.MaintainerConf // Maintainers is a list of the library maintainers
+	GeneratorNames   []string                 // GeneratorNames is a list of all active generators
+	GeneratorOutputs []string                 // GeneratorOutputs is a list of all output directories for the active generators
 }
 
 // IsPublicLibrary returns true if the repository URL indicates a public version control hosting service
@@ -167,6 +172,10 @@ type Operation struct {
 	Tags                     []string                            `yaml:"tags,omitempty"`
 	ReturnType               CodeType                            `yaml:"returnType,omitempty"`
 	ReturnTypeByCode         map[string]*CodeType                `yaml:"returnTypeByCode,omitempty"`
+	ErrorTypesByCode         map[string]*CodeType                `yaml:"errorTypesByCode,omitempty"` // ErrorTypesByCode are the body types of the 4xx and 5xx responses, including ranges like 4XX
+	DefaultErrorType         *CodeType                           `yaml:"defaultErrorType,omitempty"` // DefaultErrorType is the body type of the default response, used for all undocumented error status codes
+	ErrorResponses           []ErrorResponse                     `yaml:"errorResponses,omitempty"`   // ErrorResponses are all error responses with a body, ordered by precedence: status codes, ranges and the default response
+	ErrorTypes               []CodeType                          `yaml:"errorTypes,omitempty"`       // ErrorTypes are the distinct body types of all error responses, without void types
 	Deprecated               bool                                `yaml:"deprecated,omitempty"`
 	DeprecatedReason         string                              `yaml:"deprecatedReason,omitempty"`
 	Parameters               []Parameter                         `yaml:"parameters,omitempty"`          // Parameters holds all parameters, including static ones that can not be overridden
@@ -185,10 +194,16 @@ type Operation struct {
 	MutableCookieParameter   []Parameter                         `yaml:"mutableCookieParameter,omitempty"`
 	ImmutableCookieParameter []Parameter                         `yaml:"immutableCookieParameter,omitempty"`
 	BodyParameter            *Parameter                          `yaml:"bodyParameter,omitempty"`
+	RequestContentTypes      []string                            `yaml:"requestContentTypes,omitempty"` // RequestContentTypes are all media types of the request body, the first one is used for the body parameter
+	RequestBodyRequired      bool                                `yaml:"requestBodyRequired,omitempty"` // RequestBodyRequired is the required flag of the request body, the body parameter itself is always required
+	Form                     *Form                               `yaml:"form,omitempty"`                // Form is set if the request body is multipart/form-data or application/x-www-form-urlencoded
 	Imports                  []string                            `yaml:"imports,omitempty"`
 	Documentation            []Documentation                     `yaml:"documentation,omitempty"`
 	Stability                string                              `yaml:"stability,omitempty"`
-	Extensions               *orderedmap.Map[string, *yaml.Node] `yaml:"extensions,omitempty"` // Extensions are custom extensions to the operation
+	Pagination               *Pagination                         `yaml:"pagination,omitempty"`          // Pagination is set if the operation returns its results in multiple pages (x-pagination)
+	Retries                  *RetryPolicy                        `yaml:"retries,omitempty"`             // Retries is set if the operation overrides the retry policy of the document (x-retries)
+	Streaming                *Streaming                          `yaml:"streaming,omitempty"`           // Streaming is set if the operation responds with a stream of items (text/event-stream, application/x-ndjson)
+	Extensions               *orderedmap.Map[string, *yaml.Node] `yaml:"extensions,omitempty" json:"-"` // Extensions are custom extensions to the operation, not passed to generator plugins
 }
 
 func (o *Operation) HasParametersWithType(paramType string) bool {
@@ -248,6 +263,132 @@ func (o *Operation) AddParameter(parameter Parameter) {
 	o.Path = strings.Replace(o.Path, "{"+parameter.FieldName+"}", "{"+parameter.Name+"}", -1)
 }
 
+type PaginationStyle string
+
+const (
+	PaginationStyleCursor PaginationStyle = "cursor" // PaginationStyleCursor passes an opaque cursor from the previous response to the next request
+	PaginationStyleOffset PaginationStyle = "offset" // PaginationStyleOffset advances the offset by the number of received items
+	PaginationStylePage   PaginationStyle = "page"   // PaginationStylePage increments the page number
+)
+
+// Pagination describes how to request all pages of an operation
+type Pagination struct {
+	Style          PaginationStyle `yaml:"style"`
+	Parameter      Parameter       `yaml:"parameter"`                // Parameter receives the cursor, offset or page number of the next request
+	LimitParameter *Parameter      `yaml:"limitParameter,omitempty"` // LimitParameter controls the page size, if supported
+	ResponseCode   string          `yaml:"responseCode"`             // ResponseCode is the status code of the response that contains a page
+	ResultsPath    []PropertyPath  `yaml:"resultsPath,omitempty"`    // ResultsPath leads from the response body to the list of items, empty if the body is the list
+	ResultsType    CodeType        `yaml:"resultsType"`              // ResultsType is the type of the list of items
+	ItemType       CodeType        `yaml:"itemType"`                 // ItemType is the type of a single item
+	NextCursorPath []PropertyPath  `yaml:"nextCursorPath,omitempty"` // NextCursorPath leads from the response body to the cursor of the next page, only used by the cursor style
+	NextCursorType CodeType        `yaml:"nextCursorType,omitempty"`
+}
+
+// PropertyPath is a single step when walking the properties of a response body
+type PropertyPath struct {
+	Name      string   `yaml:"name"`      // Name is the property name in the generated model
+	FieldName string   `yaml:"fieldName"` // FieldName is the original name of the property
+	Type      CodeType `yaml:"type"`
+	Nullable  bool     `yaml:"nullable,omitempty"`
+}
+
+// ErrorResponse is a documented error response of an operation
+type ErrorResponse struct {
+	Code           string   `yaml:"code"` // Code is the status code, a range like 4XX or default
+	Description    string   `yaml:"description,omitempty"`
+	ContentType    string   `yaml:"contentType,omitempty"`
+	Type           CodeType `yaml:"type"`
+	ProblemDetails bool     `yaml:"problemDetails,omitempty"` // ProblemDetails is set for application/problem+json bodies (RFC 9457)
+}
+
+// IsRange returns true if the error response covers a range of status codes, like 4XX
+func (e ErrorResponse) IsRange() bool {
+	return isStatusCodeRange(e.Code)
+}
+
+// IsDefault returns true for the default response of the operation
+func (e ErrorResponse) IsDefault() bool {
+	return e.Code == "default"
+}
+
+// Form describes a form request body, which is sent field by field instead of as serialized model
+type Form struct {
+	ContentType string     `yaml:"contentType"`
+	Multipart   bool       `yaml:"multipart,omitempty"` // Multipart is set for multipart/form-data, otherwise the body is application/x-www-form-urlencoded
+	Parts       []FormPart `yaml:"parts,omitempty"`     // Parts are the properties of the body schema
+}
+
+// FormPart is a single field of a form request body
+type FormPart struct {
+	Name        string   `yaml:"name"`      // Name is the property name in the generated model
+	FieldName   string   `yaml:"fieldName"` // FieldName is the name of the form field
+	Type        CodeType `yaml:"type"`
+	ItemType    CodeType `yaml:"itemType,omitempty"` // ItemType is the type of a single value, only set for arrays
+	Required    bool     `yaml:"required,omitempty"`
+	Nullable    bool     `yaml:"nullable,omitempty"`
+	IsArray     bool     `yaml:"isArray,omitempty"`     // IsArray is set if the field holds multiple values, which are sent as repeated fields
+	IsFile      bool     `yaml:"isFile,omitempty"`      // IsFile is set for binary fields, which are sent as file parts
+	IsJSON      bool     `yaml:"isJson,omitempty"`      // IsJSON is set for object fields, which are sent as json encoded parts
+	Explode     bool     `yaml:"explode,omitempty"`     // Explode repeats the field for each value of an array, otherwise the values are joined with commas
+	ContentType string   `yaml:"contentType,omitempty"` // ContentType of the part, from the encoding of the media type or derived from the schema
+}
+
+type StreamingFormat string
+
+const (
+	StreamingFormatSSE    StreamingFormat = "sse"    // StreamingFormatSSE are server-sent events, the data of each event is a single item
+	StreamingFormatNDJSON StreamingFormat = "ndjson" // StreamingFormatNDJSON is newline-delimited json, each line is a single item
+)
+
+// Streaming describes a response that delivers its items one by one
+type Streaming struct {
+	Format       StreamingFormat `yaml:"format"`
+	MediaType    string          `yaml:"mediaType"`    // MediaType is the content type of the streaming response, sent as Accept header
+	ResponseCode string          `yaml:"responseCode"` // ResponseCode is the status code of the streaming response
+	ItemType     CodeType        `yaml:"itemType"`     // ItemType is the type of a single event or line
+}
+
+// RetryPolicy describes when and how often a failed request is retried
+type RetryPolicy struct {
+	StatusCodes       []int   `yaml:"statusCodes"`       // StatusCodes are the response status codes that trigger a retry
+	MaxAttempts       int     `yaml:"maxAttempts"`       // MaxAttempts is the total number of attempts, including the first request
+	InitialBackoffMs  int64   `yaml:"initialBackoffMs"`  // InitialBackoffMs is the wait time before the first retry
+	MaxBackoffMs      int64   `yaml:"maxBackoffMs"`      // MaxBackoffMs caps the wait time between two attempts
+	Multiplier        float64 `yaml:"multiplier"`        // Multiplier is applied to the wait time after each retry
+	RespectRetryAfter bool    `yaml:"respectRetryAfter"` // RespectRetryAfter prefers the Retry-After response header over the computed wait time
+}
+
+// MultiplierLiteral returns the multiplier as floating point literal (e.g. 2.0), which is valid in all target languages
+func (p RetryPolicy) MultiplierLiteral() string {
+	literal := strconv.FormatFloat(p.Multiplier, 'f', -1, 64)
+	if !strings.Contains(literal, ".") {
+		literal += ".0"
+	}
+	return literal
+}
+
+// Webhook is a request that is sent by the API to the consumer, either a webhook of the document or a callback of an operation
+type Webhook struct {
+	Name             string      `yaml:"name"`                  // Name is the generated name of the webhook
+	Event            string      `yaml:"event"`                 // Event is the key in the webhooks section, or the callback name for callbacks
+	Method           string      `yaml:"method"`                // Method is the http method the request is sent with
+	Summary          string      `yaml:"summary,omitempty"`     // Short description
+	Description      string      `yaml:"description,omitempty"` // Long description
+	Expression       string      `yaml:"expression,omitempty"`  // Expression is the runtime expression of the callback url, empty for webhooks
+	Operation        string      `yaml:"operation,omitempty"`   // Operation is the name of the operation that registers the callback, empty for webhooks
+	ContentType      string      `yaml:"contentType,omitempty"` // ContentType is the media type of the payload, empty if the request has no body
+	PayloadType      CodeType    `yaml:"payloadType,omitempty"` // PayloadType is the type of the request body
+	Headers          []Parameter `yaml:"headers,omitempty"`     // Headers are the header parameters sent with the request
+	Imports          []string    `yaml:"imports,omitempty"`
+	Deprecated       bool        `yaml:"deprecated,omitempty"`
+	DeprecatedReason string      `yaml:"deprecatedReason,omitempty"`
+}
+
+// IsCallback returns true if the webhook is a callback of an operation
+func (w Webhook) IsCallback() bool {
+	return w.Operation != ""
+}
+
 type PathSegment struct {
 	Value         string
 	IsParameter   bool
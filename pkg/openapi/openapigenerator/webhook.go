@@ -0,0 +1,131 @@
+package openapigenerator
+
+import (
+	"fmt"
+
+	"github.com/cidverse/go-ptr"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// BuildWebhooks collects the webhooks of the document and the callbacks of all operations
+func BuildWebhooks(opts OperationOpts) ([]Webhook, error) {
+	var webhooks []Webhook
+	gen := opts.Generator
+
+	// webhooks
+	if opts.Doc.Model.Webhooks != nil {
+		for wh := opts.Doc.Model.Webhooks.Oldest(); wh != nil; wh = wh.Next() {
+			ops := wh.Value.GetOperations()
+			for op := ops.Oldest(); op != nil; op = op.Next() {
+				webhook, err := buildWebhook(gen, wh.Key, webhookName(wh.Key, op.Key, op.Value, ops), op.Key, op.Value)
+				if err != nil {
+					return webhooks, fmt.Errorf("error processing webhook [%s:%s]: %w", wh.Key, op.Key, err)
+				}
+				webhooks = append(webhooks, webhook)
+			}
+		}
+	}
+
+	// callbacks
+	if opts.Doc.Model.Paths == nil {
+		return webhooks, nil
+	}
+	for path := opts.Doc.Model.Paths.PathItems.Oldest(); path != nil; path = path.Next() {
+		for op := path.Value.GetOperations().Oldest(); op != nil; op = op.Next() {
+			if op.Value.Callbacks == nil {
+				continue
+			}
+			operationName := gen.ToClassName(op.Value.OperationId)
+
+			for callback := op.Value.Callbacks.Oldest(); callback != nil; callback = callback.Next() {
+				for ce := callback.Value.Expression.Oldest(); ce != nil; ce = ce.Next() {
+					ops := ce.Value.GetOperations()
+					for cop := ops.Oldest(); cop != nil; cop = cop.Next() {
+						name := webhookName(op.Value.OperationId+"-"+callback.Key, cop.Key, cop.Value, ops)
+						webhook, err := buildWebhook(gen, callback.Key, name, cop.Key, cop.Value)
+						if err != nil {
+							return webhooks, fmt.Errorf("error processing callback [%s:%s:%s:%s]: %w", path.Key, op.Key, callback.Key, cop.Key, err)
+						}
+						webhook.Expression = ce.Key
+						webhook.Operation = operationName
+						webhooks = append(webhooks, webhook)
+					}
+				}
+			}
+		}
+	}
+
+	return webhooks, nil
+}
+
+// webhookName returns the operationId of the webhook, or the event name with the method appended if the event has multiple methods
+func webhookName(event string, method string, op *v3.Operation, ops *orderedmap.Map[string, *v3.Operation]) string {
+	if op.OperationId != "" {
+		return op.OperationId
+	}
+	if ops.Len() > 1 {
+		return event + "-" + method
+	}
+	return event
+}
+
+func buildWebhook(gen CodeGenerator, event string, name string, method string, op *v3.Operation) (Webhook, error) {
+	webhook := Webhook{
+		Name:             gen.ToClassName(name),
+		Event:            event,
+		Method:           method,
+		Summary:          op.Summary,
+		Description:      op.Description,
+		PayloadType:      gen.PostProcessType(VoidCodeType),
+		Deprecated:       getBoolValue(op.Deprecated, false),
+		DeprecatedReason: getOrDefault(op.Extensions, "x-deprecated", ""),
+	}
+
+	// headers
+	for _, param := range op.Parameters {
+		if param.In != "header" {
+			continue
+		}
+
+		pSchema, err := param.Schema.BuildSchema()
+		if err != nil {
+			return webhook, fmt.Errorf("error building schema of header [%s]: %w", param.Name, err)
+		}
+		pType, err := gen.ToCodeType(pSchema, CodeTypeSchemaParameter, ptr.Value(param.Required))
+		if err != nil {
+			return webhook, fmt.Errorf("error converting type of header [%s]: %w", param.Name, err)
+		}
+		pType = gen.PostProcessType(pType)
+
+		webhook.Headers = append(webhook.Headers, Parameter{
+			Name:            gen.ToParameterName(param.Name),
+			FieldName:       param.Name,
+			In:              param.In,
+			Description:     param.Description,
+			Type:            pType,
+			IsPrimitiveType: gen.IsPrimitiveType(pType.Name),
+			Required:        getBoolValue(param.Required, false),
+			Deprecated:      param.Deprecated,
+		})
+		webhook.Imports = append(webhook.Imports, gen.TypeToImport(pType))
+	}
+
+	// payload
+	if op.RequestBody != nil && op.RequestBody.Content != nil && op.RequestBody.Content.First() != nil {
+		content := op.RequestBody.Content.First()
+		webhook.ContentType = content.Key()
+
+		if content.Value().Schema != nil {
+			payloadType, err := gen.ToCodeType(content.Value().Schema.Schema(), CodeTypeSchemaResponse, false)
+			if err != nil {
+				return webhook, fmt.Errorf("error converting type of payload: %w", err)
+			}
+			webhook.PayloadType = gen.PostProcessType(payloadType)
+			webhook.Imports = append(webhook.Imports, gen.TypeToImport(webhook.PayloadType))
+		}
+	}
+
+	webhook.Imports = uniqueSortImports(webhook.Imports)
+	return webhook, nil
+}
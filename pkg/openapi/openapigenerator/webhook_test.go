@@ -0,0 +1,105 @@
+package openapigenerator_test
+
+import (
+	"testing"
+
+	openapi_go "github.com/primelib/primecodegen/pkg/generator/openapi-go"
+	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
+	"github.com/primelib/primecodegen/pkg/openapi/openapigenerator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const webhookSpec = `
+openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+  x-name: pets
+paths:
+  /subscriptions:
+    post:
+      operationId: subscribe
+      responses:
+        "201":
+          description: created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+      callbacks:
+        petUpdated:
+          '{$request.body#/callbackUrl}':
+            post:
+              requestBody:
+                content:
+                  application/json:
+                    schema:
+                      $ref: '#/components/schemas/Pet'
+              responses:
+                "200":
+                  description: ok
+webhooks:
+  newPet:
+    post:
+      summary: A new pet was added
+      parameters:
+        - name: X-Signature
+          in: header
+          required: true
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        "200":
+          description: ok
+  pet.deleted:
+    post:
+      responses:
+        "200":
+          description: ok
+components:
+  schemas:
+    Pet:
+      title: Pet
+      type: object
+      properties:
+        id:
+          type: string
+`
+
+func TestBuildTemplateDataWebhooks(t *testing.T) {
+	doc := openapidocument.OpenV3DocumentForTest([]byte(webhookSpec))
+	require.NotNil(t, doc)
+
+	data, err := openapigenerator.BuildTemplateData(doc, openapi_go.NewGenerator(), openapigenerator.CommonPackages{})
+	require.NoError(t, err)
+	require.Len(t, data.Webhooks, 3)
+
+	newPet := data.Webhooks[0]
+	assert.Equal(t, "NewPet", newPet.Name)
+	assert.Equal(t, "newPet", newPet.Event)
+	assert.Equal(t, "post", newPet.Method)
+	assert.Equal(t, "application/json", newPet.ContentType)
+	assert.Equal(t, "Pet", newPet.PayloadType.Name)
+	assert.False(t, newPet.IsCallback())
+	require.Len(t, newPet.Headers, 1)
+	assert.Equal(t, "X-Signature", newPet.Headers[0].FieldName)
+	assert.True(t, newPet.Headers[0].Required)
+
+	deleted := data.Webhooks[1]
+	assert.Equal(t, "PetDeleted", deleted.Name)
+	assert.Empty(t, deleted.ContentType)
+
+	callback := data.Webhooks[2]
+	assert.Equal(t, "SubscribePetUpdated", callback.Name)
+	assert.Equal(t, "petUpdated", callback.Event)
+	assert.Equal(t, "{$request.body#/callbackUrl}", callback.Expression)
+	assert.Equal(t, "Subscribe", callback.Operation)
+	assert.True(t, callback.IsCallback())
+	assert.Equal(t, "Pet", callback.PayloadType.Name)
+}
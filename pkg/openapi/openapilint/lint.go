@@ -0,0 +1,143 @@
+package openapilint
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/pb33f/libopenapi"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/primelib/primecodegen/pkg/util"
+)
+
+var ErrUnknownRule = errors.New("unknown lint rule")
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Rank orders severities, a higher rank is more severe
+func (s Severity) Rank() int {
+	switch s {
+	case SeverityError:
+		return 3
+	case SeverityWarning:
+		return 2
+	case SeverityInfo:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Finding is a single problem reported by a rule
+type Finding struct {
+	RuleID   string   `json:"ruleId"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	Location string   `json:"location"`       // Location is the json pointer like path of the affected element
+	Line     int      `json:"line,omitempty"` // Line is the line in the specification, if known
+}
+
+// Rule checks a document without modifying it
+type Rule interface {
+	GetID() string
+	GetDescription() string
+	GetSeverity() Severity
+	Check(doc *libopenapi.DocumentModel[v3.Document]) []Finding
+}
+
+// BuiltInRule is a rule that is implemented by a check function
+type BuiltInRule struct {
+	ID          string   `yaml:"id"`
+	Description string   `yaml:"description,omitempty"`
+	Severity    Severity `yaml:"severity"`
+	CheckFunc   func(rule BuiltInRule, doc *libopenapi.DocumentModel[v3.Document]) []Finding
+}
+
+func (r BuiltInRule) GetID() string {
+	return r.ID
+}
+
+func (r BuiltInRule) GetDescription() string {
+	return r.Description
+}
+
+func (r BuiltInRule) GetSeverity() Severity {
+	return r.Severity
+}
+
+func (r BuiltInRule) Check(doc *libopenapi.DocumentModel[v3.Document]) []Finding {
+	return r.CheckFunc(r, doc)
+}
+
+// finding creates a finding with the severity of the rule
+func (r BuiltInRule) finding(location string, line int, format string, args ...any) Finding {
+	return Finding{
+		RuleID:   r.ID,
+		Severity: r.Severity,
+		Message:  fmt.Sprintf(format, args...),
+		Location: location,
+		Line:     line,
+	}
+}
+
+var EmbeddedRules = []Rule{
+	MissingOperationIdRule,
+	UntitledInlineSchemaRule,
+	UnusedComponentRule,
+	InvalidMaxValueRule,
+	TagMissingDescriptionRule,
+	DuplicateEnumNameRule,
+	ConflictingParameterNameRule,
+}
+
+var EmbeddedRuleMap = util.SliceToMapWithKeyFunc(EmbeddedRules, func(r Rule) string {
+	return r.GetID()
+})
+
+// RulesByID returns the embedded rules with the given ids, all embedded rules are returned if no ids are provided
+func RulesByID(ids []string) ([]Rule, error) {
+	if len(ids) == 0 {
+		return EmbeddedRules, nil
+	}
+
+	var rules []Rule
+	for _, id := range ids {
+		rule, ok := EmbeddedRuleMap[id]
+		if !ok {
+			return nil, errors.Join(ErrUnknownRule, fmt.Errorf("id: %s", id))
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// Lint runs all rules against the document and returns the findings ordered by severity
+func Lint(doc *libopenapi.DocumentModel[v3.Document], rules []Rule) []Finding {
+	var findings []Finding
+	for _, rule := range rules {
+		findings = append(findings, rule.Check(doc)...)
+	}
+
+	slices.SortStableFunc(findings, func(a, b Finding) int {
+		return b.Severity.Rank() - a.Severity.Rank()
+	})
+
+	return findings
+}
+
+// HasFindings returns true if at least one finding has the given or a higher severity
+func HasFindings(findings []Finding, minSeverity Severity) bool {
+	for _, f := range findings {
+		if f.Severity.Rank() >= minSeverity.Rank() {
+			return true
+		}
+	}
+	return false
+}
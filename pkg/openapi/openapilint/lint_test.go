@@ -0,0 +1,155 @@
+package openapilint
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lintSpec = `openapi: 3.0.3
+info:
+  title: Petstore
+  version: 1.0.0
+tags:
+  - name: pets
+    description: Pet operations
+  - name: stores
+paths:
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      operationId: getPet
+      tags: [pets]
+      parameters:
+        - name: id
+          in: query
+          schema:
+            type: string
+        - name: page-size
+          in: query
+          schema:
+            type: integer
+        - name: page_size
+          in: query
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+    post:
+      tags: [owners]
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+      responses:
+        "204":
+          description: created
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        status:
+          $ref: '#/components/schemas/Status'
+        age:
+          type: integer
+          format: int64
+          maximum: 9223372036854775807
+        count:
+          type: integer
+          maximum: 9223372036854775807
+    Status:
+      type: string
+      enum: [in-progress, in_progress, done]
+    Unused:
+      type: object
+`
+
+func TestLint(t *testing.T) {
+	// arrange
+	doc := openapidocument.OpenV3DocumentForTest([]byte(lintSpec))
+
+	// act
+	findings := Lint(doc, EmbeddedRules)
+
+	// assert
+	var messages []string
+	for _, f := range findings {
+		messages = append(messages, f.RuleID+" "+f.Location+": "+f.Message)
+	}
+	assert.ElementsMatch(t, []string{
+		"missing-operation-id #/paths/~1pets~1{id}/post: operation POST /pets/{id} has no operationId",
+		"untitled-inline-schema #/paths/~1pets~1{id}/post/requestBody/content/application~1json/schema: inline object schema at #/paths/~1pets~1{id}/post/requestBody/content/application~1json/schema has no title",
+		"unused-component #/components/schemas/Unused: component '#/components/schemas/Unused' is not referenced",
+		"invalid-max-value #/components/schemas/Pet/properties/count: maximum 9223372036854775808 at #/components/schemas/Pet/properties/count is out of bounds for integer format 'int32'",
+		"tag-missing-description #/tags/1: tag 'stores' has no description",
+		"tag-missing-description #/paths/~1pets~1{id}/post/tags/0: tag 'owners' is not declared in the document and has no description",
+		"duplicate-enum-name #/components/schemas/Status: enum at #/components/schemas/Status has multiple values with the name IN_PROGRESS: in-progress, in_progress",
+		"conflicting-parameter-name #/paths/~1pets~1{id}/get: operation GET /pets/{id} has conflicting parameters for the name id: path:id, query:id",
+		"conflicting-parameter-name #/paths/~1pets~1{id}/get: operation GET /pets/{id} has conflicting parameters for the name pageSize: query:page-size, query:page_size",
+	}, messages)
+	assert.Equal(t, SeverityError, findings[0].Severity, "findings should be sorted by severity")
+	assert.Equal(t, SeverityInfo, findings[len(findings)-1].Severity)
+	assert.True(t, HasFindings(findings, SeverityError))
+}
+
+func TestLintSingleRule(t *testing.T) {
+	// arrange
+	doc := openapidocument.OpenV3DocumentForTest([]byte(lintSpec))
+	rules, err := RulesByID([]string{"missing-operation-id"})
+	require.NoError(t, err)
+
+	// act
+	findings := Lint(doc, rules)
+
+	// assert
+	require.Len(t, findings, 1)
+	assert.Equal(t, "#/paths/~1pets~1{id}/post", findings[0].Location)
+	assert.Greater(t, findings[0].Line, 0)
+}
+
+func TestRulesByIDUnknown(t *testing.T) {
+	_, err := RulesByID([]string{"does-not-exist"})
+	assert.ErrorIs(t, err, ErrUnknownRule)
+}
+
+func TestReportSARIF(t *testing.T) {
+	// arrange
+	findings := []Finding{{RuleID: MissingOperationIdRule.ID, Severity: SeverityError, Message: "missing", Location: "#/paths/~1pets/get", Line: 12}}
+
+	// act
+	report, err := Report(findings, []Rule{MissingOperationIdRule}, "openapi.yaml", ReportFormatSARIF)
+
+	// assert
+	require.NoError(t, err)
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(report, &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs[0].Results, 1)
+	assert.Equal(t, "error", log.Runs[0].Results[0].Level)
+	assert.Equal(t, "openapi.yaml", log.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 12, log.Runs[0].Results[0].Locations[0].PhysicalLocation.Region.StartLine)
+	assert.Equal(t, MissingOperationIdRule.ID, log.Runs[0].Tool.Driver.Rules[0].ID)
+}
+
+func TestReportUnsupportedFormat(t *testing.T) {
+	_, err := Report(nil, nil, "openapi.yaml", "xml")
+	assert.ErrorIs(t, err, ErrUnsupportedReportFormat)
+}
@@ -0,0 +1,167 @@
+package openapilint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrUnsupportedReportFormat = errors.New("unsupported report format")
+
+const (
+	ReportFormatText  = "text"
+	ReportFormatJSON  = "json"
+	ReportFormatSARIF = "sarif"
+)
+
+// Report renders the findings in the given format, the file is used as artifact location
+func Report(findings []Finding, rules []Rule, file string, format string) ([]byte, error) {
+	switch format {
+	case ReportFormatText:
+		return ReportText(findings, file), nil
+	case ReportFormatJSON:
+		if findings == nil {
+			findings = []Finding{}
+		}
+		return json.MarshalIndent(findings, "", "  ")
+	case ReportFormatSARIF:
+		return ReportSARIF(findings, rules, file)
+	default:
+		return nil, errors.Join(ErrUnsupportedReportFormat, fmt.Errorf("format: %s", format))
+	}
+}
+
+// ReportText renders one line per finding
+func ReportText(findings []Finding, file string) []byte {
+	var sb strings.Builder
+	for _, f := range findings {
+		location := f.Location
+		if f.Line > 0 {
+			location = fmt.Sprintf("%s:%d %s", file, f.Line, f.Location)
+		}
+		sb.WriteString(fmt.Sprintf("[%s] %s: %s (%s)\n", f.Severity, f.RuleID, f.Message, location))
+	}
+	return []byte(sb.String())
+}
+
+// sarif types, see https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// ReportSARIF renders the findings as SARIF 2.1.0 log
+func ReportSARIF(findings []Finding, rules []Rule, file string) ([]byte, error) {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "primecodegen",
+			InformationURI: "https://github.com/primelib/primecodegen",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+
+	for _, r := range rules {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:                   r.GetID(),
+			ShortDescription:     sarifMessage{Text: r.GetDescription()},
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevel(r.GetSeverity())},
+		})
+	}
+
+	for _, f := range findings {
+		location := sarifLocation{
+			PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: file}},
+		}
+		if f.Line > 0 {
+			location.PhysicalLocation.Region = &sarifRegion{StartLine: f.Line}
+		}
+		if f.Location != "" {
+			location.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: f.Location}}
+		}
+
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    f.RuleID,
+			Level:     sarifLevel(f.Severity),
+			Message:   sarifMessage{Text: f.Message},
+			Locations: []sarifLocation{location},
+		})
+	}
+
+	return json.MarshalIndent(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}, "", "  ")
+}
+
+func sarifLevel(severity Severity) string {
+	switch severity {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
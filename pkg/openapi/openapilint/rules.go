@@ -0,0 +1,295 @@
+package openapilint
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
+	"github.com/primelib/primecodegen/pkg/util"
+	"go.yaml.in/yaml/v4"
+)
+
+var MissingOperationIdRule = BuiltInRule{
+	ID:          "missing-operation-id",
+	Description: "Operations must have an operationId, otherwise the generated method names depend on the path and method.",
+	Severity:    SeverityError,
+	CheckFunc:   MissingOperationId,
+}
+
+// MissingOperationId reports operations without an operationId
+func MissingOperationId(rule BuiltInRule, doc *libopenapi.DocumentModel[v3.Document]) []Finding {
+	var findings []Finding
+
+	for _, op := range openapidocument.CollectOperationRefs(doc) {
+		if op.Operation.OperationId == "" {
+			findings = append(findings, rule.finding(op.Pointer(), operationLine(op.Operation), "operation %s %s has no operationId", strings.ToUpper(op.Method), op.Path))
+		}
+	}
+
+	return findings
+}
+
+var UntitledInlineSchemaRule = BuiltInRule{
+	ID:          "untitled-inline-schema",
+	Description: "Inline object schemas should have a title, otherwise the generated model names are derived from their location.",
+	Severity:    SeverityWarning,
+	CheckFunc:   UntitledInlineSchema,
+}
+
+// UntitledInlineSchema reports inline object schemas without a title
+func UntitledInlineSchema(rule BuiltInRule, doc *libopenapi.DocumentModel[v3.Document]) []Finding {
+	var findings []Finding
+
+	// component schemas are named by their key
+	componentSchemas := make(map[*base.SchemaProxy]bool)
+	if doc.Model.Components != nil && doc.Model.Components.Schemas != nil {
+		for s := doc.Model.Components.Schemas.Oldest(); s != nil; s = s.Next() {
+			componentSchemas[s.Value] = true
+		}
+	}
+
+	openapidocument.VisitAllSchemasWithPointer(doc, func(pointer string, schemaProxy *base.SchemaProxy) {
+		if schemaProxy.IsReference() || componentSchemas[schemaProxy] {
+			return
+		}
+
+		s := schemaProxy.Schema()
+		if s != nil && s.Title == "" && (slices.Contains(s.Type, "object") || (s.Properties != nil && s.Properties.Len() > 0)) {
+			findings = append(findings, rule.finding(pointer, schemaLine(schemaProxy), "inline object schema at %s has no title", pointer))
+		}
+	})
+
+	return findings
+}
+
+var UnusedComponentRule = BuiltInRule{
+	ID:          "unused-component",
+	Description: "Components that are never referenced are still generated and should be removed.",
+	Severity:    SeverityWarning,
+	CheckFunc:   UnusedComponent,
+}
+
+// UnusedComponent reports component schemas, parameters, responses, request bodies and headers that are never referenced
+func UnusedComponent(rule BuiltInRule, doc *libopenapi.DocumentModel[v3.Document]) []Finding {
+	var findings []Finding
+	if doc.Index == nil || doc.Index.GetRootNode() == nil || doc.Model.Components == nil {
+		return findings
+	}
+
+	// collect all references, including discriminator mappings
+	references := make(map[string]bool)
+	collectReferences(doc.Index.GetRootNode(), references)
+
+	check := func(componentType string, keys []string) {
+		for _, key := range keys {
+			ref := "#/components/" + componentType + "/" + key
+			if !references[ref] && !(componentType == "schemas" && references[key]) {
+				findings = append(findings, rule.finding(ref, 0, "component '%s' is not referenced", ref))
+			}
+		}
+	}
+	if doc.Model.Components.Schemas != nil {
+		check("schemas", slices.Collect(doc.Model.Components.Schemas.KeysFromOldest()))
+	}
+	if doc.Model.Components.Parameters != nil {
+		check("parameters", slices.Collect(doc.Model.Components.Parameters.KeysFromOldest()))
+	}
+	if doc.Model.Components.Responses != nil {
+		check("responses", slices.Collect(doc.Model.Components.Responses.KeysFromOldest()))
+	}
+	if doc.Model.Components.RequestBodies != nil {
+		check("requestBodies", slices.Collect(doc.Model.Components.RequestBodies.KeysFromOldest()))
+	}
+	if doc.Model.Components.Headers != nil {
+		check("headers", slices.Collect(doc.Model.Components.Headers.KeysFromOldest()))
+	}
+
+	return findings
+}
+
+func collectReferences(node *yaml.Node, references map[string]bool) {
+	if node == nil {
+		return
+	}
+
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			switch {
+			case key.Value == "$ref" && value.Kind == yaml.ScalarNode:
+				references[value.Value] = true
+			case key.Value == "mapping" && value.Kind == yaml.MappingNode:
+				for j := 1; j < len(value.Content); j += 2 {
+					references[value.Content[j].Value] = true
+				}
+			}
+		}
+	}
+
+	for _, child := range node.Content {
+		collectReferences(child, references)
+	}
+}
+
+var InvalidMaxValueRule = BuiltInRule{
+	ID:          "invalid-max-value",
+	Description: "Integer schemas must not have a maximum that is out of bounds for the type, e.g. max: 9223372036854775807 for int32.",
+	Severity:    SeverityWarning,
+	CheckFunc:   InvalidMaxValue,
+}
+
+// InvalidMaxValue reports integer schemas where the maximum value is out of bounds for the format
+func InvalidMaxValue(rule BuiltInRule, doc *libopenapi.DocumentModel[v3.Document]) []Finding {
+	var findings []Finding
+
+	openapidocument.VisitAllSchemasWithPointer(doc, func(pointer string, schemaProxy *base.SchemaProxy) {
+		s := schemaProxy.Schema()
+		if schemaProxy.IsReference() || s == nil || s.Maximum == nil || !slices.Contains(s.Type, "integer") {
+			return
+		}
+
+		limit := float64(math.MaxInt32)
+		if s.Format == "int64" {
+			limit = float64(math.MaxInt64)
+		}
+		if *s.Maximum > limit {
+			findings = append(findings, rule.finding(pointer, schemaLine(schemaProxy), "maximum %.0f at %s is out of bounds for integer format '%s'", *s.Maximum, pointer, util.FirstNonEmptyString(s.Format, "int32")))
+		}
+	})
+
+	return findings
+}
+
+var TagMissingDescriptionRule = BuiltInRule{
+	ID:          "tag-missing-description",
+	Description: "Tags are generated as services and should be declared with a description.",
+	Severity:    SeverityInfo,
+	CheckFunc:   TagMissingDescription,
+}
+
+// TagMissingDescription reports document tags without a description and operation tags that are not declared in the document
+func TagMissingDescription(rule BuiltInRule, doc *libopenapi.DocumentModel[v3.Document]) []Finding {
+	var findings []Finding
+
+	declaredTags := make(map[string]bool)
+	for i, tag := range doc.Model.Tags {
+		declaredTags[tag.Name] = true
+		if tag.Description == "" {
+			findings = append(findings, rule.finding("#/tags/"+strconv.Itoa(i), 0, "tag '%s' has no description", tag.Name))
+		}
+	}
+
+	for _, op := range openapidocument.CollectOperationRefs(doc) {
+		for i, tag := range op.Operation.Tags {
+			if !declaredTags[tag] {
+				findings = append(findings, rule.finding(op.Pointer()+"/tags/"+strconv.Itoa(i), operationLine(op.Operation), "tag '%s' is not declared in the document and has no description", tag))
+				declaredTags[tag] = true
+			}
+		}
+	}
+
+	return findings
+}
+
+var DuplicateEnumNameRule = BuiltInRule{
+	ID:          "duplicate-enum-name",
+	Description: "Enum values must map to unique constant names, e.g. 'in-progress' and 'in_progress' would both be generated as IN_PROGRESS.",
+	Severity:    SeverityError,
+	CheckFunc:   DuplicateEnumName,
+}
+
+// DuplicateEnumName reports enum schemas where multiple values are generated with the same constant name
+func DuplicateEnumName(rule BuiltInRule, doc *libopenapi.DocumentModel[v3.Document]) []Finding {
+	var findings []Finding
+
+	openapidocument.VisitAllSchemasWithPointer(doc, func(pointer string, schemaProxy *base.SchemaProxy) {
+		s := schemaProxy.Schema()
+		if schemaProxy.IsReference() || s == nil || !openapidocument.IsEnumSchema(s) {
+			return
+		}
+
+		allowedValues, err := openapidocument.EnumToAllowedValues(s)
+		if err != nil {
+			return
+		}
+
+		names := make(map[string][]string)
+		for _, v := range allowedValues {
+			constName := util.ToUpperSnakeCase(util.FirstNonEmptyString(v.Name, v.Value))
+			names[constName] = append(names[constName], v.Value)
+		}
+		for _, constName := range sortedKeys(names) {
+			if values := names[constName]; len(values) > 1 {
+				sort.Strings(values)
+				findings = append(findings, rule.finding(pointer, schemaLine(schemaProxy), "enum at %s has multiple values with the name %s: %s", pointer, constName, strings.Join(values, ", ")))
+			}
+		}
+	})
+
+	return findings
+}
+
+var ConflictingParameterNameRule = BuiltInRule{
+	ID:          "conflicting-parameter-name",
+	Description: "Parameters of an operation must map to unique argument names, e.g. 'page-size' and 'page_size' or 'id' in path and query.",
+	Severity:    SeverityError,
+	CheckFunc:   ConflictingParameterName,
+}
+
+// ConflictingParameterName reports operations with multiple parameters that are generated with the same argument name
+func ConflictingParameterName(rule BuiltInRule, doc *libopenapi.DocumentModel[v3.Document]) []Finding {
+	var findings []Finding
+
+	for _, op := range openapidocument.CollectOperationRefs(doc) {
+		// operation parameters override path item parameters with the same name and location
+		parameters := make(map[string]*v3.Parameter)
+		for _, p := range slices.Concat(op.PathItem.Parameters, op.Operation.Parameters) {
+			if p != nil {
+				parameters[p.In+":"+p.Name] = p
+			}
+		}
+
+		names := make(map[string][]string)
+		for _, p := range parameters {
+			argName := util.ToCamelCase(p.Name)
+			names[argName] = append(names[argName], p.In+":"+p.Name)
+		}
+		for _, argName := range sortedKeys(names) {
+			if params := names[argName]; len(params) > 1 {
+				sort.Strings(params)
+				findings = append(findings, rule.finding(op.Pointer(), operationLine(op.Operation), "operation %s %s has conflicting parameters for the name %s: %s", strings.ToUpper(op.Method), op.Path, argName, strings.Join(params, ", ")))
+			}
+		}
+	}
+
+	return findings
+}
+
+func operationLine(op *v3.Operation) int {
+	if low := op.GoLow(); low != nil && low.KeyNode != nil {
+		return low.KeyNode.Line
+	}
+	return 0
+}
+
+func schemaLine(schemaProxy *base.SchemaProxy) int {
+	if low := schemaProxy.GoLow(); low != nil && low.GetValueNode() != nil {
+		return low.GetValueNode().Line
+	}
+	return 0
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
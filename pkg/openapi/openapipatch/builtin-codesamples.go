@@ -16,9 +16,15 @@ import (
 )
 
 var GenerateCodeSamplesRefsPatch = BuiltInPatcher{
-	Type:                "builtin",
-	ID:                  "generate-code-samples-refs",
-	Description:         "Generates x-codeSamples refs from operation slugs",
+	Type:        "builtin",
+	ID:          "generate-code-samples-refs",
+	Description: "Generates x-codeSamples refs from operation slugs",
+	Config: []ConfigField{
+		{Name: "dir", Type: ConfigFieldTypeString, Required: true, Description: "The directory of the generated code, code samples are read from <dir>/<ref-prefix>"},
+		{Name: "ref-prefix", Type: ConfigFieldTypeString, Default: "snippets", Description: "The subdirectory that contains the code samples"},
+		{Name: "language", Type: ConfigFieldTypeString, Description: "The language of the code samples, used to infer the file extension"},
+		{Name: "extension", Type: ConfigFieldTypeString, Description: "The file extension of the code samples, inferred from the language if not set"},
+	},
 	PatchV3DocumentFunc: GenerateCodeSamplesRefs,
 }
 
@@ -357,11 +357,11 @@ func flattenCallbacks(doc *libopenapi.DocumentModel[v3.Document]) error {
 			for callback := op.Value.Callbacks.Oldest(); callback != nil; callback = callback.Next() {
 				for ce := callback.Value.Expression.Oldest(); ce != nil; ce = ce.Next() {
 					for cop := ce.Value.GetOperations().Oldest(); cop != nil; cop = cop.Next() {
-						if cop.Value.Responses.Codes == nil {
+						if cop.Value.Responses.Codes == nil || cop.Value.RequestBody == nil {
 							continue
 						}
 
-						err := processRequestBody(doc, op.Value, op.Value.RequestBody, "%sWH%s", fmt.Sprintf("operation: %s / path: %s", op.Key, callback.Key))
+						err := processRequestBody(doc, cop.Value, cop.Value.RequestBody, "%sWH%s", fmt.Sprintf("operation: %s / callback: %s", op.Key, callback.Key))
 						if err != nil {
 							return err
 						}
@@ -382,7 +382,7 @@ func flattenWebhooks(doc *libopenapi.DocumentModel[v3.Document]) error {
 
 	for webhook := doc.Model.Webhooks.Oldest(); webhook != nil; webhook = webhook.Next() {
 		for op := webhook.Value.GetOperations().Oldest(); op != nil; op = op.Next() {
-			if op.Value.Responses.Codes == nil {
+			if op.Value.Responses.Codes == nil || op.Value.RequestBody == nil {
 				continue
 			}
 
@@ -51,9 +51,12 @@ func GenerateTagFromDocTitle(doc *libopenapi.DocumentModel[v3.Document], config
 }
 
 var GenerateOperationIdsPatch = BuiltInPatcher{
-	Type:                "builtin",
-	ID:                  "generate-operation-id",
-	Description:         "Generates operation IDs for all operations (overwrites existing IDs)",
+	Type:        "builtin",
+	ID:          "generate-operation-id",
+	Description: "Generates operation IDs for all operations (overwrites existing IDs)",
+	Config: []ConfigField{
+		{Name: "trim-prefix", Type: ConfigFieldTypeString, Description: "A path prefix that is ignored when generating the operation IDs"},
+	},
 	PatchV3DocumentFunc: GenerateOperationIds,
 }
 
@@ -66,9 +69,12 @@ func GenerateOperationIds(doc *libopenapi.DocumentModel[v3.Document], config map
 }
 
 var GenerateMissingOperationIdsPatch = BuiltInPatcher{
-	Type:                "builtin",
-	ID:                  "generate-missing-operation-id",
-	Description:         "Generates operation IDs for all operations that are missing an ID (does not overwrite existing IDs)",
+	Type:        "builtin",
+	ID:          "generate-missing-operation-id",
+	Description: "Generates operation IDs for all operations that are missing an ID (does not overwrite existing IDs)",
+	Config: []ConfigField{
+		{Name: "trim-prefix", Type: ConfigFieldTypeString, Description: "A path prefix that is ignored when generating the operation IDs"},
+	},
 	PatchV3DocumentFunc: GenerateMissingOperationIds,
 }
 
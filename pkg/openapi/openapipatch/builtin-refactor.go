@@ -13,9 +13,12 @@ import (
 )
 
 var SetEndpointPatch = BuiltInPatcher{
-	Type:                "builtin",
-	ID:                  "set-endpoint",
-	Description:         "Sets the server endpoint URL for the OpenAPI document",
+	Type:        "builtin",
+	ID:          "set-endpoint",
+	Description: "Sets the server endpoint URL for the OpenAPI document",
+	Config: []ConfigField{
+		{Name: "url", Type: ConfigFieldTypeString, Required: true, Description: "The server URL"},
+	},
 	PatchV3DocumentFunc: SetEndpoint,
 }
 
@@ -72,9 +75,12 @@ func AddIdempotencyKey(doc *libopenapi.DocumentModel[v3.Document], config map[st
 }
 
 var SetOperationTagPatch = BuiltInPatcher{
-	Type:                "builtin",
-	ID:                  "set-operation-tag",
-	Description:         "Sets a tag for all operations in the OpenAPI document",
+	Type:        "builtin",
+	ID:          "set-operation-tag",
+	Description: "Sets a tag for all operations in the OpenAPI document",
+	Config: []ConfigField{
+		{Name: "tag", Type: ConfigFieldTypeString, Required: true, Description: "The tag to set on all operations"},
+	},
 	PatchV3DocumentFunc: SetOperationTag,
 }
 
@@ -96,9 +102,12 @@ func SetOperationTag(doc *libopenapi.DocumentModel[v3.Document], config map[stri
 }
 
 var AddPathPrefixPatch = BuiltInPatcher{
-	Type:                "builtin",
-	ID:                  "add-path-prefix",
-	Description:         "Adds a prefix to all paths in the OpenAPI document",
+	Type:        "builtin",
+	ID:          "add-path-prefix",
+	Description: "Adds a prefix to all paths in the OpenAPI document",
+	Config: []ConfigField{
+		{Name: "prefix", Type: ConfigFieldTypeString, Required: true, Description: "The prefix to add to all paths"},
+	},
 	PatchV3DocumentFunc: AddPathPrefix,
 }
 
@@ -121,9 +130,12 @@ func AddPathPrefix(doc *libopenapi.DocumentModel[v3.Document], config map[string
 }
 
 var PrunePathPrefixPatch = BuiltInPatcher{
-	Type:                "builtin",
-	ID:                  "prune-path-prefix",
-	Description:         "Prunes a prefix from all paths in the OpenAPI document",
+	Type:        "builtin",
+	ID:          "prune-path-prefix",
+	Description: "Prunes a prefix from all paths in the OpenAPI document",
+	Config: []ConfigField{
+		{Name: "prefix", Type: ConfigFieldTypeString, Description: "The prefix to remove from all paths, nothing is removed if empty"},
+	},
 	PatchV3DocumentFunc: PrunePathPrefix,
 }
 
@@ -146,9 +158,12 @@ func PrunePathPrefix(doc *libopenapi.DocumentModel[v3.Document], config map[stri
 }
 
 var AddComponentSchemaPrefixPatch = BuiltInPatcher{
-	Type:                "builtin",
-	ID:                  "add-component-schema-prefix",
-	Description:         "Adds a prefix to all component schemas in the OpenAPI document",
+	Type:        "builtin",
+	ID:          "add-component-schema-prefix",
+	Description: "Adds a prefix to all component schemas in the OpenAPI document",
+	Config: []ConfigField{
+		{Name: "prefix", Type: ConfigFieldTypeString, Required: true, Description: "The prefix to add to all component schema names"},
+	},
 	PatchV3DocumentFunc: AddComponentSchemaPrefix,
 }
 
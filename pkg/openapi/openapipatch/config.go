@@ -1,7 +1,12 @@
 package openapipatch
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/pb33f/libopenapi"
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
@@ -10,9 +15,10 @@ import (
 )
 
 type BuiltInPatcher struct {
-	Type                string `yaml:"type"`
-	ID                  string `yaml:"id,omitempty"`
-	Description         string `yaml:"description,omitempty"`
+	Type                string        `yaml:"type"`
+	ID                  string        `yaml:"id,omitempty"`
+	Description         string        `yaml:"description,omitempty"`
+	Config              []ConfigField `yaml:"config,omitempty"` // Config declares the accepted config keys, any other key is rejected
 	PatchV3DocumentFunc func(doc *libopenapi.DocumentModel[v3.Document], config map[string]interface{}) error
 	PatchFileFunc       func(inputFile string, config map[string]interface{}) ([]byte, error)
 }
@@ -78,6 +84,137 @@ var EmbeddedPatcherMap = util.SliceToMapWithKeyFunc(EmbeddedPatchers, func(p Bui
 	return p.Type + ":" + p.ID
 })
 
+var ErrInvalidPatchConfig = errors.New("invalid patch config")
+
+type ConfigFieldType string
+
+const (
+	ConfigFieldTypeString  ConfigFieldType = "string"
+	ConfigFieldTypeBoolean ConfigFieldType = "boolean"
+	ConfigFieldTypeInteger ConfigFieldType = "integer"
+	ConfigFieldTypeNumber  ConfigFieldType = "number"
+)
+
+// ConfigField describes a single config key of a builtin patch
+type ConfigField struct {
+	Name        string          `yaml:"name"`
+	Type        ConfigFieldType `yaml:"type"`
+	Required    bool            `yaml:"required,omitempty"`
+	Default     interface{}     `yaml:"default,omitempty"`
+	Description string          `yaml:"description,omitempty"`
+}
+
+// String returns a short summary of the field, e.g. "dir (string, required)"
+func (f ConfigField) String() string {
+	attributes := []string{string(f.Type)}
+	if f.Required {
+		attributes = append(attributes, "required")
+	}
+	if f.Default != nil {
+		attributes = append(attributes, fmt.Sprintf("default: %v", f.Default))
+	}
+	return fmt.Sprintf("%s (%s)", f.Name, strings.Join(attributes, ", "))
+}
+
+// matchesType checks if the value has the declared type, numbers decoded from json are accepted as integer if they have no fraction
+func (f ConfigField) matchesType(value interface{}) bool {
+	switch f.Type {
+	case ConfigFieldTypeString:
+		_, ok := value.(string)
+		return ok
+	case ConfigFieldTypeBoolean:
+		_, ok := value.(bool)
+		return ok
+	case ConfigFieldTypeInteger:
+		switch v := value.(type) {
+		case int, int32, int64, uint, uint32, uint64:
+			return true
+		case float64:
+			return v == math.Trunc(v)
+		}
+		return false
+	case ConfigFieldTypeNumber:
+		switch value.(type) {
+		case int, int32, int64, uint, uint32, uint64, float32, float64:
+			return true
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// ValidateConfig validates the config against the declared config fields and returns a copy of the config with the defaults applied.
+// Unknown keys, missing required keys and values of the wrong type are reported as error.
+func (bip BuiltInPatcher) ValidateConfig(config map[string]interface{}) (map[string]interface{}, error) {
+	var errs []error
+	result := make(map[string]interface{}, len(bip.Config))
+
+	// unknown keys
+	var keys []string
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !slices.ContainsFunc(bip.Config, func(f ConfigField) bool { return f.Name == key }) {
+			errs = append(errs, fmt.Errorf("unknown config key: %s", key))
+		}
+	}
+
+	// declared keys
+	for _, field := range bip.Config {
+		value, ok := config[field.Name]
+		if !ok || value == nil {
+			if field.Required {
+				errs = append(errs, fmt.Errorf("missing config key: %s", field.Name))
+			} else if field.Default != nil {
+				result[field.Name] = field.Default
+			}
+			continue
+		}
+		if !field.matchesType(value) {
+			errs = append(errs, fmt.Errorf("config key %q must be of type %s", field.Name, field.Type))
+			continue
+		}
+		result[field.Name] = value
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(append([]error{ErrInvalidPatchConfig, fmt.Errorf("patch: %s:%s", bip.Type, bip.ID)}, errs...)...)
+	}
+	return result, nil
+}
+
+// ConfigJSONSchema returns the json schema of the config, as used in configschema/primelib-v1.json
+func (bip BuiltInPatcher) ConfigJSONSchema() map[string]interface{} {
+	properties := make(map[string]interface{}, len(bip.Config))
+	required := []interface{}{}
+	for _, field := range bip.Config {
+		property := map[string]interface{}{
+			"type":        string(field.Type),
+			"description": field.Description,
+		}
+		if field.Default != nil {
+			property["default"] = field.Default
+		}
+		properties[field.Name] = property
+		if field.Required {
+			required = append(required, field.Name)
+		}
+	}
+
+	schema := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
 func getStringConfig(config map[string]interface{}, key string) (string, error) {
 	val, ok := config[key]
 	if !ok {
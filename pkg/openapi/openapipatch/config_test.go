@@ -0,0 +1,132 @@
+package openapipatch
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/primelib/primecodegen/pkg/patch/sharedpatch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltInPatcher_ValidateConfig(t *testing.T) {
+	patcher := BuiltInPatcher{
+		Type: "builtin",
+		ID:   "test",
+		Config: []ConfigField{
+			{Name: "dir", Type: ConfigFieldTypeString, Required: true},
+			{Name: "ref-prefix", Type: ConfigFieldTypeString, Default: "snippets"},
+			{Name: "strict", Type: ConfigFieldTypeBoolean},
+			{Name: "limit", Type: ConfigFieldTypeInteger},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		config   map[string]interface{}
+		expected map[string]interface{}
+		errs     []string
+	}{
+		{
+			name:     "defaults",
+			config:   map[string]interface{}{"dir": "out"},
+			expected: map[string]interface{}{"dir": "out", "ref-prefix": "snippets"},
+		},
+		{
+			name:     "json number as integer",
+			config:   map[string]interface{}{"dir": "out", "ref-prefix": "samples", "strict": true, "limit": float64(10)},
+			expected: map[string]interface{}{"dir": "out", "ref-prefix": "samples", "strict": true, "limit": float64(10)},
+		},
+		{
+			name:   "missing required key",
+			config: map[string]interface{}{},
+			errs:   []string{"missing config key: dir"},
+		},
+		{
+			name:   "unknown key",
+			config: map[string]interface{}{"dir": "out", "ref-prefx": "samples"},
+			errs:   []string{"unknown config key: ref-prefx"},
+		},
+		{
+			name:   "wrong type",
+			config: map[string]interface{}{"dir": 1, "strict": "yes", "limit": 1.5},
+			errs: []string{
+				`config key "dir" must be of type string`,
+				`config key "strict" must be of type boolean`,
+				`config key "limit" must be of type integer`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := patcher.ValidateConfig(tt.config)
+			if len(tt.errs) > 0 {
+				assert.ErrorIs(t, err, ErrInvalidPatchConfig)
+				for _, e := range tt.errs {
+					assert.ErrorContains(t, err, e)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, config)
+		})
+	}
+}
+
+func TestApplyPatches_InvalidConfig(t *testing.T) {
+	_, err := ApplyPatches([]byte(explainSpec), []sharedpatch.SpecPatch{
+		{Type: "builtin", ID: "set-operation-tag", Config: map[string]interface{}{"tags": "pets"}},
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidPatchConfig)
+	assert.ErrorContains(t, err, "unknown config key: tags")
+	assert.ErrorContains(t, err, "missing config key: tag")
+}
+
+// TestConfigJSONSchema ensures that configschema/primelib-v1.json contains the config schema of each builtin patch
+func TestConfigJSONSchema(t *testing.T) {
+	content, err := os.ReadFile("../../../configschema/primelib-v1.json")
+	require.NoError(t, err)
+
+	var schema struct {
+		Defs struct {
+			Patch struct {
+				AllOf []struct {
+					If struct {
+						Properties struct {
+							ID struct {
+								Const string `json:"const"`
+							} `json:"id"`
+						} `json:"properties"`
+					} `json:"if"`
+					Then struct {
+						Properties struct {
+							Config map[string]interface{} `json:"config"`
+						} `json:"properties"`
+					} `json:"then"`
+				} `json:"allOf"`
+			} `json:"Patch"`
+		} `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal(content, &schema))
+
+	documented := make(map[string]interface{})
+	for _, entry := range schema.Defs.Patch.AllOf {
+		documented[entry.If.Properties.ID.Const] = entry.Then.Properties.Config
+	}
+
+	for _, p := range EmbeddedPatchers {
+		if len(p.Config) == 0 {
+			continue
+		}
+
+		expected, err := json.Marshal(p.ConfigJSONSchema())
+		require.NoError(t, err)
+		actual, err := json.Marshal(documented[p.ID])
+		require.NoError(t, err)
+		assert.JSONEq(t, string(expected), string(actual), "config schema of %s is outdated", p.ID)
+	}
+}
@@ -0,0 +1,158 @@
+package openapipatch
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
+	"go.yaml.in/yaml/v4"
+)
+
+type ChangeType string
+
+const (
+	ChangeAdded   ChangeType = "added"
+	ChangeRemoved ChangeType = "removed"
+	ChangeChanged ChangeType = "changed"
+)
+
+// PatchReport describes the effect of a single patch
+type PatchReport struct {
+	Patch    string             `json:"patch"`
+	Duration time.Duration      `json:"duration"`
+	Changes  []StructuralChange `json:"changes"`
+}
+
+// StructuralChange is a path item, operation, component or top-level key that was added, removed or changed
+type StructuralChange struct {
+	Type ChangeType `json:"type"`
+	Path string     `json:"path"`
+}
+
+func (c StructuralChange) String() string {
+	switch c.Type {
+	case ChangeAdded:
+		return "+ " + c.Path
+	case ChangeRemoved:
+		return "- " + c.Path
+	default:
+		return "~ " + c.Path
+	}
+}
+
+// StructuralDiff compares two YAML or JSON documents on the level of operations, component keys and other top-level keys
+func StructuralDiff(before []byte, after []byte) ([]StructuralChange, error) {
+	beforeEntries, err := structuralEntries(before)
+	if err != nil {
+		return nil, err
+	}
+	afterEntries, err := structuralEntries(after)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []StructuralChange
+	for key, value := range beforeEntries {
+		afterValue, ok := afterEntries[key]
+		if !ok {
+			changes = append(changes, StructuralChange{Type: ChangeRemoved, Path: key})
+		} else if afterValue != value {
+			changes = append(changes, StructuralChange{Type: ChangeChanged, Path: key})
+		}
+	}
+	for key := range afterEntries {
+		if _, ok := beforeEntries[key]; !ok {
+			changes = append(changes, StructuralChange{Type: ChangeAdded, Path: key})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
+
+// structuralEntries maps the location of each operation, component and top-level key to its normalized content
+func structuralEntries(input []byte) (map[string]string, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(input, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse document: %w", err)
+	}
+
+	entries := make(map[string]string)
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return entries, nil
+	}
+
+	for key, value := range mappingEntries(root.Content[0]) {
+		switch key {
+		case "paths":
+			for path, pathItem := range mappingEntries(value) {
+				for method, op := range mappingEntries(pathItem) {
+					if err := addStructuralEntry(entries, "#/paths/"+openapidocument.EscapeJSONPointer(path)+"/"+openapidocument.EscapeJSONPointer(method), op); err != nil {
+						return nil, err
+					}
+				}
+				if pathItem.Kind != yaml.MappingNode || len(pathItem.Content) == 0 {
+					if err := addStructuralEntry(entries, "#/paths/"+openapidocument.EscapeJSONPointer(path), pathItem); err != nil {
+						return nil, err
+					}
+				}
+			}
+		case "components":
+			for componentType, components := range mappingEntries(value) {
+				for name, component := range mappingEntries(components) {
+					if err := addStructuralEntry(entries, "#/components/"+openapidocument.EscapeJSONPointer(componentType)+"/"+openapidocument.EscapeJSONPointer(name), component); err != nil {
+						return nil, err
+					}
+				}
+			}
+		default:
+			if err := addStructuralEntry(entries, "#/"+openapidocument.EscapeJSONPointer(key), value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+func mappingEntries(node *yaml.Node) map[string]*yaml.Node {
+	entries := make(map[string]*yaml.Node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return entries
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		entries[node.Content[i].Value] = node.Content[i+1]
+	}
+	return entries
+}
+
+// addStructuralEntry stores the decoded and re-encoded content (sorted keys), so formatting and key order are ignored
+func addStructuralEntry(entries map[string]string, key string, node *yaml.Node) error {
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", key, err)
+	}
+	bytes, err := yaml.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+	entries[key] = string(bytes)
+	return nil
+}
+
+// FormatPatchReport renders a human-readable summary of the patch report
+func FormatPatchReport(report PatchReport) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s (%s)\n", report.Patch, report.Duration.Round(time.Microsecond)))
+	if len(report.Changes) == 0 {
+		sb.WriteString("  no structural changes\n")
+	}
+	for _, c := range report.Changes {
+		sb.WriteString("  " + c.String() + "\n")
+	}
+	return sb.String()
+}
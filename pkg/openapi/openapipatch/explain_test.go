@@ -0,0 +1,83 @@
+package openapipatch
+
+import (
+	"testing"
+
+	"github.com/primelib/primecodegen/pkg/patch/sharedpatch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const explainSpec = `openapi: 3.0.0
+info:
+  title: Sample API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      tags: [pets, animals]
+      responses:
+        200:
+          description: OK
+components:
+  schemas:
+    Pet:
+      type: object
+    Unused:
+      type: string
+`
+
+func TestStructuralDiff(t *testing.T) {
+	const after = `{
+  "openapi": "3.0.0",
+  "info": {"version": "1.0.0", "title": "Sample API"},
+  "paths": {
+    "/pets": {"get": {"tags": ["pets"], "responses": {"200": {"description": "OK"}}}},
+    "/stores": {"get": {"responses": {"200": {"description": "OK"}}}}
+  },
+  "components": {"schemas": {"Pet": {"type": "object"}}}
+}`
+
+	changes, err := StructuralDiff([]byte(explainSpec), []byte(after))
+	require.NoError(t, err)
+
+	assert.Equal(t, []StructuralChange{
+		{Type: ChangeRemoved, Path: "#/components/schemas/Unused"},
+		{Type: ChangeChanged, Path: "#/paths/~1pets/get"},
+		{Type: ChangeAdded, Path: "#/paths/~1stores/get"},
+	}, changes)
+}
+
+func TestApplyPatchesWithOptions_ExplainAndStopAfter(t *testing.T) {
+	patches := []sharedpatch.SpecPatch{
+		{Type: "builtin", ID: "prune-operation-tags-keep-first"},
+		{Type: "builtin", ID: "prune-operation-tags"},
+	}
+	var explained []string
+
+	result, err := ApplyPatchesWithOptions([]byte(explainSpec), patches, ApplyOptions{
+		Explain:   true,
+		StopAfter: "prune-operation-tags-keep-first",
+		OnPatchApplied: func(report PatchReport) {
+			explained = append(explained, report.Patch)
+		},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, result.Stopped)
+	assert.Equal(t, []string{"builtin:prune-operation-tags-keep-first"}, explained)
+	require.Len(t, result.Reports, 1)
+	assert.Contains(t, result.Reports[0].Changes, StructuralChange{Type: ChangeChanged, Path: "#/paths/~1pets/get"})
+	assert.Contains(t, string(result.Output), "pets")
+	assert.NotContains(t, string(result.Output), "animals")
+}
+
+func TestApplyPatchesWithOptions_StopAfterUnknownPatch(t *testing.T) {
+	patches := []sharedpatch.SpecPatch{
+		{Type: "builtin", ID: "prune-operation-tags-keep-first"},
+	}
+
+	_, err := ApplyPatchesWithOptions([]byte(explainSpec), patches, ApplyOptions{StopAfter: "prune-operation-tags"})
+
+	assert.ErrorIs(t, err, ErrStopAfterPatchNotFound)
+}
@@ -3,80 +3,227 @@ package openapipatch
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	"os"
+	"slices"
+	"time"
 
+	"github.com/pb33f/libopenapi"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
 	"github.com/primelib/primecodegen/pkg/openapi/openapidocument"
 	"github.com/primelib/primecodegen/pkg/patch"
 	"github.com/primelib/primecodegen/pkg/patch/sharedpatch"
 	"github.com/primelib/primecodegen/pkg/util"
-	"log/slog"
 )
 
+var ErrStopAfterPatchNotFound = errors.New("stop-after patch is not part of the patches")
+
+type ApplyOptions struct {
+	// Explain computes a structural diff for each patch, the report is passed to OnPatchApplied
+	Explain bool
+	// OnPatchApplied is called after each patch with the duration and, if Explain is enabled, the structural changes
+	OnPatchApplied func(report PatchReport)
+	// StopAfter stops patching after the patch with the given id (or <type>:<id>) was applied
+	StopAfter string
+}
+
+type ApplyResult struct {
+	Output  []byte
+	Reports []PatchReport
+	Stopped bool // Stopped is true if patching stopped early because of StopAfter
+}
+
 func ApplyPatches(input []byte, patches []sharedpatch.SpecPatch) ([]byte, error) {
-	inputFormat := util.DetectJSONOrYAML(input)
+	result, err := ApplyPatchesWithOptions(input, patches, ApplyOptions{})
+	return result.Output, err
+}
+
+// ApplyPatchesWithOptions applies the patches in order, see ApplyOptions for the explain and stop-after options.
+// Consecutive builtin patches share the in-memory v3 model, the document is only rendered if a file-based or textual patch needs the bytes
+// or if a patch created schemas or references, which can not be resolved without parsing the document again.
+func ApplyPatchesWithOptions(input []byte, patches []sharedpatch.SpecPatch, opts ApplyOptions) (ApplyResult, error) {
+	result := ApplyResult{Output: input}
+	doc := &patchDocument{bytes: input}
+
+	// fail early instead of silently applying all patches
+	if opts.StopAfter != "" && !ContainsPatch(patches, opts.StopAfter) {
+		return result, errors.Join(ErrStopAfterPatchNotFound, fmt.Errorf("id: %s", opts.StopAfter))
+	}
 
 	for _, p := range patches {
 		slog.Info("applying patch to spec", "id", p.String(), "config", p.Config)
 
+		// validate the config of builtin patches and apply defaults
 		if patcher, ok := EmbeddedPatcherMap[p.Type+":"+p.ID]; ok {
-			// In-Memory Patcher (libopenapi)
-			if patcher.PatchV3DocumentFunc != nil {
-				doc, err := openapidocument.OpenDocument(input)
-				if err != nil {
-					return input, err
-				}
-
-				v3doc, err := doc.BuildV3Model()
-				if err != nil {
-					return input, fmt.Errorf("failed to build v3 high level model: %w", err)
-				}
+			config, err := patcher.ValidateConfig(p.Config)
+			if err != nil {
+				return result, err
+			}
+			p.Config = config
+		}
 
-				patchErr := patcher.PatchV3DocumentFunc(v3doc, p.Config)
-				if patchErr != nil {
-					return input, fmt.Errorf("failed to patch document with [%s]: %w", patcher.ID, patchErr)
-				}
+		// explain needs the document before the patch
+		var before []byte
+		if opts.Explain {
+			var err error
+			if before, err = doc.render(); err != nil {
+				return result, err
+			}
+		}
 
-				bytes, err := openapidocument.RenderV3ModelFormat(v3doc, inputFormat)
-				if err != nil {
-					return input, errors.Join(util.ErrRenderDocument, err)
-				}
-				input = bytes
-				continue
+		start := time.Now()
+		if patcher, ok := EmbeddedPatcherMap[p.Type+":"+p.ID]; ok && patcher.PatchV3DocumentFunc != nil {
+			// In-Memory Patcher (libopenapi)
+			v3doc, err := doc.v3Model()
+			if err != nil {
+				return result, err
 			}
 
-			// File-based Patch (external tool call)
-			if patcher.PatchFileFunc != nil {
-				tempFile, err := os.CreateTemp("", "input-*.yaml")
-				if err != nil {
-					return input, errors.Join(util.ErrFailedToPatchDocument, err)
-				}
-				defer os.Remove(tempFile.Name())
+			patchErr := patcher.PatchV3DocumentFunc(v3doc, p.Config)
+			if patchErr != nil {
+				return result, fmt.Errorf("failed to patch document with [%s]: %w", patcher.ID, patchErr)
+			}
 
-				_, err = tempFile.Write(input)
-				if err != nil {
-					return input, errors.Join(util.ErrFailedToPatchDocument, err)
-				}
-				err = tempFile.Close()
-				if err != nil {
-					return input, errors.Join(util.ErrFailedToPatchDocument, err)
+			// schemas and references created by the patch only resolve after the document was parsed again
+			if openapidocument.HasDetachedSchemas(v3doc) {
+				if _, err = doc.render(); err != nil {
+					return result, err
 				}
+			}
+		} else {
+			bytes, err := doc.render()
+			if err != nil {
+				return result, err
+			}
 
-				patchedBytes, patchErr := patcher.PatchFileFunc(tempFile.Name(), p.Config)
-				if patchErr != nil {
-					return input, patchErr
-				}
+			bytes, err = applyFilePatch(bytes, p)
+			if err != nil {
+				return result, err
+			}
+			doc.bytes = bytes
+		}
+		report := PatchReport{Patch: p.String(), Duration: time.Since(start)}
 
-				input = patchedBytes
-				continue
+		if opts.Explain {
+			after, err := doc.render()
+			if err != nil {
+				return result, err
 			}
-		} else {
-			patchedBytes, patchErr := patch.ApplyPatchFile(input, p)
-			if patchErr != nil {
-				return input, errors.Join(util.ErrFailedToPatchDocument, patchErr)
+			report.Changes, err = StructuralDiff(before, after)
+			if err != nil {
+				return result, fmt.Errorf("failed to diff document after patch [%s]: %w", p.String(), err)
 			}
+		}
+		result.Reports = append(result.Reports, report)
+		if opts.OnPatchApplied != nil {
+			opts.OnPatchApplied(report)
+		}
+
+		if opts.StopAfter != "" && matchesPatchId(p, opts.StopAfter) {
+			slog.Info("stopping after patch", "id", p.String())
+			result.Stopped = true
+			break
+		}
+	}
+
+	output, err := doc.render()
+	if err != nil {
+		return result, err
+	}
+	result.Output = output
+
+	return result, nil
+}
+
+// ContainsPatch checks if one of the patches is referenced by the given id or <type>:<id>
+func ContainsPatch(patches []sharedpatch.SpecPatch, id string) bool {
+	return slices.ContainsFunc(patches, func(p sharedpatch.SpecPatch) bool {
+		return matchesPatchId(p, id)
+	})
+}
+
+// matchesPatchId checks if the patch is referenced by its id or <type>:<id>
+func matchesPatchId(p sharedpatch.SpecPatch, id string) bool {
+	return id == p.ID || id == p.String()
+}
+
+// patchDocument holds the document either as bytes or as in-memory v3 model, the model is only rendered when the bytes are needed
+type patchDocument struct {
+	bytes  []byte
+	format string
+	model  *libopenapi.DocumentModel[v3.Document]
+}
+
+// v3Model returns the in-memory model, the bytes are only parsed if there is no model yet
+func (d *patchDocument) v3Model() (*libopenapi.DocumentModel[v3.Document], error) {
+	if d.model != nil {
+		return d.model, nil
+	}
+
+	doc, err := openapidocument.OpenDocument(d.bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	v3doc, err := doc.BuildV3Model()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build v3 high level model: %w", err)
+	}
+	d.format = util.DetectJSONOrYAML(d.bytes)
+	d.model = v3doc
+
+	return d.model, nil
+}
+
+// render returns the document bytes, a pending in-memory model is rendered in the original format and released
+func (d *patchDocument) render() ([]byte, error) {
+	if d.model == nil {
+		return d.bytes, nil
+	}
+
+	bytes, err := openapidocument.RenderV3ModelFormat(d.model, d.format)
+	if err != nil {
+		return d.bytes, errors.Join(util.ErrRenderDocument, err)
+	}
+	d.bytes = bytes
+	d.model = nil
+
+	return d.bytes, nil
+}
+
+// applyFilePatch applies textual patches (jsonpatch, git, overlay) and builtin patches that work on files (speakeasy)
+func applyFilePatch(input []byte, p sharedpatch.SpecPatch) ([]byte, error) {
+	patcher, ok := EmbeddedPatcherMap[p.Type+":"+p.ID]
+	if !ok {
+		patchedBytes, patchErr := patch.ApplyPatchFile(input, p)
+		if patchErr != nil {
+			return input, errors.Join(util.ErrFailedToPatchDocument, patchErr)
+		}
+		return patchedBytes, nil
+	}
+
+	// File-based Patch (external tool call)
+	if patcher.PatchFileFunc != nil {
+		tempFile, err := os.CreateTemp("", "input-*.yaml")
+		if err != nil {
+			return input, errors.Join(util.ErrFailedToPatchDocument, err)
+		}
+		defer os.Remove(tempFile.Name())
+
+		_, err = tempFile.Write(input)
+		if err != nil {
+			return input, errors.Join(util.ErrFailedToPatchDocument, err)
+		}
+		err = tempFile.Close()
+		if err != nil {
+			return input, errors.Join(util.ErrFailedToPatchDocument, err)
+		}
 
-			input = patchedBytes
+		patchedBytes, patchErr := patcher.PatchFileFunc(tempFile.Name(), p.Config)
+		if patchErr != nil {
+			return input, patchErr
 		}
+		return patchedBytes, nil
 	}
 
 	return input, nil
@@ -0,0 +1,63 @@
+package openapipatch_test
+
+import (
+	_ "embed"
+	"testing"
+
+	"github.com/primelib/primecodegen/pkg/openapi/openapigenerator"
+	"github.com/primelib/primecodegen/pkg/openapi/openapipatch"
+	"github.com/primelib/primecodegen/pkg/patch/sharedpatch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	//go:embed specs/resources.yaml
+	resourcesSpec []byte
+	//go:embed specs/composition-inline.yaml
+	compositionInlineSpec []byte
+)
+
+func TestApplyPatches_InMemoryMatchesRenderEachPatch(t *testing.T) {
+	patchLists := map[string][]sharedpatch.SpecPatch{
+		"default-code-generation": sharedpatch.ParsePatchSpecsFromStrings(openapigenerator.DefaultCodeGenerationPatches),
+	}
+	for _, set := range []string{"code-generation", "code-generation-polymorphic"} {
+		patches, err := openapipatch.ResolvePatchSets([]openapipatch.PatchSet{{Id: set}}, nil)
+		require.NoError(t, err)
+		patchLists[set] = patches
+	}
+	specs := map[string][]byte{
+		"resources":          resourcesSpec,
+		"composition-inline": compositionInlineSpec,
+	}
+
+	for patchListName, patches := range patchLists {
+		for specName, input := range specs {
+			t.Run(patchListName+"/"+specName, func(t *testing.T) {
+				// render and re-parse the document after every patch
+				expected := input
+				for _, p := range patches {
+					var err error
+					expected, err = openapipatch.ApplyPatches(expected, []sharedpatch.SpecPatch{p})
+					require.NoError(t, err)
+				}
+				actual, err := openapipatch.ApplyPatches(input, patches)
+				require.NoError(t, err)
+
+				assert.Equal(t, string(expected), string(actual))
+			})
+		}
+	}
+}
+
+func TestApplyPatches_DefaultCodeGenerationKeepsComposedProperties(t *testing.T) {
+	patches := sharedpatch.ParsePatchSpecsFromStrings(openapigenerator.DefaultCodeGenerationPatches)
+
+	output, err := openapipatch.ApplyPatches(compositionInlineSpec, patches)
+	require.NoError(t, err)
+
+	for _, property := range []string{"extra", "adopted", "wings", "company", "nickname"} {
+		assert.Contains(t, string(output), property+":")
+	}
+}
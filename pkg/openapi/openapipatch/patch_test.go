@@ -0,0 +1,147 @@
+package openapipatch
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/primelib/primecodegen/pkg/patch/sharedpatch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// generatePatchTestSpec generates a spec with n resources, each with inline request and response schemas
+func generatePatchTestSpec(n int) []byte {
+	var sb strings.Builder
+	sb.WriteString("openapi: 3.0.3\ninfo:\n  title: Benchmark API\n  version: 1.0.0\npaths:\n")
+	for i := 0; i < n; i++ {
+		sb.WriteString(fmt.Sprintf(`  /api/v1/resource%[1]d/{id}:
+    get:
+      tags: [resource%[1]d]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Resource%[1]d'
+    post:
+      tags: [resource%[1]d]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                settings:
+                  type: object
+                  properties:
+                    enabled:
+                      type: boolean
+      responses:
+        "201":
+          description: created
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  id:
+                    type: string
+`, i))
+	}
+	sb.WriteString("components:\n  schemas:\n")
+	for i := 0; i < n; i++ {
+		sb.WriteString(fmt.Sprintf(`    Resource%[1]d:
+      type: object
+      properties:
+        id:
+          type: string
+        count:
+          type: integer
+          maximum: 9223372036854775807
+        status:
+          type: string
+          enum: [active, inactive]
+        owner:
+          type: object
+          properties:
+            name:
+              type: string
+`, i))
+	}
+	return []byte(sb.String())
+}
+
+// applyPatchesRenderEachPatch applies each patch individually, which renders and re-parses the document between patches
+func applyPatchesRenderEachPatch(input []byte, patches []sharedpatch.SpecPatch) ([]byte, error) {
+	var err error
+	for _, p := range patches {
+		input, err = ApplyPatches(input, []sharedpatch.SpecPatch{p})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return input, nil
+}
+
+func TestApplyPatches_MixedBuiltinAndTextualPatches(t *testing.T) {
+	input := generatePatchTestSpec(1)
+	patches := []sharedpatch.SpecPatch{
+		PrunePathPrefixPatch.ToSpecPatch(),
+		{Type: string(sharedpatch.PatchTypeOpenAPIOverlay), Content: "overlay: 1.0.0\ninfo:\n  title: Title\n  version: 1.0.0\nactions:\n  - target: $.info\n    update:\n      title: Patched API\n"},
+		GenerateOperationIdsPatch.ToSpecPatch(),
+	}
+
+	expected, err := applyPatchesRenderEachPatch(input, patches)
+	require.NoError(t, err)
+	actual, err := ApplyPatches(input, patches)
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expected), string(actual))
+	assert.Contains(t, string(actual), "Patched API")
+}
+
+func BenchmarkApplyPatches_InMemory(b *testing.B) {
+	input := generatePatchTestSpec(200)
+	patches, err := ResolvePatchSets([]PatchSet{{Id: "code-generation"}}, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := ApplyPatches(input, patches); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkApplyPatches_RenderEachPatch(b *testing.B) {
+	input := generatePatchTestSpec(200)
+	patches, err := ResolvePatchSets([]PatchSet{{Id: "code-generation"}}, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := applyPatchesRenderEachPatch(input, patches); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
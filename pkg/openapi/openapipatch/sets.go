@@ -1,7 +1,26 @@
 package openapipatch
 
 import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
 	"github.com/primelib/primecodegen/pkg/patch/sharedpatch"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrUnknownPatchSet      = errors.New("unknown patch set")
+	ErrUnknownPatch         = errors.New("unknown patch")
+	ErrDuplicatePatchSet    = errors.New("patch set is defined more than once")
+	ErrCircularPatchSet     = errors.New("patch set extends itself")
+	ErrReadPatchSetFile     = errors.New("failed to read patch set file")
+	ErrInvalidPatchSetEntry = errors.New("invalid patch set entry")
+	ErrUnknownPatchConfig   = errors.New("patch set config references a patch that is not part of the set")
 )
 
 type PatchSet struct {
@@ -10,8 +29,15 @@ type PatchSet struct {
 }
 
 type PatchPreset struct {
-	Id      string                  `yaml:"id"`
-	Patches []sharedpatch.SpecPatch `yaml:"patches"`
+	Id          string                  `yaml:"id"`
+	Description string                  `yaml:"description,omitempty"`
+	Extends     []string                `yaml:"extends,omitempty"` // Extends are the ids of other sets, their patches are applied before the own patches
+	Patches     []sharedpatch.SpecPatch `yaml:"patches"`
+}
+
+// PatchSetFile is a standalone file with user-defined patch sets
+type PatchSetFile struct {
+	PatchSets []PatchPreset `yaml:"patchSets"`
 }
 
 var allPatchSets = map[string]PatchPreset{
@@ -41,23 +67,123 @@ var allPatchSets = map[string]PatchPreset{
 	},
 }
 
-func ResolvePatchSets(patchSets []PatchSet) []sharedpatch.SpecPatch {
+// LoadPatchSetFile reads user-defined patch sets from a yaml file, relative patch files are resolved against the directory of the file
+func LoadPatchSetFile(file string) ([]PatchPreset, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.Join(ErrReadPatchSetFile, err)
+	}
+
+	var patchSetFile PatchSetFile
+	if err = yaml.Unmarshal(content, &patchSetFile); err != nil {
+		return nil, errors.Join(ErrReadPatchSetFile, fmt.Errorf("file: %s", file), err)
+	}
+
+	baseDir := filepath.Dir(file)
+	for i := range patchSetFile.PatchSets {
+		for j, p := range patchSetFile.PatchSets[i].Patches {
+			if p.File != "" && !filepath.IsAbs(p.File) {
+				patchSetFile.PatchSets[i].Patches[j].File = filepath.Join(baseDir, p.File)
+			}
+		}
+	}
+
+	return patchSetFile.PatchSets, nil
+}
+
+// ResolvePatchSets resolves the patch sets into a list of patches, definitions are user-defined sets in addition to the builtin sets.
+// Unknown sets, unknown builtin patches and config entries for patches that are not part of the set are reported as error.
+func ResolvePatchSets(patchSets []PatchSet, definitions []PatchPreset) ([]sharedpatch.SpecPatch, error) {
 	var resolvedPatches []sharedpatch.SpecPatch
 
+	// merge builtin and user-defined sets
+	sets := make(map[string]PatchPreset, len(allPatchSets)+len(definitions))
+	for id, set := range allPatchSets {
+		sets[id] = set
+	}
+	for _, set := range definitions {
+		if _, exists := sets[set.Id]; exists {
+			return nil, errors.Join(ErrDuplicatePatchSet, fmt.Errorf("id: %s", set.Id))
+		}
+		sets[set.Id] = set
+	}
+
 	// resolve sets
 	for _, patchSet := range patchSets {
-		if patchSetConfig, patchSetConfigOk := allPatchSets[patchSet.Id]; patchSetConfigOk {
-			for _, patch := range patchSetConfig.Patches {
-				// apply config per patch ID if available
-				if cfg, cfgOk := patchSet.Config[patch.ID]; cfgOk {
-					patch.Config = cfg
-				}
-
-				// apply patch
-				resolvedPatches = append(resolvedPatches, patch)
+		patches, err := expandPatchSet(patchSet.Id, sets, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// config entries must match a patch of the set
+		for _, patchId := range slices.Sorted(maps.Keys(patchSet.Config)) {
+			if !slices.ContainsFunc(patches, func(p sharedpatch.SpecPatch) bool { return p.ID == patchId }) {
+				return nil, errors.Join(ErrUnknownPatchConfig, fmt.Errorf("patch set: %s, patch: %s", patchSet.Id, patchId))
+			}
+		}
+
+		for _, patch := range patches {
+			// apply config per patch ID if available
+			if cfg, cfgOk := patchSet.Config[patch.ID]; cfgOk {
+				patch.Config = cfg
 			}
+
+			// apply patch
+			resolvedPatches = append(resolvedPatches, patch)
+		}
+	}
+
+	return resolvedPatches, nil
+}
+
+func expandPatchSet(id string, sets map[string]PatchPreset, stack []string) ([]sharedpatch.SpecPatch, error) {
+	if slices.Contains(stack, id) {
+		return nil, errors.Join(ErrCircularPatchSet, fmt.Errorf("chain: %s", strings.Join(append(stack, id), " -> ")))
+	}
+	set, ok := sets[id]
+	if !ok {
+		return nil, errors.Join(ErrUnknownPatchSet, fmt.Errorf("id: %s", id))
+	}
+	stack = append(stack, id)
+
+	var patches []sharedpatch.SpecPatch
+	for _, parent := range set.Extends {
+		parentPatches, err := expandPatchSet(parent, sets, stack)
+		if err != nil {
+			return nil, err
 		}
+		patches = append(patches, parentPatches...)
 	}
 
-	return resolvedPatches
+	for _, p := range set.Patches {
+		if p.Type == "" {
+			p.Type = "builtin"
+		}
+		if err := validatePatchSetEntry(p); err != nil {
+			return nil, errors.Join(err, fmt.Errorf("patch set: %s", id))
+		}
+		patches = append(patches, p)
+	}
+
+	return patches, nil
+}
+
+// validatePatchSetEntry ensures that builtin patches exist and that external patches have a file or content
+func validatePatchSetEntry(p sharedpatch.SpecPatch) error {
+	if p.ID != "" {
+		if _, ok := EmbeddedPatcherMap[p.Type+":"+p.ID]; !ok {
+			return errors.Join(ErrUnknownPatch, fmt.Errorf("patch: %s", p.String()))
+		}
+		return nil
+	}
+
+	switch p.Type {
+	case "file", string(sharedpatch.PatchTypeJSONPatch), string(sharedpatch.PatchTypeGitPatch), string(sharedpatch.PatchTypeOpenAPIOverlay):
+		if p.File == "" && p.Content == "" {
+			return errors.Join(ErrInvalidPatchSetEntry, sharedpatch.ErrExternalPatchMustHaveContentOrFile, fmt.Errorf("patch: %s", p.String()))
+		}
+		return nil
+	default:
+		return errors.Join(ErrInvalidPatchSetEntry, sharedpatch.ErrUnsupportedPatchType, fmt.Errorf("type: %s", p.Type))
+	}
 }
@@ -0,0 +1,145 @@
+package openapipatch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/primelib/primecodegen/pkg/patch/sharedpatch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePatchSets_Builtin(t *testing.T) {
+	patches, err := ResolvePatchSets([]PatchSet{{Id: "code-generation", Config: map[string]map[string]interface{}{
+		"prune-path-prefix": {"prefix": "/api"},
+	}}}, nil)
+	require.NoError(t, err)
+
+	assert.Len(t, patches, len(allPatchSets["code-generation"].Patches))
+	assert.Equal(t, "prune-path-prefix", patches[0].ID)
+	assert.Equal(t, map[string]interface{}{"prefix": "/api"}, patches[0].Config)
+}
+
+func TestResolvePatchSets_UserDefinedExtends(t *testing.T) {
+	definitions := []PatchPreset{
+		{
+			Id:      "base",
+			Extends: []string{"code-generation"},
+			Patches: []sharedpatch.SpecPatch{{ID: "prune-operation-tags"}},
+		},
+		{
+			Id:      "custom",
+			Extends: []string{"base"},
+			Patches: []sharedpatch.SpecPatch{
+				{Type: "openapi-overlay", File: "overlay.yaml"},
+				{Type: "builtin", ID: "set-operation-tag", Config: map[string]interface{}{"tag": "pets"}},
+			},
+		},
+	}
+
+	patches, err := ResolvePatchSets([]PatchSet{{Id: "custom"}}, definitions)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, p := range patches {
+		ids = append(ids, p.String())
+	}
+	assert.Equal(t, []string{
+		"builtin:prune-path-prefix",
+		"builtin:generate-operation-id",
+		"builtin:fix-missing-oneof-from-discriminator",
+		"builtin:simplify-polymorphic-schemas",
+		"builtin:flatten-components",
+		"builtin:fix-missing-schema-title",
+		"builtin:fix-common",
+		"builtin:prune-operation-tags",
+		"openapi-overlay:overlay.yaml",
+		"builtin:set-operation-tag",
+	}, ids)
+	assert.Equal(t, map[string]interface{}{"tag": "pets"}, patches[len(patches)-1].Config)
+}
+
+func TestResolvePatchSets_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		sets        []PatchSet
+		definitions []PatchPreset
+		err         error
+	}{
+		{
+			name: "unknown set",
+			sets: []PatchSet{{Id: "does-not-exist"}},
+			err:  ErrUnknownPatchSet,
+		},
+		{
+			name:        "unknown extended set",
+			sets:        []PatchSet{{Id: "custom"}},
+			definitions: []PatchPreset{{Id: "custom", Extends: []string{"does-not-exist"}}},
+			err:         ErrUnknownPatchSet,
+		},
+		{
+			name:        "unknown builtin patch",
+			sets:        []PatchSet{{Id: "custom"}},
+			definitions: []PatchPreset{{Id: "custom", Patches: []sharedpatch.SpecPatch{{ID: "does-not-exist"}}}},
+			err:         ErrUnknownPatch,
+		},
+		{
+			name:        "external patch without file",
+			sets:        []PatchSet{{Id: "custom"}},
+			definitions: []PatchPreset{{Id: "custom", Patches: []sharedpatch.SpecPatch{{Type: "openapi-overlay"}}}},
+			err:         ErrInvalidPatchSetEntry,
+		},
+		{
+			name: "config for patch outside of the set",
+			sets: []PatchSet{{Id: "code-generation", Config: map[string]map[string]interface{}{"prune-operation-tags": {}}}},
+			err:  ErrUnknownPatchConfig,
+		},
+		{
+			name:        "duplicate set",
+			definitions: []PatchPreset{{Id: "code-generation"}},
+			err:         ErrDuplicatePatchSet,
+		},
+		{
+			name: "circular set",
+			sets: []PatchSet{{Id: "a"}},
+			definitions: []PatchPreset{
+				{Id: "a", Extends: []string{"b"}},
+				{Id: "b", Extends: []string{"a"}},
+			},
+			err: ErrCircularPatchSet,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ResolvePatchSets(tt.sets, tt.definitions)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestLoadPatchSetFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "patchsets.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`patchSets:
+  - id: custom
+    extends: [code-generation]
+    patches:
+      - id: prune-operation-tags
+      - type: openapi-overlay
+        file: overlays/info.yaml
+`), 0644))
+
+	definitions, err := LoadPatchSetFile(file)
+	require.NoError(t, err)
+
+	require.Len(t, definitions, 1)
+	assert.Equal(t, "custom", definitions[0].Id)
+	assert.Equal(t, []string{"code-generation"}, definitions[0].Extends)
+	assert.Equal(t, filepath.Join(dir, "overlays/info.yaml"), definitions[0].Patches[1].File)
+
+	patches, err := ResolvePatchSets([]PatchSet{{Id: "custom"}}, definitions)
+	require.NoError(t, err)
+	assert.Equal(t, "builtin", patches[len(patches)-2].Type)
+}
@@ -0,0 +1,38 @@
+package template
+
+import (
+	"bytes"
+	"os"
+
+	"github.com/primelib/primecodegen/pkg/template/templateapi"
+)
+
+// FileChecksum returns the checksum of a generated file on disk, the content of custom regions is excluded as it is not generated
+func FileChecksum(content []byte) string {
+	segments, err := splitCustomRegions(content)
+	if err != nil {
+		return templateapi.Checksum(content)
+	}
+
+	var generated bytes.Buffer
+	for _, s := range segments {
+		if s.name == "" {
+			generated.WriteString(s.content)
+		} else {
+			generated.WriteString(s.begin)
+			generated.WriteString(s.end)
+		}
+	}
+	return templateapi.Checksum(generated.Bytes())
+}
+
+// IsUnchanged checks if the rendered content matches the previous run and the file on disk was not modified since
+func IsUnchanged(file string, checksum string, checksums map[string]templateapi.FileChecksum) bool {
+	previous, ok := checksums[file]
+	if !ok || previous.Rendered != checksum || previous.File == "" {
+		return false
+	}
+
+	content, err := os.ReadFile(file)
+	return err == nil && FileChecksum(content) == previous.File
+}
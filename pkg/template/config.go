@@ -2,17 +2,27 @@ package template
 
 import (
 	"github.com/primelib/primecodegen/pkg/template/templateapi"
+	openapi_csharp_httpclient "github.com/primelib/primecodegen/pkg/template/templates/openapi-csharp-httpclient"
 	openapi_default_scaffolding "github.com/primelib/primecodegen/pkg/template/templates/openapi-default-scaffolding"
 	openapi_go_httpclient "github.com/primelib/primecodegen/pkg/template/templates/openapi-go-httpclient"
+	openapi_go_server "github.com/primelib/primecodegen/pkg/template/templates/openapi-go-server"
 	openapi_java_httpclient "github.com/primelib/primecodegen/pkg/template/templates/openapi-java-httpclient"
 	openapi_kotlin_httpclient "github.com/primelib/primecodegen/pkg/template/templates/openapi-kotlin-httpclient"
+	openapi_python_httpclient "github.com/primelib/primecodegen/pkg/template/templates/openapi-python-httpclient"
+	openapi_rust_httpclient "github.com/primelib/primecodegen/pkg/template/templates/openapi-rust-httpclient"
+	openapi_typescript_httpclient "github.com/primelib/primecodegen/pkg/template/templates/openapi-typescript-httpclient"
 )
 
 var defaultSnippets = []string{"global-layout.gohtml"}
 
 var allTemplates = map[string]templateapi.Config{
-	openapi_go_httpclient.Template.ID:       openapi_go_httpclient.Template,
-	openapi_java_httpclient.Template.ID:     openapi_java_httpclient.Template,
-	openapi_kotlin_httpclient.Template.ID:   openapi_kotlin_httpclient.Template,
-	openapi_default_scaffolding.Template.ID: openapi_default_scaffolding.Template,
+	openapi_csharp_httpclient.Template.ID:     openapi_csharp_httpclient.Template,
+	openapi_go_httpclient.Template.ID:         openapi_go_httpclient.Template,
+	openapi_go_server.Template.ID:             openapi_go_server.Template,
+	openapi_java_httpclient.Template.ID:       openapi_java_httpclient.Template,
+	openapi_kotlin_httpclient.Template.ID:     openapi_kotlin_httpclient.Template,
+	openapi_python_httpclient.Template.ID:     openapi_python_httpclient.Template,
+	openapi_rust_httpclient.Template.ID:       openapi_rust_httpclient.Template,
+	openapi_typescript_httpclient.Template.ID: openapi_typescript_httpclient.Template,
+	openapi_default_scaffolding.Template.ID:   openapi_default_scaffolding.Template,
 }
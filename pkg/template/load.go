@@ -0,0 +1,102 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/primelib/primecodegen/pkg/template/templateapi"
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFile is the name of the manifest in a template directory
+const ManifestFile = "template.yaml"
+
+// ResolveTemplate returns the embedded template with the given id, or the template loaded from templateDir if set
+func ResolveTemplate(templateId string, templateDir string) (templateapi.Config, error) {
+	if templateDir != "" {
+		return LoadTemplateDir(templateDir, templateId)
+	}
+
+	templateConfig, exists := allTemplates[templateId]
+	if !exists {
+		return templateapi.Config{}, errors.Join(templateapi.ErrTemplateNotFound, fmt.Errorf("template id not found: %s", templateId))
+	}
+	return templateConfig, nil
+}
+
+// LoadTemplateDir loads a template set from a directory on disk.
+//
+// If the directory contains a template.yaml manifest, the manifest defines the template set.
+// A manifest with extends but without files uses the files of the extended embedded template.
+// Without a manifest, the directory overrides individual files of the embedded template baseTemplateId by name.
+func LoadTemplateDir(dir string, baseTemplateId string) (templateapi.Config, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return templateapi.Config{}, errors.Join(templateapi.ErrInvalidTemplateDir, err)
+	}
+	if !info.IsDir() {
+		return templateapi.Config{}, errors.Join(templateapi.ErrInvalidTemplateDir, fmt.Errorf("not a directory: %s", dir))
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		// override files of the embedded template
+		base, exists := allTemplates[baseTemplateId]
+		if !exists {
+			return templateapi.Config{}, errors.Join(templateapi.ErrTemplateNotFound, fmt.Errorf("template directory %s has no %s and template id %s is not embedded", dir, ManifestFile, baseTemplateId))
+		}
+		base.Dir = dir
+		return base, nil
+	} else if err != nil {
+		return templateapi.Config{}, errors.Join(templateapi.ErrInvalidTemplateManifest, err)
+	}
+
+	var config templateapi.Config
+	if err = yaml.Unmarshal(content, &config); err != nil {
+		return templateapi.Config{}, errors.Join(templateapi.ErrInvalidTemplateManifest, fmt.Errorf("file: %s", filepath.Join(dir, ManifestFile)), err)
+	}
+	if config.ID == "" {
+		config.ID = filepath.Base(dir)
+	}
+	config.Dir = dir
+
+	if config.Extends != "" {
+		base, exists := allTemplates[config.Extends]
+		if !exists {
+			return templateapi.Config{}, errors.Join(templateapi.ErrInvalidTemplateManifest, templateapi.ErrTemplateNotFound, fmt.Errorf("extends: %s", config.Extends))
+		}
+		if len(config.Files) == 0 {
+			config.Files = base.Files
+		}
+	}
+
+	if err = validateTemplateConfig(config); err != nil {
+		return templateapi.Config{}, err
+	}
+
+	return config, nil
+}
+
+// validateTemplateConfig ensures that each file has a known type and a source
+func validateTemplateConfig(config templateapi.Config) error {
+	if len(config.Files) == 0 {
+		return errors.Join(templateapi.ErrInvalidTemplateManifest, fmt.Errorf("template %s has no files", config.ID))
+	}
+
+	for i, file := range config.Files {
+		if !slices.Contains(templateapi.AllTypes, file.Type) {
+			return errors.Join(templateapi.ErrInvalidTemplateManifest, fmt.Errorf("file %d (%s) has unsupported type: %q", i, file.TargetFileName, file.Type))
+		}
+		if file.SourceTemplate == "" && file.SourceFile == "" && file.SourceUrl == "" {
+			return errors.Join(templateapi.ErrInvalidTemplateManifest, templateapi.ErrTemplateFileOrUrlIsRequired, fmt.Errorf("file %d (%s)", i, file.TargetFileName))
+		}
+		if file.TargetFileName == "" {
+			return errors.Join(templateapi.ErrInvalidTemplateManifest, fmt.Errorf("file %d (%s) has no targetFileName", i, file.SourceTemplate))
+		}
+	}
+
+	return nil
+}
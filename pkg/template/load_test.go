@@ -0,0 +1,136 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/primelib/primecodegen/pkg/template/templateapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTemplateFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestLoadTemplateDir_OverrideEmbeddedFiles(t *testing.T) {
+	dir := writeTemplateFiles(t, map[string]string{
+		"model.gohtml": `{{ template "header-singleline" }}
+type {{ .model }} struct{}`,
+	})
+
+	config, err := LoadTemplateDir(dir, "openapi-go-httpclient")
+	require.NoError(t, err)
+	assert.Equal(t, "openapi-go-httpclient", config.ID)
+	assert.Equal(t, allTemplates["openapi-go-httpclient"].Files, config.Files)
+
+	// overridden file
+	content, err := readTemplateFile(config, "model.gohtml")
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "type {{ .model }} struct{}")
+
+	// fallback to the embedded files
+	content, err = readTemplateFile(config, "client.gohtml")
+	require.NoError(t, err)
+	assert.NotEmpty(t, content)
+}
+
+func TestLoadTemplateDir_Manifest(t *testing.T) {
+	dir := writeTemplateFiles(t, map[string]string{
+		ManifestFile: `id: my-template
+description: custom models
+files:
+  - sourceTemplate: model.gohtml
+    snippets: [global-layout.gohtml]
+    targetDirectory: "models"
+    targetFileName: "{{ snakeCase .model }}.txt"
+    type: model_each
+    kind: model
+`,
+		"model.gohtml": `{{ template "header-hash" }}
+model {{ .model }}`,
+	})
+	outputDir := t.TempDir()
+
+	config, err := LoadTemplateDir(dir, "")
+	require.NoError(t, err)
+	assert.Equal(t, "my-template", config.ID)
+	assert.Equal(t, dir, config.Dir)
+	require.Len(t, config.Files, 1)
+	assert.Equal(t, templateapi.KindModel, config.Files[0].Kind)
+
+	files, err := RenderTemplate(config, outputDir, templateapi.TypeModelEach, map[string]string{"model": "PetOwner"}, templateapi.RenderOpts{})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "models", "pet_owner.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "# WARNING: This file was generated by PrimeCodeGen. DO NOT EDIT.\nmodel PetOwner", string(content))
+}
+
+func TestLoadTemplateDir_ManifestExtends(t *testing.T) {
+	dir := writeTemplateFiles(t, map[string]string{
+		ManifestFile: "id: my-go\nextends: openapi-go-httpclient\n",
+	})
+
+	config, err := LoadTemplateDir(dir, "")
+	require.NoError(t, err)
+	assert.Equal(t, "my-go", config.ID)
+	assert.Equal(t, allTemplates["openapi-go-httpclient"].Files, config.Files)
+
+	content, err := readTemplateFile(config, "model.gohtml")
+	require.NoError(t, err)
+	assert.NotEmpty(t, content)
+}
+
+func TestLoadTemplateDir_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		err      error
+	}{
+		{
+			name:     "unknown type",
+			manifest: "files:\n  - sourceTemplate: a.gohtml\n    targetFileName: a.txt\n    type: model_once\n",
+			err:      templateapi.ErrInvalidTemplateManifest,
+		},
+		{
+			name:     "missing source",
+			manifest: "files:\n  - targetFileName: a.txt\n    type: model_each\n",
+			err:      templateapi.ErrTemplateFileOrUrlIsRequired,
+		},
+		{
+			name:     "unknown extends",
+			manifest: "extends: openapi-cobol-httpclient\n",
+			err:      templateapi.ErrTemplateNotFound,
+		},
+		{
+			name:     "no files",
+			manifest: "id: empty\n",
+			err:      templateapi.ErrInvalidTemplateManifest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeTemplateFiles(t, map[string]string{ManifestFile: tt.manifest})
+
+			_, err := LoadTemplateDir(dir, "")
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	t.Run("no manifest and unknown base template", func(t *testing.T) {
+		_, err := LoadTemplateDir(t.TempDir(), "openapi-cobol-httpclient")
+		assert.ErrorIs(t, err, templateapi.ErrTemplateNotFound)
+	})
+	t.Run("missing directory", func(t *testing.T) {
+		_, err := LoadTemplateDir(filepath.Join(t.TempDir(), "missing"), "openapi-go-httpclient")
+		assert.ErrorIs(t, err, templateapi.ErrInvalidTemplateDir)
+	})
+}
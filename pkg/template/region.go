@@ -0,0 +1,185 @@
+package template
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/primelib/primecodegen/pkg/template/templateapi"
+)
+
+// CustomRegionBegin and CustomRegionEnd mark hand-written code in generated files, followed by the region name.
+// The content between the markers is kept when the file is generated again, the comment syntax depends on the language.
+const (
+	CustomRegionBegin = "primecodegen:custom-begin"
+	CustomRegionEnd   = "primecodegen:custom-end"
+)
+
+// regionSegment is either generated content (name is empty) or a custom region
+type regionSegment struct {
+	name    string
+	begin   string
+	content string
+	end     string
+}
+
+// splitCustomRegions splits the content into generated content and custom regions, markers are matched line by line
+func splitCustomRegions(content []byte) ([]regionSegment, error) {
+	var segments []regionSegment
+	var current *regionSegment
+	var generated strings.Builder
+	names := make(map[string]bool)
+
+	for i, line := range strings.SplitAfter(string(content), "\n") {
+		if name, ok := customRegionMarker(line, CustomRegionBegin); ok {
+			if current != nil {
+				return nil, errors.Join(templateapi.ErrInvalidCustomRegion, fmt.Errorf("line %d: region %s begins before region %s ends", i+1, name, current.name))
+			}
+			if name == "" {
+				return nil, errors.Join(templateapi.ErrInvalidCustomRegion, fmt.Errorf("line %d: region has no name", i+1))
+			}
+			if names[name] {
+				return nil, errors.Join(templateapi.ErrInvalidCustomRegion, fmt.Errorf("line %d: duplicate region %s", i+1, name))
+			}
+			names[name] = true
+
+			segments = append(segments, regionSegment{content: generated.String()})
+			generated.Reset()
+			current = &regionSegment{name: name, begin: line}
+		} else if name, ok := customRegionMarker(line, CustomRegionEnd); ok {
+			if current == nil || (name != "" && name != current.name) {
+				return nil, errors.Join(templateapi.ErrInvalidCustomRegion, fmt.Errorf("line %d: end of region %s without begin", i+1, name))
+			}
+			current.end = line
+			segments = append(segments, *current)
+			current = nil
+		} else if current != nil {
+			current.content += line
+		} else {
+			generated.WriteString(line)
+		}
+	}
+	if current != nil {
+		return nil, errors.Join(templateapi.ErrInvalidCustomRegion, fmt.Errorf("region %s has no end marker", current.name))
+	}
+	segments = append(segments, regionSegment{content: generated.String()})
+
+	return segments, nil
+}
+
+// customRegionMarker returns the region name if the line contains the marker
+func customRegionMarker(line string, marker string) (string, bool) {
+	_, after, ok := strings.Cut(line, marker)
+	if !ok {
+		return "", false
+	}
+	if fields := strings.Fields(after); len(fields) > 0 {
+		return fields[0], true
+	}
+	return "", true
+}
+
+// mergeCustomRegions restores the custom regions of the existing file in the rendered content.
+// Regions of the existing file with content that are not part of the rendered content are returned as orphaned, in the order of the existing file.
+func mergeCustomRegions(rendered []byte, existing []byte) ([]byte, []regionSegment, error) {
+	if !bytes.Contains(existing, []byte(CustomRegionBegin)) {
+		return rendered, nil, nil
+	}
+
+	existingSegments, err := splitCustomRegions(existing)
+	if err != nil {
+		return nil, nil, fmt.Errorf("existing file: %w", err)
+	}
+	renderedSegments, err := splitCustomRegions(rendered)
+	if err != nil {
+		return nil, nil, fmt.Errorf("rendered template: %w", err)
+	}
+
+	existingRegions := make(map[string]string)
+	for _, s := range existingSegments {
+		if s.name != "" {
+			existingRegions[s.name] = s.content
+		}
+	}
+
+	var output bytes.Buffer
+	for _, s := range renderedSegments {
+		if s.name == "" {
+			output.WriteString(s.content)
+			continue
+		}
+
+		output.WriteString(s.begin)
+		if content, ok := existingRegions[s.name]; ok {
+			output.WriteString(content)
+			delete(existingRegions, s.name)
+		} else {
+			output.WriteString(s.content)
+		}
+		output.WriteString(s.end)
+	}
+
+	var orphaned []regionSegment
+	for _, s := range existingSegments {
+		if _, ok := existingRegions[s.name]; ok && s.name != "" && strings.TrimSpace(s.content) != "" {
+			orphaned = append(orphaned, s)
+		}
+	}
+
+	return output.Bytes(), orphaned, nil
+}
+
+// OrphanedRegionsFile returns the file that keeps the custom regions which are no longer part of the generated file
+func OrphanedRegionsFile(file string) string {
+	return file + ".orphaned"
+}
+
+// KeepCustomRegions moves the custom regions with content of a file that is about to be removed to the orphaned regions file
+func KeepCustomRegions(file string) error {
+	content, err := os.ReadFile(file)
+	if err != nil || !bytes.Contains(content, []byte(CustomRegionBegin)) {
+		return nil
+	}
+
+	segments, err := splitCustomRegions(content)
+	if err != nil {
+		return fmt.Errorf("file %s: %w", file, err)
+	}
+	var regions []regionSegment
+	for _, s := range segments {
+		if s.name != "" && strings.TrimSpace(s.content) != "" {
+			regions = append(regions, s)
+		}
+	}
+	if len(regions) == 0 {
+		return nil
+	}
+
+	return appendOrphanedRegions(file, regions)
+}
+
+// appendOrphanedRegions appends the regions including their markers to the orphaned regions file, so hand-written code is never lost
+func appendOrphanedRegions(file string, regions []regionSegment) error {
+	var content strings.Builder
+	for _, r := range regions {
+		content.WriteString(r.begin)
+		content.WriteString(r.content)
+		content.WriteString(r.end)
+		if !strings.HasSuffix(r.end, "\n") {
+			content.WriteString("\n")
+		}
+	}
+
+	f, err := os.OpenFile(OrphanedRegionsFile(file), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open orphaned regions file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err = f.WriteString(content.String()); err != nil {
+		return fmt.Errorf("failed to write orphaned regions file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/primelib/primecodegen/pkg/template/templateapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeCustomRegions(t *testing.T) {
+	rendered := `package models
+
+type Pet struct{}
+
+// primecodegen:custom-begin methods
+// primecodegen:custom-end methods
+
+// primecodegen:custom-begin validation
+func (p Pet) Validate() error { return nil }
+// primecodegen:custom-end validation
+`
+	existing := `package models
+
+type Pet struct{ Name string }
+
+// primecodegen:custom-begin methods
+func (p Pet) String() string { return p.Name }
+// primecodegen:custom-end methods
+
+// primecodegen:custom-begin helpers
+func helper() {}
+// primecodegen:custom-end
+`
+
+	output, orphaned, err := mergeCustomRegions([]byte(rendered), []byte(existing))
+	require.NoError(t, err)
+	assert.Equal(t, `package models
+
+type Pet struct{}
+
+// primecodegen:custom-begin methods
+func (p Pet) String() string { return p.Name }
+// primecodegen:custom-end methods
+
+// primecodegen:custom-begin validation
+func (p Pet) Validate() error { return nil }
+// primecodegen:custom-end validation
+`, string(output))
+	require.Len(t, orphaned, 1)
+	assert.Equal(t, "helpers", orphaned[0].name)
+	assert.Equal(t, "func helper() {}\n", orphaned[0].content)
+}
+
+func TestMergeCustomRegions_Invalid(t *testing.T) {
+	rendered := "// primecodegen:custom-begin methods\n// primecodegen:custom-end methods\n"
+
+	tests := []struct {
+		name     string
+		existing string
+	}{
+		{name: "missing end", existing: "// primecodegen:custom-begin methods\nfunc a() {}\n"},
+		{name: "missing begin", existing: "// primecodegen:custom-begin methods\n// primecodegen:custom-end methods\n// primecodegen:custom-end other\n"},
+		{name: "nested", existing: "// primecodegen:custom-begin methods\n// primecodegen:custom-begin other\n// primecodegen:custom-end other\n// primecodegen:custom-end methods\n"},
+		{name: "duplicate", existing: "// primecodegen:custom-begin methods\n// primecodegen:custom-end\n// primecodegen:custom-begin methods\n// primecodegen:custom-end\n"},
+		{name: "no name", existing: "// primecodegen:custom-begin\n// primecodegen:custom-end\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := mergeCustomRegions([]byte(rendered), []byte(tt.existing))
+			assert.ErrorIs(t, err, templateapi.ErrInvalidCustomRegion)
+		})
+	}
+}
+
+func TestRenderTemplate_KeepsCustomRegions(t *testing.T) {
+	dir := writeTemplateFiles(t, map[string]string{
+		"model.gohtml": "type {{ .model }} struct{}\n\n# primecodegen:custom-begin methods\n# primecodegen:custom-end methods\n",
+	})
+	config, err := LoadTemplateDir(dir, "openapi-go-httpclient")
+	require.NoError(t, err)
+	config.Files = []templateapi.File{{SourceTemplate: "model.gohtml", TargetFileName: "model.txt", Type: templateapi.TypeModelEach}}
+	outputDir := t.TempDir()
+	targetFile := filepath.Join(outputDir, "model.txt")
+
+	_, err = RenderTemplate(config, outputDir, templateapi.TypeModelEach, map[string]string{"model": "Pet"}, templateapi.RenderOpts{})
+	require.NoError(t, err)
+
+	// hand-written code is kept, generated code is updated
+	require.NoError(t, os.WriteFile(targetFile, []byte("type Pet struct{}\n\n# primecodegen:custom-begin methods\nfunc (p Pet) Name() {}\n# primecodegen:custom-end methods\n"), 0644))
+	_, err = RenderTemplate(config, outputDir, templateapi.TypeModelEach, map[string]string{"model": "Dog"}, templateapi.RenderOpts{})
+	require.NoError(t, err)
+	content, err := os.ReadFile(targetFile)
+	require.NoError(t, err)
+	assert.Equal(t, "type Dog struct{}\n\n# primecodegen:custom-begin methods\nfunc (p Pet) Name() {}\n# primecodegen:custom-end methods\n", string(content))
+
+	// broken markers in the existing file are not overwritten
+	broken := "type Pet struct{}\n\n# primecodegen:custom-begin methods\nfunc (p Pet) Name() {}\n"
+	require.NoError(t, os.WriteFile(targetFile, []byte(broken), 0644))
+	_, err = RenderTemplate(config, outputDir, templateapi.TypeModelEach, map[string]string{"model": "Pet"}, templateapi.RenderOpts{})
+	assert.ErrorIs(t, err, templateapi.ErrInvalidCustomRegion)
+	content, err = os.ReadFile(targetFile)
+	require.NoError(t, err)
+	assert.Equal(t, broken, string(content))
+}
+
+func TestRenderTemplate_KeepsOrphanedCustomRegions(t *testing.T) {
+	dir := writeTemplateFiles(t, map[string]string{
+		"model.gohtml": "type {{ .model }} struct{}\n{{ if .methods }}\n# primecodegen:custom-begin methods\n# primecodegen:custom-end methods\n{{ end }}",
+	})
+	config, err := LoadTemplateDir(dir, "openapi-go-httpclient")
+	require.NoError(t, err)
+	config.Files = []templateapi.File{{SourceTemplate: "model.gohtml", TargetFileName: "model.txt", Type: templateapi.TypeModelEach}}
+	outputDir := t.TempDir()
+	targetFile := filepath.Join(outputDir, "model.txt")
+
+	// regions that are no longer rendered are moved to the orphaned regions file
+	require.NoError(t, os.WriteFile(targetFile, []byte("type Pet struct{}\n\n# primecodegen:custom-begin methods\nfunc (p Pet) Name() {}\n# primecodegen:custom-end methods"), 0644))
+	_, err = RenderTemplate(config, outputDir, templateapi.TypeModelEach, map[string]string{"model": "Pet"}, templateapi.RenderOpts{})
+	require.NoError(t, err)
+	content, err := os.ReadFile(targetFile)
+	require.NoError(t, err)
+	assert.Equal(t, "type Pet struct{}\n", string(content))
+	orphaned, err := os.ReadFile(OrphanedRegionsFile(targetFile))
+	require.NoError(t, err)
+	assert.Equal(t, "# primecodegen:custom-begin methods\nfunc (p Pet) Name() {}\n# primecodegen:custom-end methods\n", string(orphaned))
+
+	// further orphaned regions are appended
+	require.NoError(t, os.WriteFile(targetFile, []byte("type Pet struct{}\n// primecodegen:custom-begin extra\nvar x = 1\n// primecodegen:custom-end\n"), 0644))
+	_, err = RenderTemplate(config, outputDir, templateapi.TypeModelEach, map[string]string{"model": "Pet"}, templateapi.RenderOpts{})
+	require.NoError(t, err)
+	orphaned, err = os.ReadFile(OrphanedRegionsFile(targetFile))
+	require.NoError(t, err)
+	assert.Equal(t, "# primecodegen:custom-begin methods\nfunc (p Pet) Name() {}\n# primecodegen:custom-end methods\n// primecodegen:custom-begin extra\nvar x = 1\n// primecodegen:custom-end\n", string(orphaned))
+}
+
+func TestFileChecksum_IgnoresCustomRegions(t *testing.T) {
+	generated := "type Pet struct{}\n// primecodegen:custom-begin methods\n// primecodegen:custom-end methods\n"
+	edited := "type Pet struct{}\n// primecodegen:custom-begin methods\nfunc (p Pet) Name() {}\n// primecodegen:custom-end methods\n"
+
+	assert.Equal(t, FileChecksum([]byte(generated)), FileChecksum([]byte(edited)))
+	assert.NotEqual(t, FileChecksum([]byte(generated)), FileChecksum([]byte("type Dog struct{}\n// primecodegen:custom-begin methods\n// primecodegen:custom-end methods\n")))
+}
@@ -21,9 +21,9 @@ import (
 var templateFS embed.FS
 
 func RenderTemplateById(templateId string, outputDir string, templateType templateapi.Type, data interface{}, opts templateapi.RenderOpts) (map[string]templateapi.RenderedFile, error) {
-	templateConfig, exists := allTemplates[templateId]
-	if !exists {
-		return nil, errors.Join(templateapi.ErrTemplateNotFound, fmt.Errorf("template id not found: %s", templateId))
+	templateConfig, err := ResolveTemplate(templateId, "")
+	if err != nil {
+		return nil, err
 	}
 
 	return RenderTemplate(templateConfig, outputDir, templateType, data, opts)
@@ -42,7 +42,7 @@ func RenderTemplate(config templateapi.Config, outputDir string, templateType te
 			continue
 		}
 
-		t, err := loadTemplate(config.ID, append([]string{file.SourceTemplate}, file.Snippets...), opts.TemplateFunctions)
+		t, err := loadTemplate(config, append([]string{file.SourceTemplate}, file.Snippets...), opts.TemplateFunctions)
 		if err != nil {
 			return nil, errors.Join(templateapi.ErrFailedToParseTemplate, fmt.Errorf("template in %s, file %s: %w", config.ID, file.SourceTemplate, err))
 		}
@@ -74,7 +74,7 @@ func RenderTemplate(config templateapi.Config, outputDir string, templateType te
 					return
 				}
 			} else if file.SourceFile != "" {
-				content, err := readTemplateFile([]string{config.ID, "_global"}, file.SourceFile)
+				content, err := readTemplateFile(config, file.SourceFile)
 				if err != nil {
 					select {
 					case errCh <- errors.Join(templateapi.ErrFailedToCopyTemplateFile, fmt.Errorf("failed to read template file %s: %w", file.SourceFile, err)):
@@ -130,14 +130,43 @@ func RenderTemplate(config templateapi.Config, outputDir string, templateType te
 				output = opts.PostProcess(resolvedFile, output)
 			}
 
+			checksum := templateapi.Checksum(output)
+
 			var state templateapi.FileState
-			if opts.DryRun {
-				state = templateapi.FileDryRun
-			} else if skippedByName {
+			if skippedByName {
 				state = templateapi.FileSkippedName
 			} else if skippedByScope {
 				state = templateapi.FileSkippedScope
+			} else if IsUnchanged(targetFile, checksum, opts.Checksums) {
+				state = templateapi.FileUnchanged
+			} else if opts.DryRun {
+				state = templateapi.FileDryRun
 			} else {
+				// keep hand-written code in the custom regions of the existing file
+				if existing, readErr := os.ReadFile(targetFile); readErr == nil {
+					var orphaned []regionSegment
+					output, orphaned, err = mergeCustomRegions(output, existing)
+					if err != nil {
+						select {
+						case errCh <- fmt.Errorf("failed to keep custom regions of %s: %w", targetFile, err):
+						default:
+						}
+						return
+					}
+					if len(orphaned) > 0 {
+						if err = appendOrphanedRegions(targetFile, orphaned); err != nil {
+							select {
+							case errCh <- fmt.Errorf("failed to keep orphaned custom regions of %s: %w", targetFile, err):
+							default:
+							}
+							return
+						}
+						for _, r := range orphaned {
+							slog.Warn("Custom region is no longer part of the generated file and was moved", "file", targetFile, "region", r.name, "target", OrphanedRegionsFile(targetFile))
+						}
+					}
+				}
+
 				err = os.MkdirAll(targetDir, 0755)
 				if err != nil {
 					select {
@@ -160,7 +189,7 @@ func RenderTemplate(config templateapi.Config, outputDir string, templateType te
 			slog.Debug("Rendered file", "template-id", config.ID, "file", targetFile)
 
 			filesMutex.Lock()
-			files[targetFile] = templateapi.RenderedFile{File: targetFile, TemplateFile: file.SourceTemplate, State: state}
+			files[targetFile] = templateapi.RenderedFile{File: targetFile, TemplateFile: file.SourceTemplate, State: state, Checksum: checksum}
 			filesMutex.Unlock()
 		}()
 	}
@@ -175,12 +204,11 @@ func RenderTemplate(config templateapi.Config, outputDir string, templateType te
 	return files, nil
 }
 
-func loadTemplate(templateId string, files []string, customFunctions template.FuncMap) (*template.Template, error) {
+func loadTemplate(config templateapi.Config, files []string, customFunctions template.FuncMap) (*template.Template, error) {
 	if len(files) == 0 {
 		return nil, fmt.Errorf("no files provided")
 	}
 	name := files[0]
-	lookupTemplates := []string{templateId, "_global"}
 
 	tmpl := template.New(name)
 	tmpl.Funcs(templateapi.TemplateFunctions)
@@ -188,7 +216,7 @@ func loadTemplate(templateId string, files []string, customFunctions template.Fu
 		tmpl.Funcs(customFunctions)
 	}
 	for _, f := range files {
-		err := loadTemplateById(tmpl, lookupTemplates, f)
+		err := loadTemplateById(tmpl, config, f)
 		if err != nil {
 			return nil, err
 		}
@@ -200,16 +228,14 @@ func loadTemplate(templateId string, files []string, customFunctions template.Fu
 	return nil, fmt.Errorf("neither embedded filesystem nor PRIMECODEGEN_TEMPLATE_DIR environment variable is set")
 }
 
-// loadTemplateById reads a template file from either the local filesystem or the embedded filesystem.
-//
-// It searches for the file in the given lookupTemplates directories, following the order of priority.
+// loadTemplateById reads a template file from either the local filesystem or the embedded filesystem and parses it into tmpl.
 //
 // Parameters:
-//   - lookupTemplates: A list of template directories to search in, ordered by priority.
+//   - config: The template config, see readTemplateFile for the lookup order.
 //   - templateFile: The name of the file to read.
-func loadTemplateById(tmpl *template.Template, lookupTemplates []string, templateFile string) error {
+func loadTemplateById(tmpl *template.Template, config templateapi.Config, templateFile string) error {
 	// read contents of the template file
-	content, err := readTemplateFile(lookupTemplates, templateFile)
+	content, err := readTemplateFile(config, templateFile)
 	if err != nil {
 		return err
 	}
@@ -225,12 +251,26 @@ func loadTemplateById(tmpl *template.Template, lookupTemplates []string, templat
 
 // readTemplateFile reads a template file from either the local filesystem or the embedded filesystem.
 //
-// It searches for the file in the given lookupTemplates directories, following the order of priority.
+// It searches for the file in the template directory (config.Dir), PRIMECODEGEN_TEMPLATE_DIR and the embedded templates, following the order of priority.
 //
 // Parameters:
-//   - lookupTemplates: A list of template directories to search in, ordered by priority.
+//   - config: The template config, the embedded lookup order is the template, the extended template and _global.
 //   - templateFile: The name of the file to read.
-func readTemplateFile(lookupTemplates []string, templateFile string) ([]byte, error) {
+func readTemplateFile(config templateapi.Config, templateFile string) ([]byte, error) {
+	lookupTemplates := []string{config.ID}
+	if config.Extends != "" {
+		lookupTemplates = append(lookupTemplates, config.Extends)
+	}
+	lookupTemplates = append(lookupTemplates, "_global")
+
+	// check user-supplied template directory, files in the directory override embedded files with the same name
+	if config.Dir != "" {
+		content, err := os.ReadFile(filepath.Join(config.Dir, templateFile))
+		if err == nil {
+			return content, nil
+		}
+	}
+
 	// check local filesystem (PRIMECODEGEN_TEMPLATE_DIR has priority to allow easy customization of templates)
 	templateDir := os.Getenv("PRIMECODEGEN_TEMPLATE_DIR")
 	if templateDir != "" {
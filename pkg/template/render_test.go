@@ -4,9 +4,11 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/primelib/primecodegen/pkg/template/templateapi"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRenderTemplateDryRun(t *testing.T) {
@@ -66,3 +68,68 @@ func TestRenderTemplateFile(t *testing.T) {
 	//fileKey := filepath.Join("models", "model.go")
 	//assert.Equal(t, FileRendered, files[fileKey].State)
 }
+
+func TestRenderTemplateUnchanged(t *testing.T) {
+	outputDir := t.TempDir()
+	config := templateapi.Config{
+		ID: "openapi-go-httpclient",
+		Files: []templateapi.File{
+			{
+				SourceTemplate:  "model.gohtml",
+				Snippets:        defaultSnippets,
+				TargetDirectory: "models",
+				TargetFileName:  "model.go",
+				Type:            templateapi.TypeModelEach,
+			},
+		},
+	}
+	data := map[string]string{"model": "User"}
+	fileKey := filepath.Join(outputDir, "models", "model.go")
+
+	files, err := RenderTemplate(config, outputDir, templateapi.TypeModelEach, data, templateapi.RenderOpts{})
+	require.NoError(t, err)
+	require.Equal(t, templateapi.FileRendered, files[fileKey].State)
+	require.NotEmpty(t, files[fileKey].Checksum)
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(fileKey, modTime, modTime))
+
+	// same content is not written again
+	checksums := map[string]templateapi.FileChecksum{fileKey: fileChecksumForTest(t, fileKey, files[fileKey].Checksum)}
+	files, err = RenderTemplate(config, outputDir, templateapi.TypeModelEach, data, templateapi.RenderOpts{Checksums: checksums})
+	require.NoError(t, err)
+	assert.Equal(t, templateapi.FileUnchanged, files[fileKey].State)
+	info, err := os.Stat(fileKey)
+	require.NoError(t, err)
+	assert.Equal(t, modTime, info.ModTime())
+
+	// files modified on disk are restored
+	require.NoError(t, os.WriteFile(fileKey, []byte("package models\n"), 0644))
+	files, err = RenderTemplate(config, outputDir, templateapi.TypeModelEach, data, templateapi.RenderOpts{Checksums: checksums})
+	require.NoError(t, err)
+	assert.Equal(t, templateapi.FileRendered, files[fileKey].State)
+	assert.Equal(t, checksums[fileKey].File, FileChecksum(readFileForTest(t, fileKey)))
+
+	// changed content is written
+	changed := func(name string, content []byte) []byte { return append(content, "// changed\n"...) }
+	files, err = RenderTemplate(config, outputDir, templateapi.TypeModelEach, data, templateapi.RenderOpts{Checksums: checksums, PostProcess: changed})
+	require.NoError(t, err)
+	assert.Equal(t, templateapi.FileRendered, files[fileKey].State)
+
+	// deleted files are written again
+	checksums = map[string]templateapi.FileChecksum{fileKey: fileChecksumForTest(t, fileKey, files[fileKey].Checksum)}
+	require.NoError(t, os.Remove(fileKey))
+	files, err = RenderTemplate(config, outputDir, templateapi.TypeModelEach, data, templateapi.RenderOpts{Checksums: checksums, PostProcess: changed})
+	require.NoError(t, err)
+	assert.Equal(t, templateapi.FileRendered, files[fileKey].State)
+	assert.FileExists(t, fileKey)
+}
+
+func readFileForTest(t *testing.T, file string) []byte {
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	return content
+}
+
+func fileChecksumForTest(t *testing.T, file string, rendered string) templateapi.FileChecksum {
+	return templateapi.FileChecksum{Rendered: rendered, File: FileChecksum(readFileForTest(t, file))}
+}
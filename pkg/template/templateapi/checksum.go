@@ -0,0 +1,18 @@
+package templateapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// FileChecksum is the checksum of the rendered content and of the file that was written to disk by the previous run
+type FileChecksum struct {
+	Rendered string // Rendered is the checksum of the template output
+	File     string // File is the checksum of the file on disk after formatting, without the content of custom regions
+}
+
+// Checksum returns the sha256 checksum of the content
+func Checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
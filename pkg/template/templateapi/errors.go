@@ -11,4 +11,7 @@ var (
 	ErrFailedToCopyTemplateFile     = errors.New("failed to copy the template file")
 	ErrFailedToDownloadTemplateFile = errors.New("failed to download the template file")
 	ErrTemplateFileOrUrlIsRequired  = errors.New("template has no source template or source url")
+	ErrInvalidTemplateDir           = errors.New("invalid template directory")
+	ErrInvalidTemplateManifest      = errors.New("invalid template manifest")
+	ErrInvalidCustomRegion          = errors.New("invalid custom region markers")
 )
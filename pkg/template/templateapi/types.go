@@ -12,12 +12,14 @@ type RenderOpts struct {
 	Properties           map[string]string                        // User-defined properties that can be used in the templates
 	PostProcess          func(name string, content []byte) []byte // PostProcess is a function that can be used to post-process file output
 	TemplateFunctions    template.FuncMap                         // TemplateFunctions is a map of additional functions that can be used in the templates
+	Checksums            map[string]FileChecksum                  // Checksums of the previous run by file, unchanged files that were not modified on disk are not written again
 }
 
 type RenderedFile struct {
 	File         string
 	TemplateFile string
 	State        FileState
+	Checksum     string // Checksum of the rendered content, before custom regions are restored and formatters run
 }
 
 type FileState string
@@ -27,12 +29,15 @@ const (
 	FileSkippedName  FileState = "skipped-by-name"
 	FileSkippedScope FileState = "skipped-by-scope"
 	FileRendered     FileState = "rendered"
+	FileUnchanged    FileState = "unchanged" // FileUnchanged is reported if the rendered content matches the previous run and the file on disk was not modified, the file is not written
 )
 
 type Config struct {
-	ID          string // ID is a unique identifier for the template, should be a combination of the spec type, generator and template name (openapi-go-client, asyncapi-java-client, etc.)
-	Description string // Description is a human-readable description, only used to list available templates
-	Files       []File // Files is a list of files that will be rendered
+	ID          string `yaml:"id"`                // ID is a unique identifier for the template, should be a combination of the spec type, generator and template name (openapi-go-client, asyncapi-java-client, etc.)
+	Description string `yaml:"description"`       // Description is a human-readable description, only used to list available templates
+	Extends     string `yaml:"extends,omitempty"` // Extends is the ID of an embedded template, files that are not found in the template are looked up in the extended template
+	Dir         string `yaml:"-"`                 // Dir is a directory on disk that is searched for template files before the embedded templates
+	Files       []File `yaml:"files"`             // Files is a list of files that will be rendered
 }
 
 func (c Config) FilesByType(t Type) []File {
@@ -46,16 +51,16 @@ func (c Config) FilesByType(t Type) []File {
 }
 
 type File struct {
-	Description     string   // Description is a human-readable description of the template
-	SourceTemplate  string   // SourceTemplate is the path to the template file
-	SourceFile      string   // SourceFile is the path to a file that will be copied as is
-	SourceUrl       string   // SourceUrl is the URL where the template or binary file can be downloaded from
-	Snippets        []string // Snippets is a list of paths to files that contain snippets that can be used in the template
-	TargetDirectory string   // TargetDirectory is the directory where the rendered file will be saved
-	TargetFileName  string   // TargetFileName contains the template for the file name
-	Type            Type     // Type is the type of the template
-	Kind            Kind     // Kind is the kind of the template, can be used to filter which templates to render
-	Category        []string // Category is a list of categories that the template belongs to, can be used to filter which templates to render
+	Description     string   `yaml:"description,omitempty"`     // Description is a human-readable description of the template
+	SourceTemplate  string   `yaml:"sourceTemplate,omitempty"`  // SourceTemplate is the path to the template file
+	SourceFile      string   `yaml:"sourceFile,omitempty"`      // SourceFile is the path to a file that will be copied as is
+	SourceUrl       string   `yaml:"sourceUrl,omitempty"`       // SourceUrl is the URL where the template or binary file can be downloaded from
+	Snippets        []string `yaml:"snippets,omitempty"`        // Snippets is a list of paths to files that contain snippets that can be used in the template
+	TargetDirectory string   `yaml:"targetDirectory,omitempty"` // TargetDirectory is the directory where the rendered file will be saved
+	TargetFileName  string   `yaml:"targetFileName"`            // TargetFileName contains the template for the file name
+	Type            Type     `yaml:"type"`                      // Type is the type of the template
+	Kind            Kind     `yaml:"kind,omitempty"`            // Kind is the kind of the template, can be used to filter which templates to render
+	Category        []string `yaml:"category,omitempty"`        // Category is a list of categories that the template belongs to, can be used to filter which templates to render
 	// TODO: allow to filter or transform template data per file
 }
 
@@ -67,9 +72,12 @@ const (
 	TypeOperationEach Type = "operation_each"
 	TypeModelEach     Type = "model_each"
 	TypeEnumEach      Type = "enum_each"
+	TypeWebhookEach   Type = "webhook_each"
 	TypeSupportOnce   Type = "support_once"
 )
 
+var AllTypes = []Type{TypeAPIOnce, TypeAPIEach, TypeOperationEach, TypeModelEach, TypeEnumEach, TypeWebhookEach, TypeSupportOnce}
+
 type Kind string
 
 const (
@@ -0,0 +1,86 @@
+package openapi_csharp_httpclient
+
+import (
+	"github.com/primelib/primecodegen/pkg/template/templateapi"
+)
+
+var Template = templateapi.Config{
+	ID:          "openapi-csharp-httpclient",
+	Description: "OpenAPI Client for C# (System.Net.Http)",
+	Files: []templateapi.File{
+		{
+			Description:     "client",
+			SourceTemplate:  "client.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src",
+			TargetFileName:  "{{ .Metadata.Name }}Client.cs",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindAPI,
+		},
+		{
+			Description:     "http runtime",
+			SourceTemplate:  "runtime.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src",
+			TargetFileName:  "ApiClient.cs",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindAPI,
+		},
+		{
+			Description:     "auth methods",
+			SourceTemplate:  "auth.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src/Auth",
+			TargetFileName:  "AuthMethods.cs",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindAPI,
+		},
+		{
+			Description:     "service per tag with all operations",
+			SourceTemplate:  "service.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src/Services",
+			TargetFileName:  "{{ .Service.Name | pascalCase }}Api.cs",
+			Type:            templateapi.TypeAPIEach,
+			Kind:            templateapi.KindAPI,
+		},
+		// models
+		{
+			Description:     "model file",
+			SourceTemplate:  "model.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src/Models",
+			TargetFileName:  "{{ .Name }}.cs",
+			Type:            templateapi.TypeModelEach,
+			Kind:            templateapi.KindModel,
+		},
+		{
+			Description:     "enum file",
+			SourceTemplate:  "enum.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src/Models",
+			TargetFileName:  "{{ .Name }}.cs",
+			Type:            templateapi.TypeEnumEach,
+			Kind:            templateapi.KindModel,
+		},
+		// support files - docs
+		{
+			Description:    "README.md",
+			SourceTemplate: "readme.gohtml",
+			Snippets:       templateapi.DefaultSnippets,
+			TargetFileName: "README.md",
+			Type:           templateapi.TypeSupportOnce,
+			Kind:           templateapi.KindDocumentation,
+		},
+		// support files - build system
+		{
+			Description:     "project file",
+			SourceTemplate:  "csproj.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src",
+			TargetFileName:  "{{ .Metadata.ArtifactId }}.csproj",
+			Type:            templateapi.TypeSupportOnce,
+			Kind:            templateapi.KindBuildSystem,
+		},
+	},
+}
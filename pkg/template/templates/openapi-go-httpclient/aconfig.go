@@ -35,6 +35,24 @@ var Template = templateapi.Config{
 			Type:            templateapi.TypeOperationEach,
 			Kind:            templateapi.KindAPI,
 		},
+		{
+			Description:     "typed api errors",
+			SourceTemplate:  "errors.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "pkgs/operations",
+			TargetFileName:  "errors.go",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindAPI,
+		},
+		{
+			Description:     "webhook and callback payload parser",
+			SourceTemplate:  "webhook.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "",
+			TargetFileName:  "webhook-{{ .Name }}.go",
+			Type:            templateapi.TypeWebhookEach,
+			Kind:            templateapi.KindAPI,
+		},
 		// models
 		{
 			Description:     "model file",
@@ -0,0 +1,68 @@
+package openapi_go_server
+
+import (
+	"github.com/primelib/primecodegen/pkg/template/templateapi"
+)
+
+var Template = templateapi.Config{
+	ID:          "openapi-go-server",
+	Description: "OpenAPI Server Stubs for Go (net/http)",
+	Extends:     "openapi-go-httpclient", // the model and enum templates are shared with the client
+	Files: []templateapi.File{
+		{
+			Description:     "server runtime",
+			SourceTemplate:  "server.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "server",
+			TargetFileName:  "server.go",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindAPI,
+		},
+		{
+			Description:     "handler interface, request decoding and router registration per tag",
+			SourceTemplate:  "service.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "server",
+			TargetFileName:  "service-{{ .Service.Name }}.go",
+			Type:            templateapi.TypeAPIEach,
+			Kind:            templateapi.KindAPI,
+		},
+		// models (templates of openapi-go-httpclient)
+		{
+			Description:     "model file",
+			SourceTemplate:  "model.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "pkgs/models",
+			TargetFileName:  "{{ .Name }}.go",
+			Type:            templateapi.TypeModelEach,
+			Kind:            templateapi.KindModel,
+		},
+		{
+			Description:     "model file",
+			SourceTemplate:  "enum.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "pkgs/enums",
+			TargetFileName:  "{{ .Name }}.go",
+			Type:            templateapi.TypeEnumEach,
+			Kind:            templateapi.KindModel,
+		},
+		// support files - docs
+		{
+			Description:    "README.md",
+			SourceTemplate: "readme.gohtml",
+			Snippets:       templateapi.DefaultSnippets,
+			TargetFileName: "README.md",
+			Type:           templateapi.TypeSupportOnce,
+			Kind:           templateapi.KindDocumentation,
+		},
+		// support files - go.mod
+		{
+			Description:    "go.mod",
+			SourceTemplate: "gomod.gohtml",
+			Snippets:       templateapi.DefaultSnippets,
+			TargetFileName: "go.mod",
+			Type:           templateapi.TypeSupportOnce,
+			Kind:           templateapi.KindBuildSystem,
+		},
+	},
+}
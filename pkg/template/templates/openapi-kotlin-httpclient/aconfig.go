@@ -132,6 +132,14 @@ var Template = templateapi.Config{
 			Type:            templateapi.TypeOperationEach,
 			Kind:            templateapi.KindAPI,
 		},
+		{
+			SourceTemplate:  "problem_details.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "core/src/commonMain/kotlin/{{ .Common.Packages.Responses | toFilePath }}",
+			TargetFileName:  "ProblemDetails.kt",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindAPI,
+		},
 		// core - auth
 		{
 			SourceTemplate:  "auth_api.gohtml",
@@ -0,0 +1,103 @@
+package openapi_python_httpclient
+
+import (
+	"github.com/primelib/primecodegen/pkg/template/templateapi"
+)
+
+var Template = templateapi.Config{
+	ID:          "openapi-python-httpclient",
+	Description: "OpenAPI Client for Python (httpx + pydantic)",
+	Files: []templateapi.File{
+		{
+			Description:     "package entrypoint",
+			SourceTemplate:  "init.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "{{ .Common.Packages.Root | toFilePath }}",
+			TargetFileName:  "__init__.py",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindAPI,
+		},
+		{
+			Description:     "sync and async client",
+			SourceTemplate:  "client.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "{{ .Common.Packages.Client | toFilePath }}",
+			TargetFileName:  "client.py",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindAPI,
+		},
+		{
+			Description:     "http runtime",
+			SourceTemplate:  "runtime.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "{{ .Common.Packages.Root | toFilePath }}",
+			TargetFileName:  "_runtime.py",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindAPI,
+		},
+		{
+			Description:     "auth methods",
+			SourceTemplate:  "auth.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "{{ .Common.Packages.Root | toFilePath }}",
+			TargetFileName:  "auth.py",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindAPI,
+		},
+		{
+			Description:     "service per tag with all operations",
+			SourceTemplate:  "service.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "{{ .Common.Packages.Operations | toFilePath }}",
+			TargetFileName:  "{{ .Service.Name | snakeCase }}.py",
+			Type:            templateapi.TypeAPIEach,
+			Kind:            templateapi.KindAPI,
+		},
+		{
+			Description:     "service index",
+			SourceTemplate:  "service-init.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "{{ .Common.Packages.Operations | toFilePath }}",
+			TargetFileName:  "__init__.py",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindAPI,
+		},
+		// models
+		{
+			Description:     "models and enums",
+			SourceTemplate:  "models.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "{{ .Common.Packages.Root | toFilePath }}",
+			TargetFileName:  "models.py",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindModel,
+		},
+		// support files - docs
+		{
+			Description:    "README.md",
+			SourceTemplate: "readme.gohtml",
+			Snippets:       templateapi.DefaultSnippets,
+			TargetFileName: "README.md",
+			Type:           templateapi.TypeSupportOnce,
+			Kind:           templateapi.KindDocumentation,
+		},
+		// support files - build system
+		{
+			Description:    "pyproject.toml",
+			SourceTemplate: "pyproject.gohtml",
+			Snippets:       templateapi.DefaultSnippets,
+			TargetFileName: "pyproject.toml",
+			Type:           templateapi.TypeSupportOnce,
+			Kind:           templateapi.KindBuildSystem,
+		},
+		{
+			Description:     "py.typed marker",
+			SourceTemplate:  "pytyped.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "{{ .Common.Packages.Root | toFilePath }}",
+			TargetFileName:  "py.typed",
+			Type:            templateapi.TypeSupportOnce,
+			Kind:            templateapi.KindBuildSystem,
+		},
+	},
+}
@@ -0,0 +1,112 @@
+package openapi_rust_httpclient
+
+import (
+	"github.com/primelib/primecodegen/pkg/template/templateapi"
+)
+
+var Template = templateapi.Config{
+	ID:          "openapi-rust-httpclient",
+	Description: "OpenAPI Client for Rust (reqwest)",
+	Files: []templateapi.File{
+		{
+			Description:     "crate root",
+			SourceTemplate:  "lib.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src",
+			TargetFileName:  "lib.rs",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindAPI,
+		},
+		{
+			Description:     "client",
+			SourceTemplate:  "client.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src",
+			TargetFileName:  "{{ .Common.Packages.Client }}.rs",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindAPI,
+		},
+		{
+			Description:     "errors and responses",
+			SourceTemplate:  "error.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src",
+			TargetFileName:  "error.rs",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindAPI,
+		},
+		{
+			Description:     "auth methods",
+			SourceTemplate:  "auth.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src",
+			TargetFileName:  "{{ .Common.Packages.Auth }}.rs",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindAPI,
+		},
+		{
+			Description:     "service per tag with all operations",
+			SourceTemplate:  "api.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src/{{ .Common.Packages.Operations }}",
+			TargetFileName:  "{{ .Service.Name | snakeCase }}.rs",
+			Type:            templateapi.TypeAPIEach,
+			Kind:            templateapi.KindAPI,
+		},
+		{
+			Description:     "service module",
+			SourceTemplate:  "api-mod.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src/{{ .Common.Packages.Operations }}",
+			TargetFileName:  "mod.rs",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindAPI,
+		},
+		// models
+		{
+			Description:     "model file",
+			SourceTemplate:  "model.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src/{{ .Common.Packages.Models }}",
+			TargetFileName:  "{{ .Name | snakeCase }}.rs",
+			Type:            templateapi.TypeModelEach,
+			Kind:            templateapi.KindModel,
+		},
+		{
+			Description:     "enum file",
+			SourceTemplate:  "enum.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src/{{ .Common.Packages.Enums }}",
+			TargetFileName:  "{{ .Name | snakeCase }}.rs",
+			Type:            templateapi.TypeEnumEach,
+			Kind:            templateapi.KindModel,
+		},
+		{
+			Description:     "model module",
+			SourceTemplate:  "model-mod.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src/{{ .Common.Packages.Models }}",
+			TargetFileName:  "mod.rs",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindModel,
+		},
+		// support files - docs
+		{
+			Description:    "README.md",
+			SourceTemplate: "readme.gohtml",
+			Snippets:       templateapi.DefaultSnippets,
+			TargetFileName: "README.md",
+			Type:           templateapi.TypeSupportOnce,
+			Kind:           templateapi.KindDocumentation,
+		},
+		// support files - build system
+		{
+			Description:    "Cargo.toml",
+			SourceTemplate: "cargo.gohtml",
+			Snippets:       templateapi.DefaultSnippets,
+			TargetFileName: "Cargo.toml",
+			Type:           templateapi.TypeSupportOnce,
+			Kind:           templateapi.KindBuildSystem,
+		},
+	},
+}
@@ -0,0 +1,120 @@
+package openapi_typescript_httpclient
+
+import (
+	"github.com/primelib/primecodegen/pkg/template/templateapi"
+)
+
+var Template = templateapi.Config{
+	ID:          "openapi-typescript-httpclient",
+	Description: "OpenAPI Client for TypeScript (fetch)",
+	Files: []templateapi.File{
+		{
+			Description:     "client",
+			SourceTemplate:  "client.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src",
+			TargetFileName:  "{{ .Common.Packages.Client }}.ts",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindAPI,
+		},
+		{
+			Description:     "http runtime",
+			SourceTemplate:  "runtime.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src",
+			TargetFileName:  "runtime.ts",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindAPI,
+		},
+		{
+			Description:     "auth methods",
+			SourceTemplate:  "auth.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src",
+			TargetFileName:  "{{ .Common.Packages.Auth }}.ts",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindAPI,
+		},
+		{
+			Description:     "package entrypoint",
+			SourceTemplate:  "index.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src",
+			TargetFileName:  "index.ts",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindAPI,
+		},
+		{
+			Description:     "service per tag with all operations",
+			SourceTemplate:  "service.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src/{{ .Common.Packages.Operations }}",
+			TargetFileName:  "{{ .Service.Name | pascalCase }}Api.ts",
+			Type:            templateapi.TypeAPIEach,
+			Kind:            templateapi.KindAPI,
+		},
+		{
+			Description:     "service index",
+			SourceTemplate:  "service-index.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src/{{ .Common.Packages.Operations }}",
+			TargetFileName:  "index.ts",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindAPI,
+		},
+		// models
+		{
+			Description:     "model file",
+			SourceTemplate:  "model.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src/{{ .Common.Packages.Models }}",
+			TargetFileName:  "{{ .Name }}.ts",
+			Type:            templateapi.TypeModelEach,
+			Kind:            templateapi.KindModel,
+		},
+		{
+			Description:     "enum file",
+			SourceTemplate:  "enum.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src/{{ .Common.Packages.Enums }}",
+			TargetFileName:  "{{ .Name }}.ts",
+			Type:            templateapi.TypeEnumEach,
+			Kind:            templateapi.KindModel,
+		},
+		{
+			Description:     "model index",
+			SourceTemplate:  "model-index.gohtml",
+			Snippets:        templateapi.DefaultSnippets,
+			TargetDirectory: "src/{{ .Common.Packages.Models }}",
+			TargetFileName:  "index.ts",
+			Type:            templateapi.TypeAPIOnce,
+			Kind:            templateapi.KindModel,
+		},
+		// support files - docs
+		{
+			Description:    "README.md",
+			SourceTemplate: "readme.gohtml",
+			Snippets:       templateapi.DefaultSnippets,
+			TargetFileName: "README.md",
+			Type:           templateapi.TypeSupportOnce,
+			Kind:           templateapi.KindDocumentation,
+		},
+		// support files - build system
+		{
+			Description:    "package.json",
+			SourceTemplate: "packagejson.gohtml",
+			Snippets:       templateapi.DefaultSnippets,
+			TargetFileName: "package.json",
+			Type:           templateapi.TypeSupportOnce,
+			Kind:           templateapi.KindBuildSystem,
+		},
+		{
+			Description:    "tsconfig.json",
+			SourceTemplate: "tsconfig.gohtml",
+			Snippets:       templateapi.DefaultSnippets,
+			TargetFileName: "tsconfig.json",
+			Type:           templateapi.TypeSupportOnce,
+			Kind:           templateapi.KindBuildSystem,
+		},
+	},
+}